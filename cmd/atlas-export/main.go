@@ -0,0 +1,130 @@
+// atlas-export reads the existing Atlas projects and prints the Atlas Custom Resources describing them, so the
+// projects can be adopted by the Operator. The Atlas API keys are read from the ATLAS_PUBLIC_KEY and
+// ATLAS_PRIVATE_KEY environment variables.
+//
+//	atlas-export --org-id=<org> [--project-id=<project>] --namespace=atlas > atlas.yaml
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/export"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/httputil"
+)
+
+type config struct {
+	AtlasDomain          string
+	OrgID                string
+	ProjectID            string
+	Output               string
+	Namespace            string
+	ConnectionSecretName string
+	ReconciliationPolicy string
+}
+
+func main() {
+	config := parseConfiguration()
+	logger := setupLogger()
+
+	if err := run(config, logger); err != nil {
+		logger.Errorf("Failed to export the Atlas resources: %s", err)
+		os.Exit(1)
+	}
+}
+
+func run(config config, logger *zap.SugaredLogger) error {
+	connection := atlas.Connection{
+		OrgID:      config.OrgID,
+		PublicKey:  os.Getenv("ATLAS_PUBLIC_KEY"),
+		PrivateKey: os.Getenv("ATLAS_PRIVATE_KEY"),
+	}
+	if connection.PublicKey == "" || connection.PrivateKey == "" {
+		return errors.New("the ATLAS_PUBLIC_KEY and ATLAS_PRIVATE_KEY environment variables must be set")
+	}
+
+	// the export never changes anything in Atlas
+	atlasClient, err := atlas.Client(config.AtlasDomain, connection, logger, httputil.ReadOnly())
+	if err != nil {
+		return err
+	}
+
+	exporter := export.NewExporter(atlasClient, export.Options{
+		Namespace:            config.Namespace,
+		ConnectionSecretName: config.ConnectionSecretName,
+		ReconciliationPolicy: config.ReconciliationPolicy,
+	}, logger)
+
+	var objects []client.Object
+	if config.ProjectID != "" {
+		objects, err = exporter.Project(context.Background(), config.ProjectID)
+	} else {
+		objects, err = exporter.Organization(context.Background(), config.OrgID)
+	}
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if config.Output != "-" {
+		file, err := os.Create(config.Output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	if err := export.WriteManifests(out, objects); err != nil {
+		return err
+	}
+	logger.Infof("Exported %d resources", len(objects))
+	return nil
+}
+
+func parseConfiguration() config {
+	config := config{}
+	flag.StringVar(&config.AtlasDomain, "atlas-domain", "https://cloud.mongodb.com/", "the Atlas URL domain name (with slash in the end).")
+	flag.StringVar(&config.OrgID, "org-id", "", "The Atlas organization all the projects of which are exported.")
+	flag.StringVar(&config.ProjectID, "project-id", "", "The ID of the single Atlas project to export instead of the whole organization.")
+	flag.StringVar(&config.Output, "output", "-", "The file the manifests are written to, '-' for the standard output.")
+	flag.StringVar(&config.Namespace, "namespace", "default", "The namespace of the exported resources.")
+	flag.StringVar(&config.ConnectionSecretName, "connection-secret", "", "The Secret with the Atlas API keys referenced by the AtlasProjects. "+
+		"The global Operator Secret is used if not specified.")
+	flag.StringVar(&config.ReconciliationPolicy, "reconciliation-policy", "", "The reconciliation policy annotation set to the exported resources: "+
+		"skip | plan | observe. Not set by default.")
+	flag.Parse()
+
+	if config.OrgID == "" && config.ProjectID == "" {
+		fmt.Fprintln(os.Stderr, "either --org-id or --project-id must be specified")
+		os.Exit(2)
+	}
+	switch config.ReconciliationPolicy {
+	case "", customresource.ReconciliationPolicySkip, customresource.ReconciliationPolicyPlan, customresource.ReconciliationPolicyObserve:
+	default:
+		fmt.Fprintf(os.Stderr, "unknown reconciliation policy %q\n", config.ReconciliationPolicy)
+		os.Exit(2)
+	}
+	return config
+}
+
+// setupLogger writes the logs to the standard error so they don't mix with the manifests
+func setupLogger() *zap.SugaredLogger {
+	logConfig := zap.NewDevelopmentConfig()
+	logConfig.OutputPaths = []string{"stderr"}
+	log, err := logConfig.Build()
+	if err != nil {
+		zap.S().Errorf("Error building logger config: %s", err)
+		os.Exit(1)
+	}
+
+	return log.Sugar()
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
@@ -40,16 +41,23 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	mdbv1beta1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1beta1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlasaccessrequest"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlascluster"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlasdatabaseuser"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlasproject"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/metrics"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/tracing"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/watch"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/webhook"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -65,6 +73,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(mdbv1.AddToScheme(scheme))
+	utilruntime.Must(mdbv1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 
 	atlas.ProductVersion = version
@@ -89,7 +98,19 @@ func main() {
 
 	logger.Sugar().Infof("MongoDB Atlas Operator version %s", version)
 
-	syncPeriod := time.Hour * 3
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Endpoint:    config.TracingEndpoint,
+		Insecure:    config.TracingInsecure,
+		SampleRatio: config.TracingSampleRatio,
+		Version:     version,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
+	workflow.SetRetryPolicy(config.RetryPolicy)
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     config.MetricsAddr,
@@ -98,7 +119,7 @@ func main() {
 		HealthProbeBindAddress: config.ProbeAddr,
 		LeaderElection:         config.EnableLeaderElection,
 		LeaderElectionID:       "06d035fb.mongodb.com",
-		SyncPeriod:             &syncPeriod,
+		SyncPeriod:             &config.SyncPeriod,
 		NewCache: cache.BuilderWithOptions(cache.Options{
 			SelectorsByObject: cache.SelectorsByObject{
 				&corev1.Secret{}: {
@@ -122,47 +143,86 @@ func main() {
 		watch.SelectNamespacesPredicate(config.WatchedNamespaces), // select only desired namespaces
 	}
 
+	// atlasClients is shared by the controllers so that the Atlas clients are reused for the same connection Secret
+	atlasClients := atlas.NewClientCache(config.AtlasDomain, logger.Named("atlas").Sugar())
+
+	if err = ctrlmetrics.Registry.Register(metrics.NewResourceCollector(mgr.GetClient(), logger.Named("metrics").Sugar())); err != nil {
+		setupLog.Error(err, "unable to register metrics collector")
+		os.Exit(1)
+	}
+
 	if err = (&atlascluster.AtlasClusterReconciler{
-		Client:           mgr.GetClient(),
-		Log:              logger.Named("controllers").Named("AtlasCluster").Sugar(),
-		Scheme:           mgr.GetScheme(),
-		AtlasDomain:      config.AtlasDomain,
-		GlobalAPISecret:  config.GlobalAPISecret,
-		ResourceWatcher:  watch.NewResourceWatcher(),
-		GlobalPredicates: globalPredicates,
-		EventRecorder:    mgr.GetEventRecorderFor("AtlasCluster"),
+		Client:                  mgr.GetClient(),
+		Log:                     logger.Named("controllers").Named("AtlasCluster").Sugar(),
+		Scheme:                  mgr.GetScheme(),
+		AtlasClients:            atlasClients,
+		GlobalAPISecret:         config.GlobalAPISecret,
+		ResourceWatcher:         watch.NewResourceWatcher(),
+		GlobalPredicates:        globalPredicates,
+		EventRecorder:           mgr.GetEventRecorderFor("AtlasCluster"),
+		MaxConcurrentReconciles: config.ClusterConcurrency,
+		PlanMode:                config.PlanMode,
+		RequireChangeApproval:   config.RequireChangeApproval,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AtlasCluster")
 		os.Exit(1)
 	}
 
 	if err = (&atlasproject.AtlasProjectReconciler{
-		Client:           mgr.GetClient(),
-		Log:              logger.Named("controllers").Named("AtlasProject").Sugar(),
-		Scheme:           mgr.GetScheme(),
-		AtlasDomain:      config.AtlasDomain,
-		ResourceWatcher:  watch.NewResourceWatcher(),
-		GlobalAPISecret:  config.GlobalAPISecret,
-		GlobalPredicates: globalPredicates,
-		EventRecorder:    mgr.GetEventRecorderFor("AtlasProject"),
+		Client:                  mgr.GetClient(),
+		Log:                     logger.Named("controllers").Named("AtlasProject").Sugar(),
+		Scheme:                  mgr.GetScheme(),
+		AtlasClients:            atlasClients,
+		ResourceWatcher:         watch.NewResourceWatcher(),
+		GlobalAPISecret:         config.GlobalAPISecret,
+		GlobalPredicates:        globalPredicates,
+		EventRecorder:           mgr.GetEventRecorderFor("AtlasProject"),
+		MaxConcurrentReconciles: config.ProjectConcurrency,
+		PlanMode:                config.PlanMode,
+		WatchNodes:              config.Namespace == "",
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AtlasProject")
 		os.Exit(1)
 	}
 
 	if err = (&atlasdatabaseuser.AtlasDatabaseUserReconciler{
-		Client:           mgr.GetClient(),
-		Log:              logger.Named("controllers").Named("AtlasDatabaseUser").Sugar(),
-		Scheme:           mgr.GetScheme(),
-		AtlasDomain:      config.AtlasDomain,
-		ResourceWatcher:  watch.NewResourceWatcher(),
-		GlobalAPISecret:  config.GlobalAPISecret,
-		GlobalPredicates: globalPredicates,
-		EventRecorder:    mgr.GetEventRecorderFor("AtlasDatabaseUser"),
+		Client:                  mgr.GetClient(),
+		Log:                     logger.Named("controllers").Named("AtlasDatabaseUser").Sugar(),
+		Scheme:                  mgr.GetScheme(),
+		AtlasClients:            atlasClients,
+		ResourceWatcher:         watch.NewResourceWatcher(),
+		GlobalAPISecret:         config.GlobalAPISecret,
+		GlobalPredicates:        globalPredicates,
+		EventRecorder:           mgr.GetEventRecorderFor("AtlasDatabaseUser"),
+		MaxConcurrentReconciles: config.DatabaseUserConcurrency,
+		PlanMode:                config.PlanMode,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AtlasDatabaseUser")
 		os.Exit(1)
 	}
+
+	if err = (&atlasaccessrequest.AtlasAccessRequestReconciler{
+		Client:                  mgr.GetClient(),
+		Log:                     logger.Named("controllers").Named("AtlasAccessRequest").Sugar(),
+		Scheme:                  mgr.GetScheme(),
+		AtlasClients:            atlasClients,
+		ResourceWatcher:         watch.NewResourceWatcher(),
+		GlobalAPISecret:         config.GlobalAPISecret,
+		GlobalPredicates:        globalPredicates,
+		EventRecorder:           mgr.GetEventRecorderFor("AtlasAccessRequest"),
+		MaxConcurrentReconciles: config.AccessRequestConcurrency,
+		PlanMode:                config.PlanMode,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AtlasAccessRequest")
+		os.Exit(1)
+	}
+
+	if config.EnableWebhooks {
+		if err = webhook.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhooks")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("health", healthz.Ping); err != nil {
@@ -173,24 +233,58 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if config.AtlasReadinessCheck {
+		// The results of the individual checks are available at /readyz/<check> and /readyz?verbose
+		readinessChecker := atlas.NewReadinessChecker(atlasClients, mgr.GetAPIReader(), config.GlobalAPISecret, logger.Named("readiness").Sugar())
+		if err := mgr.Add(readinessChecker); err != nil {
+			setupLog.Error(err, "unable to set up Atlas readiness checker")
+			os.Exit(1)
+		}
+		if err := mgr.AddReadyzCheck("atlas-credentials", readinessChecker.CredentialsCheck); err != nil {
+			setupLog.Error(err, "unable to set up ready check")
+			os.Exit(1)
+		}
+		if err := mgr.AddReadyzCheck("atlas-api", readinessChecker.AtlasCheck); err != nil {
+			setupLog.Error(err, "unable to set up ready check")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+		setupLog.Error(shutdownErr, "unable to flush traces")
+	}
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
 
 type Config struct {
-	AtlasDomain          string
-	EnableLeaderElection bool
-	MetricsAddr          string
-	Namespace            string
-	WatchedNamespaces    map[string]bool
-	ProbeAddr            string
-	GlobalAPISecret      client.ObjectKey
-	LogLevel             string
-	LogEncoder           string
+	AtlasDomain           string
+	EnableLeaderElection  bool
+	MetricsAddr           string
+	Namespace             string
+	WatchedNamespaces     map[string]bool
+	ProbeAddr             string
+	GlobalAPISecret       client.ObjectKey
+	LogLevel              string
+	LogEncoder            string
+	EnableWebhooks        bool
+	TracingEndpoint       string
+	TracingInsecure       bool
+	TracingSampleRatio    float64
+	RetryPolicy           workflow.RetryPolicy
+	SyncPeriod            time.Duration
+	AtlasReadinessCheck   bool
+	PlanMode              bool
+	RequireChangeApproval bool
+	// The number of the resources of each kind reconciled in parallel
+	ClusterConcurrency       int
+	ProjectConcurrency       int
+	DatabaseUserConcurrency  int
+	AccessRequestConcurrency int
 }
 
 // ParseConfiguration fills the 'OperatorConfig' from the flags passed to the program
@@ -207,6 +301,27 @@ func parseConfiguration() Config {
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&config.LogLevel, "log-level", "info", "Log level. Available values: debug | info | warn | error | dpanic | panic | fatal")
 	flag.StringVar(&config.LogEncoder, "log-encoder", "json", "Log encoder. Available values: json | console")
+	flag.BoolVar(&config.EnableWebhooks, "enable-webhooks", false, "Enable the admission webhooks validating and defaulting the Atlas Custom Resources. "+
+		"Requires the TLS certificate for the webhook server to be mounted to the Operator Pod.")
+	flag.StringVar(&config.TracingEndpoint, "otlp-endpoint", "", "The host:port of the OTLP HTTP receiver the traces are exported to. The tracing is disabled if not specified.")
+	flag.BoolVar(&config.TracingInsecure, "otlp-insecure", false, "Disable TLS for the connection to the OTLP receiver.")
+	flag.Float64Var(&config.TracingSampleRatio, "trace-sample-ratio", 1, "The share of the reconciliations traced, from 0 to 1.")
+	flag.BoolVar(&config.AtlasReadinessCheck, "atlas-readiness-check", true, "Report the Operator as not ready until the global Secret contains the Atlas API keys and Atlas accepts them. "+
+		"Should be disabled if all the AtlasProjects reference their own connection Secrets.")
+	flag.BoolVar(&config.PlanMode, "plan-mode", false, "Only compute the changes to Atlas and report them in the status of the resources without applying them. "+
+		"The single resources can be reconciled in the plan mode with the annotation mongodb.com/atlas-reconciliation-policy=plan.")
+	flag.BoolVar(&config.RequireChangeApproval, "require-change-approval", false, "Hold the risky AtlasCluster changes (instance size downgrade, disk shrink, "+
+		"MongoDB major version change, shards reduction, disabling backups) until they are approved with the annotation mongodb.com/atlas-changes-approved. "+
+		"The single clusters can require the approval with the annotation mongodb.com/atlas-change-approval-policy=required.")
+	flag.DurationVar(&config.SyncPeriod, "sync-period", time.Hour*3, "The interval all the Atlas Custom Resources are reconciled with even if they haven't changed.")
+	flag.IntVar(&config.ClusterConcurrency, "cluster-max-concurrent-reconciles", 1, "The number of the AtlasClusters reconciled in parallel.")
+	flag.IntVar(&config.ProjectConcurrency, "project-max-concurrent-reconciles", 1, "The number of the AtlasProjects reconciled in parallel.")
+	flag.IntVar(&config.DatabaseUserConcurrency, "database-user-max-concurrent-reconciles", 1, "The number of the AtlasDatabaseUsers reconciled in parallel.")
+	flag.IntVar(&config.AccessRequestConcurrency, "access-request-max-concurrent-reconciles", 1, "The number of the AtlasAccessRequests reconciled in parallel.")
+	defaultRetryPolicy := workflow.DefaultRetryPolicy()
+	flag.DurationVar(&config.RetryPolicy.PollInterval, "poll-interval", defaultRetryPolicy.PollInterval, "The interval the state of the long-running Atlas operations (like the cluster creation) is checked with.")
+	flag.DurationVar(&config.RetryPolicy.MinBackoff, "retry-min-backoff", defaultRetryPolicy.MinBackoff, "The delay before retrying the reconciliation failed with a transient error. It doubles after each next failure.")
+	flag.DurationVar(&config.RetryPolicy.MaxBackoff, "retry-max-backoff", defaultRetryPolicy.MaxBackoff, "The maximum delay before retrying the reconciliation failed with a transient error.")
 	appVersion := flag.Bool("v", false, "prints application version")
 	flag.Parse()
 
@@ -215,6 +330,13 @@ func parseConfiguration() Config {
 		os.Exit(0)
 	}
 
+	if config.ClusterConcurrency < 1 || config.ProjectConcurrency < 1 || config.DatabaseUserConcurrency < 1 || config.AccessRequestConcurrency < 1 {
+		log.Fatal("the number of the resources reconciled in parallel must be positive")
+	}
+	if config.RetryPolicy.MinBackoff > config.RetryPolicy.MaxBackoff {
+		log.Fatalf("--retry-min-backoff (%s) must not be greater than --retry-max-backoff (%s)", config.RetryPolicy.MinBackoff, config.RetryPolicy.MaxBackoff)
+	}
+
 	config.GlobalAPISecret = operatorGlobalKeySecretOrDefault(globalAPISecretName)
 
 	// dev note: we pass the watched namespace as the env variable to use the Kubernetes Downward API. Unfortunately
@@ -0,0 +1,152 @@
+/*
+Copyright (C) MongoDB, Inc. 2020-present.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may
+not use this file except in compliance with the License. You may obtain
+a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package v1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+func init() {
+	SchemeBuilder.Register(&AtlasAccessRequest{}, &AtlasAccessRequestList{})
+}
+
+// AtlasAccessRequestSpec defines the time-boxed access to the databases of the Atlas project. The Operator creates
+// the temporary database user and the IP Access List entry for the requester and removes them once the access expires.
+type AtlasAccessRequestSpec struct {
+	// Project is a reference to AtlasProject resource the access is requested for
+	Project ResourceRefNamespaced `json:"projectRef"`
+
+	// RequesterIP is the IP address or the CIDR block the access is requested from. It's added to the project IP Access
+	// List until the access expires.
+	RequesterIP string `json:"requesterIp"`
+
+	// Roles is an array of the roles of the temporary database user and the databases / collections on which the roles
+	// apply.
+	// +kubebuilder:validation:MinItems=1
+	Roles []RoleSpec `json:"roles"`
+
+	// Duration of the access, for example "2h". The access expires once the duration passes since the creation of the
+	// resource. The duration must not exceed one week.
+	Duration metav1.Duration `json:"duration"`
+
+	// Reason describes why the access is requested. It's reported in the Events for auditing.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Requester IP",type=string,JSONPath=`.spec.requesterIp`
+// +kubebuilder:printcolumn:name="Expires At",type=string,JSONPath=`.status.expiresAt`
+// +kubebuilder:printcolumn:name="Expired",type=boolean,JSONPath=`.status.expired`
+// +kubebuilder:subresource:status
+// +groupName:=atlas.mongodb.com
+
+// AtlasAccessRequest is the Schema for the Atlas temporary access API
+type AtlasAccessRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AtlasAccessRequestSpec          `json:"spec,omitempty"`
+	Status status.AtlasAccessRequestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AtlasAccessRequestList contains a list of AtlasAccessRequest
+type AtlasAccessRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AtlasAccessRequest `json:"items"`
+}
+
+var _ AtlasCustomResource = &AtlasAccessRequest{}
+
+func (p AtlasAccessRequest) AtlasProjectObjectKey() client.ObjectKey {
+	ns := p.Namespace
+	if p.Spec.Project.Namespace != "" {
+		ns = p.Spec.Project.Namespace
+	}
+	return kube.ObjectKey(ns, p.Spec.Project.Name)
+}
+
+// ExpiresAt returns the time the access expires at. It's counted from the creation of the resource so that it doesn't
+// depend on when the resource is reconciled.
+func (p AtlasAccessRequest) ExpiresAt() time.Time {
+	return p.CreationTimestamp.Add(p.Spec.Duration.Duration).UTC()
+}
+
+// Username returns the name of the temporary database user. The UID makes it unique among the requests with the same
+// name in different namespaces or recreated with the same name.
+func (p AtlasAccessRequest) Username() string {
+	uid := string(p.UID)
+	if len(uid) > 8 {
+		uid = uid[:8]
+	}
+	return p.Name + "-" + uid
+}
+
+// CredentialsSecretName returns the name of the Secret the credentials of the temporary database user are stored in.
+func (p AtlasAccessRequest) CredentialsSecretName() string {
+	return p.Name + "-credentials"
+}
+
+func (p *AtlasAccessRequest) GetStatus() status.Status {
+	return p.Status
+}
+
+func (p *AtlasAccessRequest) UpdateStatus(conditions []status.Condition, options ...status.Option) {
+	p.Status.Conditions = conditions
+	p.Status.ObservedGeneration = p.ObjectMeta.Generation
+	// The planned changes are reported only by the reconciliation in the plan mode
+	p.Status.PlannedChanges = nil
+
+	for _, o := range options {
+		if common, ok := o.(status.CommonStatusOption); ok {
+			common(&p.Status.Common)
+			continue
+		}
+		// This will fail if the Option passed is incorrect - which is expected
+		v := o.(status.AtlasAccessRequestStatusOption)
+		v(&p.Status)
+	}
+}
+
+// ************************************ Builder methods *************************************************
+
+func NewAccessRequest(namespace, name, projectName, requesterIP string, duration time.Duration) *AtlasAccessRequest {
+	return &AtlasAccessRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: AtlasAccessRequestSpec{
+			Project:     ResourceRefNamespaced{Name: projectName},
+			RequesterIP: requesterIP,
+			Roles:       []RoleSpec{},
+			Duration:    metav1.Duration{Duration: duration},
+		},
+	}
+}
+
+func (p *AtlasAccessRequest) WithRole(roleName, databaseName, collectionName string) *AtlasAccessRequest {
+	p.Spec.Roles = append(p.Spec.Roles, RoleSpec{RoleName: roleName, DatabaseName: databaseName, CollectionName: collectionName})
+	return p
+}
+
+func (p *AtlasAccessRequest) WithReason(reason string) *AtlasAccessRequest {
+	p.Spec.Reason = reason
+	return p
+}
@@ -0,0 +1,5 @@
+package v1
+
+// Hub marks the v1 AtlasCluster as the conversion hub: the other versions are converted to and from v1
+// which is the storage version and the one the Operator works with.
+func (*AtlasCluster) Hub() {}
@@ -17,7 +17,9 @@ limitations under the License.
 package v1
 
 import (
+	"fmt"
 	"reflect"
+	"strconv"
 
 	"go.mongodb.org/atlas/mongodbatlas"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -110,6 +112,11 @@ type ClusterSpec struct {
 	// Configuration for cluster regions.
 	// +optional
 	ReplicationSpecs []ReplicationSpec `json:"replicationSpecs,omitempty"`
+
+	// Flag that indicates whether termination protection is enabled on the cluster. If set to true, Atlas won't
+	// delete the cluster and the Operator won't remove the AtlasCluster resource until the deletion is confirmed.
+	// +optional
+	TerminationProtectionEnabled *bool `json:"terminationProtectionEnabled,omitempty"`
 }
 
 // AtlasClusterSpec defines the desired state of AtlasCluster
@@ -143,9 +150,64 @@ type ServerlessSpec struct {
 	// Name of the cluster as it appears in Atlas. After Atlas creates the cluster, you can't change its name.
 	Name string `json:"name"`
 	// Configuration for the provisioned hosts on which MongoDB runs. The available options are specific to the cloud service provider.
+	// Atlas can't move an existing serverless instance to another provider or region, so these settings can't be changed after creation.
 	ProviderSettings *ProviderSettingsSpec `json:"providerSettings"`
+
+	// Flag that indicates whether termination protection is enabled on the serverless instance.
+	// If set to true, Atlas won't delete the serverless instance.
+	// +optional
+	TerminationProtectionEnabled *bool `json:"terminationProtectionEnabled,omitempty"`
+
+	// Serverless backup options.
+	// +optional
+	BackupOptions *ServerlessBackupOptions `json:"backupOptions,omitempty"`
+
+	// Key-value pairs that tag and categorize the serverless instance.
+	// +optional
+	Tags []TagSpec `json:"tags,omitempty"`
+
+	// List of private endpoints configured for the serverless instance.
+	// Private endpoints are supported only for the AWS and AZURE backing providers.
+	// The Operator doesn't manage the private endpoints if the list is omitted. Only the private endpoints created by
+	// the Operator are deleted once they are removed from the list.
+	// +optional
+	PrivateEndpoints []ServerlessPrivateEndpoint `json:"privateEndpoints,omitempty"`
+}
+
+// ServerlessPrivateEndpoint configures the private endpoint connection to the serverless instance.
+type ServerlessPrivateEndpoint struct {
+	// Name is the human-readable label that identifies the private endpoint. It's stored as the private endpoint
+	// comment in Atlas and must be unique for the serverless instance.
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+
+	// Unique identifier of the private endpoint you created in your AWS VPC or Azure VNet.
+	// Should be specified once Atlas creates the private endpoint service (see status.serverlessPrivateEndpoints).
+	// +optional
+	CloudProviderEndpointID string `json:"cloudProviderEndpointId,omitempty"`
+
+	// IPv4 address of the private endpoint in your Azure VNet.
+	// Required only for the AZURE backing provider.
+	// +optional
+	PrivateEndpointIPAddress string `json:"privateEndpointIpAddress,omitempty"`
+}
+
+// Identifier is required to satisfy "Identifiable" interface
+func (pe ServerlessPrivateEndpoint) Identifier() interface{} {
+	return pe.Name
+}
+
+// ServerlessBackupOptions configures the backup of the serverless instance.
+type ServerlessBackupOptions struct {
+	// Flag that indicates whether the serverless instance uses Serverless Continuous Backup.
+	// If this parameter is false, the serverless instance uses Basic Backup.
+	// +optional
+	ServerlessContinuousBackupEnabled *bool `json:"serverlessContinuousBackupEnabled,omitempty"`
 }
 
+// Check compatibility with library type.
+var _ = ServerlessBackupOptions(mongodbatlas.ServerlessBackupOptions{})
+
 type AdvancedClusterSpec struct {
 	BackupEnabled            *bool                      `json:"backupEnabled,omitempty"`
 	BiConnector              *BiConnectorSpec           `json:"biConnector,omitempty"`
@@ -166,6 +228,11 @@ type AdvancedClusterSpec struct {
 	CreateDate               string                     `json:"createDate,omitempty"`
 	RootCertType             string                     `json:"rootCertType,omitempty"`
 	VersionReleaseSystem     string                     `json:"versionReleaseSystem,omitempty"`
+
+	// Flag that indicates whether termination protection is enabled on the cluster. If set to true, Atlas won't
+	// delete the cluster and the Operator won't remove the AtlasCluster resource until the deletion is confirmed.
+	// +optional
+	TerminationProtectionEnabled *bool `json:"terminationProtectionEnabled,omitempty"`
 }
 
 // AdvancedCluster converts the AdvancedClusterSpec to native Atlas client AdvancedCluster format.
@@ -268,10 +335,54 @@ type ComputeSpec struct {
 	MaxInstanceSize string `json:"maxInstanceSize,omitempty"`
 }
 
-type ProcessArgs mongodbatlas.ProcessArgs
+// ProcessArgs configures Advanced Configuration Options of the cluster.
+// Mirrors mongodbatlas.ProcessArgs except for floating point values that are passed as strings
+// as CRDs don't support floats well.
+type ProcessArgs struct {
+	DefaultReadConcern               string `json:"defaultReadConcern,omitempty"`
+	DefaultWriteConcern              string `json:"defaultWriteConcern,omitempty"`
+	MinimumEnabledTLSProtocol        string `json:"minimumEnabledTlsProtocol,omitempty"`
+	FailIndexKeyTooLong              *bool  `json:"failIndexKeyTooLong,omitempty"`
+	JavascriptEnabled                *bool  `json:"javascriptEnabled,omitempty"`
+	NoTableScan                      *bool  `json:"noTableScan,omitempty"`
+	OplogSizeMB                      *int64 `json:"oplogSizeMB,omitempty"`
+	SampleSizeBIConnector            *int64 `json:"sampleSizeBIConnector,omitempty"`
+	SampleRefreshIntervalBIConnector *int64 `json:"sampleRefreshIntervalBIConnector,omitempty"`
+
+	// Minimum retention window for cluster's oplog expressed in hours (a decimal number, e.g. "1.5").
+	// +kubebuilder:validation:Pattern=^\d+(\.\d+)?$
+	// +optional
+	OplogMinRetentionHours string `json:"oplogMinRetentionHours,omitempty"`
+}
+
+// ToAtlas converts the ProcessArgs to native Atlas client format.
+func (specArgs ProcessArgs) ToAtlas() (*mongodbatlas.ProcessArgs, error) {
+	oplogMinRetentionHours := specArgs.OplogMinRetentionHours
+	specArgs.OplogMinRetentionHours = ""
+
+	result := &mongodbatlas.ProcessArgs{}
+	if err := compat.JSONCopy(result, specArgs); err != nil {
+		return nil, err
+	}
+
+	if oplogMinRetentionHours != "" {
+		hours, err := strconv.ParseFloat(oplogMinRetentionHours, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse oplogMinRetentionHours %q: %w", oplogMinRetentionHours, err)
+		}
+		result.OplogMinRetentionHours = &hours
+	}
+
+	return result, nil
+}
 
 func (specArgs ProcessArgs) IsEqual(newArgs interface{}) bool {
-	specV := reflect.ValueOf(specArgs)
+	atlasArgs, err := specArgs.ToAtlas()
+	if err != nil {
+		return false
+	}
+
+	specV := reflect.ValueOf(*atlasArgs)
 	newV := reflect.Indirect(reflect.ValueOf(newArgs))
 	typeOfSpec := specV.Type()
 	for i := 0; i < specV.NumField(); i++ {
@@ -420,6 +531,7 @@ func (spec *AtlasClusterSpec) Cluster() (*mongodbatlas.Cluster, error) {
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 
 // AtlasCluster is the Schema for the atlasclusters API
 type AtlasCluster struct {
@@ -450,6 +562,20 @@ func (c *AtlasCluster) IsAdvancedCluster() bool {
 	return c.Spec.AdvancedClusterSpec != nil
 }
 
+// TerminationProtectionEnabled returns true if the termination protection is enabled for the cluster in the spec.
+func (c *AtlasCluster) TerminationProtectionEnabled() bool {
+	var enabled *bool
+	switch {
+	case c.IsAdvancedCluster():
+		enabled = c.Spec.AdvancedClusterSpec.TerminationProtectionEnabled
+	case c.IsServerless():
+		enabled = c.Spec.ServerlessSpec.TerminationProtectionEnabled
+	case c.Spec.ClusterSpec != nil:
+		enabled = c.Spec.ClusterSpec.TerminationProtectionEnabled
+	}
+	return enabled != nil && *enabled
+}
+
 // +kubebuilder:object:root=true
 
 // AtlasClusterList contains a list of AtlasCluster
@@ -474,8 +600,14 @@ func (c *AtlasCluster) GetStatus() status.Status {
 func (c *AtlasCluster) UpdateStatus(conditions []status.Condition, options ...status.Option) {
 	c.Status.Conditions = conditions
 	c.Status.ObservedGeneration = c.ObjectMeta.Generation
+	// The planned changes are reported only by the reconciliation in the plan mode
+	c.Status.PlannedChanges = nil
 
 	for _, o := range options {
+		if common, ok := o.(status.CommonStatusOption); ok {
+			common(&c.Status.Common)
+			continue
+		}
 		// This will fail if the Option passed is incorrect - which is expected
 		v := o.(status.AtlasClusterStatusOption)
 		v(&c.Status)
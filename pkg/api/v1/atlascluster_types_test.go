@@ -28,6 +28,10 @@ func init() {
 	excludedClusterFieldsTheirs["createDate"] = true
 	excludedClusterFieldsTheirs["links"] = true
 	excludedClusterFieldsTheirs["versionReleaseSystem"] = true
+	excludedClusterFieldsTheirs["rootCertType"] = true
+
+	// Only applicable to serverless instances
+	excludedClusterFieldsTheirs["serverlessBackupOptions"] = true
 
 	// Deprecated
 	excludedClusterFieldsTheirs["replicationSpec"] = true
@@ -178,4 +182,12 @@ func TestIsEqual(t *testing.T) {
 
 	areTheyEqual = operatorArgs.IsEqual(atlasArgs)
 	assert.False(t, areTheyEqual, "should NOT be equal if Operator has more args")
+
+	operatorArgs.OplogSizeMB = nil
+	operatorArgs.OplogMinRetentionHours = "1.5"
+	hours := 1.5
+	atlasArgs.OplogMinRetentionHours = &hours
+
+	areTheyEqual = operatorArgs.IsEqual(atlasArgs)
+	assert.True(t, areTheyEqual, "should compare float values passed as strings")
 }
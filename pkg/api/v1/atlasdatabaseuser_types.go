@@ -19,6 +19,7 @@ package v1
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/atlas/mongodbatlas"
 	corev1 "k8s.io/api/core/v1"
@@ -72,9 +73,14 @@ type AtlasDatabaseUserSpec struct {
 	// Scopes is an array of clusters and Atlas Data Lakes that this user has access to.
 	Scopes []ScopeSpec `json:"scopes,omitempty"`
 
-	// PasswordSecret is a reference to the Secret keeping the user password.
+	// PasswordSecret is a reference to the Secret keeping the user password. The Operator generates the password if
+	// the reference is omitted (the Secret is named "<resource name>-password" then) or the Secret doesn't exist.
 	PasswordSecret *ResourceRef `json:"passwordSecretRef,omitempty"`
 
+	// PasswordRotation configures the periodic rotation of the password generated by the Operator.
+	// +optional
+	PasswordRotation *PasswordRotationSpec `json:"passwordRotation,omitempty"`
+
 	// Username is a username for authenticating to MongoDB.
 	Username string `json:"username"`
 
@@ -120,6 +126,13 @@ type RoleSpec struct {
 	CollectionName string `json:"collectionName,omitempty"`
 }
 
+// PasswordRotationSpec defines how often the password generated by the Operator is rotated. The new password is set
+// in Atlas and in all the connection Secrets of the user.
+type PasswordRotationSpec struct {
+	// Interval between the password rotations, for example "720h". Must be at least one hour.
+	Interval metav1.Duration `json:"interval"`
+}
+
 // ScopeSpec if present a database user only have access to the indicated resource (Cluster or Atlas Data Lake)
 // if none is given then it has access to all.
 // It's highly recommended to restrict the access of the database users only to a limited set of resources.
@@ -139,14 +152,31 @@ func (p AtlasDatabaseUser) AtlasProjectObjectKey() client.ObjectKey {
 	return kube.ObjectKey(ns, p.Spec.Project.Name)
 }
 
+// PasswordSecretObjectKey returns the key of the Secret keeping the user password, nil if the user doesn't
+// authenticate with the password (X.509 users).
 func (p AtlasDatabaseUser) PasswordSecretObjectKey() *client.ObjectKey {
 	if p.Spec.PasswordSecret != nil {
 		key := kube.ObjectKey(p.Namespace, p.Spec.PasswordSecret.Name)
 		return &key
 	}
+	if p.UsesPassword() {
+		key := kube.ObjectKey(p.Namespace, p.GeneratedPasswordSecretName())
+		return &key
+	}
 	return nil
 }
 
+// UsesPassword returns true if the user authenticates with the password rather than the X.509 certificate
+func (p AtlasDatabaseUser) UsesPassword() bool {
+	return p.Spec.X509Type == "" || p.Spec.X509Type == "NONE"
+}
+
+// GeneratedPasswordSecretName returns the name of the Secret the generated password is stored in if the spec doesn't
+// reference one
+func (p AtlasDatabaseUser) GeneratedPasswordSecretName() string {
+	return p.Name + "-password"
+}
+
 func (p *AtlasDatabaseUser) GetStatus() status.Status {
 	return p.Status
 }
@@ -154,8 +184,14 @@ func (p *AtlasDatabaseUser) GetStatus() status.Status {
 func (p *AtlasDatabaseUser) UpdateStatus(conditions []status.Condition, options ...status.Option) {
 	p.Status.Conditions = conditions
 	p.Status.ObservedGeneration = p.ObjectMeta.Generation
+	// The planned changes are reported only by the reconciliation in the plan mode
+	p.Status.PlannedChanges = nil
 
 	for _, o := range options {
+		if common, ok := o.(status.CommonStatusOption); ok {
+			common(&p.Status.Common)
+			continue
+		}
 		// This will fail if the Option passed is incorrect - which is expected
 		v := o.(status.AtlasDatabaseUserStatusOption)
 		v(&p.Status)
@@ -163,7 +199,7 @@ func (p *AtlasDatabaseUser) UpdateStatus(conditions []status.Condition, options
 }
 
 func (p *AtlasDatabaseUser) ReadPassword(kubeClient client.Client) (string, error) {
-	if p.Spec.PasswordSecret != nil {
+	if p.PasswordSecretObjectKey() != nil {
 		secret := &corev1.Secret{}
 		if err := kubeClient.Get(context.Background(), *p.PasswordSecretObjectKey(), secret); err != nil {
 			return "", err
@@ -243,6 +279,11 @@ func (p *AtlasDatabaseUser) WithRole(roleName, databaseName, collectionName stri
 	return p
 }
 
+func (p *AtlasDatabaseUser) WithPasswordRotation(interval time.Duration) *AtlasDatabaseUser {
+	p.Spec.PasswordRotation = &PasswordRotationSpec{Interval: metav1.Duration{Duration: interval}}
+	return p
+}
+
 func (p *AtlasDatabaseUser) WithScope(scopeType ScopeType, name string) *AtlasDatabaseUser {
 	p.Spec.Scopes = append(p.Spec.Scopes, ScopeSpec{Name: name, Type: scopeType})
 	return p
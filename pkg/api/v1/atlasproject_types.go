@@ -42,8 +42,14 @@ func init() {
 type AtlasProjectSpec struct {
 
 	// Name is the name of the Project that is created in Atlas by the Operator if it doesn't exist yet.
+	// If the Project is bound by 'projectId' the Atlas Project is renamed when the name changes.
 	Name string `json:"name"`
 
+	// ProjectID is the ID of the existing Atlas Project the resource is bound to. The Project is looked up by the ID
+	// instead of the name and is never created by the Operator.
+	// +optional
+	ProjectID string `json:"projectId,omitempty"`
+
 	// ConnectionSecret is the name of the Kubernetes Secret which contains the information about the way to connect to
 	// Atlas (organization ID, API keys). The default Operator connection configuration will be used if not provided.
 	// +optional
@@ -54,9 +60,21 @@ type AtlasProjectSpec struct {
 	// +optional
 	ProjectIPAccessList []project.IPAccessList `json:"projectIpAccessList,omitempty"`
 
+	// IPAccessListPolicy defines which IP Access List entries not specified in the spec are removed from Atlas:
+	// all of them ('exclusive') or only the ones created by the Operator ('merge').
+	// +kubebuilder:default:=exclusive
+	// +optional
+	IPAccessListPolicy ListPolicy `json:"ipAccessListPolicy,omitempty"`
+
 	// PrivateEndpoints is a list of Private Endpoints configured for the current Project.
 	PrivateEndpoints []project.PrivateEndpoint `json:"privateEndpoints,omitempty"`
 
+	// PrivateEndpointPolicy defines which Private Endpoint Services not specified in the spec are removed from Atlas:
+	// all of them ('exclusive') or only the ones created by the Operator ('merge').
+	// +kubebuilder:default:=exclusive
+	// +optional
+	PrivateEndpointPolicy ListPolicy `json:"privateEndpointPolicy,omitempty"`
+
 	// Flag that indicates whether to create the new project with the default alert settings enabled. This parameter defaults to true
 	// +kubebuilder:default:=true
 	// +optional
@@ -66,6 +84,18 @@ type AtlasProjectSpec struct {
 	X509CertRef *ResourceRef `json:"x509CertRef,omitempty"`
 }
 
+// ListPolicy defines how the list of items (like the IP Access List entries) is reconciled with Atlas.
+// +kubebuilder:validation:Enum=exclusive;merge
+type ListPolicy string
+
+const (
+	// ListPolicyExclusive makes the spec the only source of the items, the items not specified in it are removed
+	// from Atlas.
+	ListPolicyExclusive ListPolicy = "exclusive"
+	// ListPolicyMerge keeps the items added to Atlas by other tools, only the items created by the Operator are removed.
+	ListPolicyMerge ListPolicy = "merge"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
 // +kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.spec.name`
@@ -110,8 +140,14 @@ func (p *AtlasProject) GetStatus() status.Status {
 func (p *AtlasProject) UpdateStatus(conditions []status.Condition, options ...status.Option) {
 	p.Status.Conditions = conditions
 	p.Status.ObservedGeneration = p.ObjectMeta.Generation
+	// The planned changes are reported only by the reconciliation in the plan mode
+	p.Status.PlannedChanges = nil
 
 	for _, o := range options {
+		if common, ok := o.(status.CommonStatusOption); ok {
+			common(&p.Status.Common)
+			continue
+		}
 		// This will fail if the Option passed is incorrect - which is expected
 		v := o.(status.AtlasProjectStatusOption)
 		v(&p.Status)
@@ -150,6 +186,11 @@ func (p *AtlasProject) WithAtlasName(name string) *AtlasProject {
 	return p
 }
 
+func (p *AtlasProject) WithProjectID(id string) *AtlasProject {
+	p.Spec.ProjectID = id
+	return p
+}
+
 func (p *AtlasProject) WithConnectionSecret(name string) *AtlasProject {
 	if name != "" {
 		p.Spec.ConnectionSecret = &ResourceRef{Name: name}
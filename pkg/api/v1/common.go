@@ -30,3 +30,11 @@ type LabelSpec struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
 }
+
+// TagSpec holds a key-value pair for resource tagging on the serverless instance
+type TagSpec struct {
+	// +kubebuilder:validation:MaxLength:=255
+	Key string `json:"key"`
+	// +kubebuilder:validation:MaxLength:=255
+	Value string `json:"value"`
+}
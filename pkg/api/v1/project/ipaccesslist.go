@@ -2,6 +2,7 @@ package project
 
 import (
 	"go.mongodb.org/atlas/mongodbatlas"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/compat"
 )
@@ -22,6 +23,21 @@ type IPAccessList struct {
 	// Entry using an IP address in this access list entry.
 	// +optional
 	IPAddress string `json:"ipAddress,omitempty"`
+	// Source of the IP addresses which are added to the access list as separate entries kept in sync with the source.
+	// Can't be specified together with 'awsSecurityGroup', 'cidrBlock' or 'ipAddress'.
+	// +optional
+	Source *IPAccessListSource `json:"source,omitempty"`
+}
+
+// IPAccessListSource is the Kubernetes source of the IP addresses. Only one of the fields can be specified.
+type IPAccessListSource struct {
+	// NodeSelector selects the Kubernetes Nodes the external IP addresses of which are added to the access list.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+	// ConfigMap is the name of the ConfigMap in the namespace of the AtlasProject. Its values contain the IP addresses
+	// or the CIDR blocks separated by whitespaces or commas.
+	// +optional
+	ConfigMap string `json:"configMap,omitempty"`
 }
 
 // ToAtlas converts the ProjectIPAccessList to native Atlas client format.
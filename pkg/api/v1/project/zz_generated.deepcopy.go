@@ -0,0 +1,72 @@
+// +build !ignore_autogenerated
+
+/*
+Copyright (C) MongoDB, Inc. 2020-present.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may
+not use this file except in compliance with the License. You may obtain
+a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package project
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAccessList) DeepCopyInto(out *IPAccessList) {
+	*out = *in
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(IPAccessListSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAccessList.
+func (in *IPAccessList) DeepCopy() *IPAccessList {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAccessList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAccessListSource) DeepCopyInto(out *IPAccessListSource) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAccessListSource.
+func (in *IPAccessListSource) DeepCopy() *IPAccessListSource {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAccessListSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateEndpoint) DeepCopyInto(out *PrivateEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateEndpoint.
+func (in *PrivateEndpoint) DeepCopy() *PrivateEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,44 @@
+package status
+
+// +k8s:deepcopy-gen=false
+
+// AtlasAccessRequestStatusOption is the option that is applied to Atlas Access Request Status
+type AtlasAccessRequestStatusOption func(s *AtlasAccessRequestStatus)
+
+// AtlasAccessRequestGrantedOption records the access granted in Atlas
+func AtlasAccessRequestGrantedOption(username, ipAccessListEntry, expiresAt, credentialsSecret string) AtlasAccessRequestStatusOption {
+	return func(s *AtlasAccessRequestStatus) {
+		s.Username = username
+		s.IPAccessListEntry = ipAccessListEntry
+		s.ExpiresAt = expiresAt
+		s.CredentialsSecret = credentialsSecret
+	}
+}
+
+// AtlasAccessRequestExpiredOption marks the access as expired and revoked
+func AtlasAccessRequestExpiredOption() AtlasAccessRequestStatusOption {
+	return func(s *AtlasAccessRequestStatus) {
+		s.Expired = true
+		s.CredentialsSecret = ""
+	}
+}
+
+// AtlasAccessRequestStatus defines the observed state of AtlasAccessRequest
+type AtlasAccessRequestStatus struct {
+	Common `json:",inline"`
+
+	// Username is the name of the temporary database user created in Atlas
+	Username string `json:"username,omitempty"`
+
+	// IPAccessListEntry is the IP address or the CIDR block added to the project IP Access List
+	IPAccessListEntry string `json:"ipAccessListEntry,omitempty"`
+
+	// ExpiresAt is the timestamp in ISO 8601 date and time format in UTC when the access expires
+	ExpiresAt string `json:"expiresAt,omitempty"`
+
+	// CredentialsSecret is the name of the Secret keeping the credentials of the temporary database user
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+
+	// Expired is true once the access has expired and has been revoked in Atlas
+	Expired bool `json:"expired,omitempty"`
+}
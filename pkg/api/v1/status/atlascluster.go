@@ -23,6 +23,28 @@ type AtlasClusterStatus struct {
 	// MongoURIUpdated is a timestamp in ISO 8601 date and time format in UTC when the connection string was last updated.
 	// The connection string changes if you update any of the other values.
 	MongoURIUpdated string `json:"mongoURIUpdated,omitempty"`
+
+	// TerminationProtectionEnabled indicates whether Atlas prevents the cluster from being deleted.
+	TerminationProtectionEnabled *bool `json:"terminationProtectionEnabled,omitempty"`
+
+	// ServerlessContinuousBackupEnabled indicates whether the serverless instance uses Serverless Continuous Backup.
+	ServerlessContinuousBackupEnabled *bool `json:"serverlessContinuousBackupEnabled,omitempty"`
+
+	// Tags are the key-value pairs that tag and categorize the serverless instance.
+	Tags []Tag `json:"tags,omitempty"`
+
+	// ServerlessPrivateEndpoints is the list of private endpoints configured for the serverless instance.
+	ServerlessPrivateEndpoints []ServerlessPrivateEndpoint `json:"serverlessPrivateEndpoints,omitempty"`
+
+	// ServerlessPrivateEndpointIDs are the IDs of the serverless private endpoints created by the Operator. Only these
+	// are deleted once they are removed from the spec.
+	ServerlessPrivateEndpointIDs []string `json:"serverlessPrivateEndpointIds,omitempty"`
+}
+
+// Tag is a key-value pair that tags and categorizes the resource in Atlas
+type Tag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
 }
 
 // ConnectionStrings contains configuration for applications use to connect to this cluster
@@ -121,3 +143,37 @@ func AtlasClusterMongoURIUpdatedOption(mongoURIUpdated string) AtlasClusterStatu
 		s.MongoURIUpdated = mongoURIUpdated
 	}
 }
+
+func AtlasClusterTerminationProtectionOption(terminationProtectionEnabled *bool) AtlasClusterStatusOption {
+	return func(s *AtlasClusterStatus) {
+		s.TerminationProtectionEnabled = terminationProtectionEnabled
+	}
+}
+
+func AtlasClusterServerlessBackupOption(backupOptions *mongodbatlas.ServerlessBackupOptions) AtlasClusterStatusOption {
+	return func(s *AtlasClusterStatus) {
+		if backupOptions == nil {
+			s.ServerlessContinuousBackupEnabled = nil
+			return
+		}
+		s.ServerlessContinuousBackupEnabled = backupOptions.ServerlessContinuousBackupEnabled
+	}
+}
+
+func AtlasClusterTagsOption(tags []Tag) AtlasClusterStatusOption {
+	return func(s *AtlasClusterStatus) {
+		s.Tags = tags
+	}
+}
+
+func AtlasClusterServerlessPrivateEndpointsOption(privateEndpoints []ServerlessPrivateEndpoint) AtlasClusterStatusOption {
+	return func(s *AtlasClusterStatus) {
+		s.ServerlessPrivateEndpoints = privateEndpoints
+	}
+}
+
+func AtlasClusterServerlessPrivateEndpointIDsOption(ids []string) AtlasClusterStatusOption {
+	return func(s *AtlasClusterStatus) {
+		s.ServerlessPrivateEndpointIDs = ids
+	}
+}
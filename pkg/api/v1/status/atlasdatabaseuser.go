@@ -11,6 +11,13 @@ func AtlasDatabaseUserPasswordVersion(passwordVersion string) AtlasDatabaseUserS
 	}
 }
 
+// AtlasDatabaseUserPasswordRotatedOption records the time the password generated by the Operator was last rotated
+func AtlasDatabaseUserPasswordRotatedOption(rotatedAt string) AtlasDatabaseUserStatusOption {
+	return func(s *AtlasDatabaseUserStatus) {
+		s.PasswordRotatedAt = rotatedAt
+	}
+}
+
 func AtlasDatabaseUserNameOption(name string) AtlasDatabaseUserStatusOption {
 	return func(s *AtlasDatabaseUserStatus) {
 		s.UserName = name
@@ -26,4 +33,8 @@ type AtlasDatabaseUserStatus struct {
 
 	// UserName is the current name of database user.
 	UserName string `json:"name,omitempty"`
+
+	// PasswordRotatedAt is the timestamp in ISO 8601 date and time format in UTC when the password generated by the
+	// Operator was last generated or rotated
+	PasswordRotatedAt string `json:"passwordRotatedAt,omitempty"`
 }
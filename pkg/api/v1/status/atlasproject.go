@@ -22,6 +22,12 @@ func AtlasProjectExpiredIPAccessOption(lists []project.IPAccessList) AtlasProjec
 	}
 }
 
+func AtlasProjectIPAccessListEntriesOption(entries []string) AtlasProjectStatusOption {
+	return func(s *AtlasProjectStatus) {
+		s.IPAccessListEntries = entries
+	}
+}
+
 func AtlasProjectAddPrivateEnpointsOption(privateEndpoints []ProjectPrivateEndpoint) AtlasProjectStatusOption {
 	return func(s *AtlasProjectStatus) {
 		s.PrivateEndpoints = append(s.PrivateEndpoints, privateEndpoints...)
@@ -77,6 +83,10 @@ type AtlasProjectStatus struct {
 	// Note, that this field is updated by the Atlas Operator only after specification changes
 	ExpiredIPAccessList []project.IPAccessList `json:"expiredIpAccessList,omitempty"`
 
+	// The identifiers of the IP Access List entries created by the Operator. Only these entries are removed from Atlas
+	// if the 'merge' IP Access List policy is used.
+	IPAccessListEntries []string `json:"ipAccessListEntries,omitempty"`
+
 	// The list of private endpoints configured for current project
 	PrivateEndpoints []ProjectPrivateEndpoint `json:"privateEndpoints,omitempty"`
 
@@ -30,6 +30,7 @@ type ConditionType string
 const (
 	ReadyType           ConditionType = "Ready"
 	ValidationSucceeded ConditionType = "ValidationSucceeded"
+	DriftDetectedType   ConditionType = "DriftDetected"
 )
 
 // AtlasProject condition types
@@ -42,7 +43,9 @@ const (
 
 // AtlasCluster condition types
 const (
-	ClusterReadyType ConditionType = "ClusterReady"
+	ClusterReadyType                   ConditionType = "ClusterReady"
+	ServerlessPrivateEndpointReadyType ConditionType = "ServerlessPrivateEndpointReady"
+	PendingApprovalType                ConditionType = "PendingApproval"
 )
 
 // AtlasDatabaseUser condition types
@@ -50,6 +53,11 @@ const (
 	DatabaseUserReadyType ConditionType = "DatabaseUserReady"
 )
 
+// AtlasAccessRequest condition types
+const (
+	AccessGrantedType ConditionType = "AccessGranted"
+)
+
 // Condition describes the state of an Atlas Custom Resource at a certain point.
 type Condition struct {
 	// Type of Atlas Custom Resource condition.
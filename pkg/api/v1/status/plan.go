@@ -0,0 +1,33 @@
+package status
+
+// PlannedChange is the change in Atlas the Operator would make for the resource if it wasn't reconciled in the
+// plan mode.
+type PlannedChange struct {
+	// Action is the change planned: Create, Update or Delete.
+	Action string `json:"action"`
+
+	// Resource identifies the Atlas resource to be changed, e.g. "cluster test-cluster".
+	Resource string `json:"resource"`
+
+	// Diff is the difference between the state of the resource in Atlas and the desired one.
+	// +optional
+	Diff string `json:"diff,omitempty"`
+}
+
+// +k8s:deepcopy-gen=false
+
+// CommonStatusOption is the option updating the part of the status shared by all the Atlas Custom Resources.
+type CommonStatusOption func(s *Common)
+
+// NotAdoptedOption marks the status as reported for the Atlas resource not managed by the Operator.
+func NotAdoptedOption(notAdopted bool) CommonStatusOption {
+	return func(s *Common) {
+		s.NotAdopted = notAdopted
+	}
+}
+
+func PlannedChangesOption(changes []PlannedChange) CommonStatusOption {
+	return func(s *Common) {
+		s.PlannedChanges = changes
+	}
+}
@@ -20,3 +20,28 @@ type ProjectPrivateEndpoint struct {
 func (pe ProjectPrivateEndpoint) Identifier() interface{} {
 	return string(pe.Provider) + pe.Region
 }
+
+type ServerlessPrivateEndpoint struct {
+	// Unique identifier of the Serverless PrivateLink Service.
+	ID string `json:"id,omitempty"`
+	// Human-readable label that identifies the private endpoint (the 'name' of the endpoint in the spec).
+	Name string `json:"name,omitempty"`
+	// Cloud provider of the private endpoint. Atlas returns AWS or AZURE.
+	ProviderName string `json:"providerName,omitempty"`
+	// Name of the PrivateLink endpoint service in AWS. Empty while the endpoint service is being created.
+	EndpointServiceName string `json:"endpointServiceName,omitempty"`
+	// Root-relative path that identifies the Azure Private Link Service that Atlas manages.
+	PrivateLinkServiceResourceID string `json:"privateLinkServiceResourceId,omitempty"`
+	// Unique identifier of the private endpoint in your AWS VPC or Azure VNet.
+	CloudProviderEndpointID string `json:"cloudProviderEndpointId,omitempty"`
+	// IPv4 address of the private endpoint in your Azure VNet.
+	PrivateEndpointIPAddress string `json:"privateEndpointIpAddress,omitempty"`
+	// Status of the private endpoint connection: INITIATING, WAITING_FOR_USER, FAILED, DELETING, AVAILABLE.
+	Status string `json:"status,omitempty"`
+	// Error message pertaining to the private endpoint connection.
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+func (pe ServerlessPrivateEndpoint) Identifier() interface{} {
+	return pe.Name
+}
@@ -21,6 +21,8 @@ type Status interface {
 	GetConditions() []Condition
 
 	GetObservedGeneration() int64
+
+	IsNotAdopted() bool
 }
 
 var _ Status = &Common{}
@@ -33,6 +35,17 @@ type Common struct {
 	// ObservedGeneration indicates the generation of the resource specification that the Atlas Operator is aware of.
 	// The Atlas Operator updates this field to the 'metadata.generation' as soon as it starts reconciliation of the resource.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// PlannedChanges is the list of the changes the Operator would make in Atlas. It's reported only if the resource
+	// is reconciled in the plan mode (see the 'mongodb.com/atlas-reconciliation-policy' annotation).
+	// +optional
+	PlannedChanges []PlannedChange `json:"plannedChanges,omitempty"`
+
+	// NotAdopted indicates that the status was reported by the reconciliation in the plan or observe mode while the
+	// Atlas resource wasn't marked as managed by the Operator. Such resource still requires the adoption once the
+	// Operator starts managing it.
+	// +optional
+	NotAdopted bool `json:"notAdopted,omitempty"`
 }
 
 func (c Common) GetConditions() []Condition {
@@ -42,3 +55,7 @@ func (c Common) GetConditions() []Condition {
 func (c Common) GetObservedGeneration() int64 {
 	return c.ObservedGeneration
 }
+
+func (c Common) IsNotAdopted() bool {
+	return c.NotAdopted
+}
@@ -17,6 +17,22 @@ import (
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasAccessRequestStatus) DeepCopyInto(out *AtlasAccessRequestStatus) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasAccessRequestStatus.
+func (in *AtlasAccessRequestStatus) DeepCopy() *AtlasAccessRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasAccessRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AtlasClusterStatus) DeepCopyInto(out *AtlasClusterStatus) {
 	*out = *in
@@ -26,6 +42,31 @@ func (in *AtlasClusterStatus) DeepCopyInto(out *AtlasClusterStatus) {
 		*out = new(ConnectionStrings)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TerminationProtectionEnabled != nil {
+		in, out := &in.TerminationProtectionEnabled, &out.TerminationProtectionEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ServerlessContinuousBackupEnabled != nil {
+		in, out := &in.ServerlessContinuousBackupEnabled, &out.ServerlessContinuousBackupEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]Tag, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServerlessPrivateEndpoints != nil {
+		in, out := &in.ServerlessPrivateEndpoints, &out.ServerlessPrivateEndpoints
+		*out = make([]ServerlessPrivateEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServerlessPrivateEndpointIDs != nil {
+		in, out := &in.ServerlessPrivateEndpointIDs, &out.ServerlessPrivateEndpointIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasClusterStatus.
@@ -61,6 +102,13 @@ func (in *AtlasProjectStatus) DeepCopyInto(out *AtlasProjectStatus) {
 	if in.ExpiredIPAccessList != nil {
 		in, out := &in.ExpiredIPAccessList, &out.ExpiredIPAccessList
 		*out = make([]project.IPAccessList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IPAccessListEntries != nil {
+		in, out := &in.IPAccessListEntries, &out.IPAccessListEntries
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	if in.PrivateEndpoints != nil {
@@ -95,6 +143,11 @@ func (in *Common) DeepCopyInto(out *Common) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]PlannedChange, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Common.
@@ -160,6 +213,21 @@ func (in *Endpoint) DeepCopy() *Endpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedChange) DeepCopyInto(out *PlannedChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedChange.
+func (in *PlannedChange) DeepCopy() *PlannedChange {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateEndpoint) DeepCopyInto(out *PrivateEndpoint) {
 	*out = *in
@@ -194,3 +262,33 @@ func (in *ProjectPrivateEndpoint) DeepCopy() *ProjectPrivateEndpoint {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerlessPrivateEndpoint) DeepCopyInto(out *ServerlessPrivateEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerlessPrivateEndpoint.
+func (in *ServerlessPrivateEndpoint) DeepCopy() *ServerlessPrivateEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerlessPrivateEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tag) DeepCopyInto(out *Tag) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tag.
+func (in *Tag) DeepCopy() *Tag {
+	if in == nil {
+		return nil
+	}
+	out := new(Tag)
+	in.DeepCopyInto(out)
+	return out
+}
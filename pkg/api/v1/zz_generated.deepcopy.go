@@ -66,6 +66,11 @@ func (in *AdvancedClusterSpec) DeepCopyInto(out *AdvancedClusterSpec) {
 			}
 		}
 	}
+	if in.TerminationProtectionEnabled != nil {
+		in, out := &in.TerminationProtectionEnabled, &out.TerminationProtectionEnabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedClusterSpec.
@@ -144,6 +149,87 @@ func (in *AdvancedReplicationSpec) DeepCopy() *AdvancedReplicationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasAccessRequest) DeepCopyInto(out *AtlasAccessRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasAccessRequest.
+func (in *AtlasAccessRequest) DeepCopy() *AtlasAccessRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasAccessRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasAccessRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasAccessRequestList) DeepCopyInto(out *AtlasAccessRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasAccessRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasAccessRequestList.
+func (in *AtlasAccessRequestList) DeepCopy() *AtlasAccessRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasAccessRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasAccessRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasAccessRequestSpec) DeepCopyInto(out *AtlasAccessRequestSpec) {
+	*out = *in
+	out.Project = in.Project
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]RoleSpec, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasAccessRequestSpec.
+func (in *AtlasAccessRequestSpec) DeepCopy() *AtlasAccessRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasAccessRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AtlasBackupExportSpec) DeepCopyInto(out *AtlasBackupExportSpec) {
 	*out = *in
@@ -538,6 +624,11 @@ func (in *AtlasDatabaseUserSpec) DeepCopyInto(out *AtlasDatabaseUserSpec) {
 		*out = new(ResourceRef)
 		**out = **in
 	}
+	if in.PasswordRotation != nil {
+		in, out := &in.PasswordRotation, &out.PasswordRotation
+		*out = new(PasswordRotationSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasDatabaseUserSpec.
@@ -620,7 +711,9 @@ func (in *AtlasProjectSpec) DeepCopyInto(out *AtlasProjectSpec) {
 	if in.ProjectIPAccessList != nil {
 		in, out := &in.ProjectIPAccessList, &out.ProjectIPAccessList
 		*out = make([]project.IPAccessList, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.PrivateEndpoints != nil {
 		in, out := &in.PrivateEndpoints, &out.PrivateEndpoints
@@ -769,6 +862,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TerminationProtectionEnabled != nil {
+		in, out := &in.TerminationProtectionEnabled, &out.TerminationProtectionEnabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -872,6 +970,22 @@ func (in *LabelSpec) DeepCopy() *LabelSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotationSpec) DeepCopyInto(out *PasswordRotationSpec) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotationSpec.
+func (in *PasswordRotationSpec) DeepCopy() *PasswordRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateEndpointSpec) DeepCopyInto(out *PrivateEndpointSpec) {
 	*out = *in
@@ -1089,6 +1203,41 @@ func (in *ScopeSpec) DeepCopy() *ScopeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerlessBackupOptions) DeepCopyInto(out *ServerlessBackupOptions) {
+	*out = *in
+	if in.ServerlessContinuousBackupEnabled != nil {
+		in, out := &in.ServerlessContinuousBackupEnabled, &out.ServerlessContinuousBackupEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerlessBackupOptions.
+func (in *ServerlessBackupOptions) DeepCopy() *ServerlessBackupOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerlessBackupOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerlessPrivateEndpoint) DeepCopyInto(out *ServerlessPrivateEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerlessPrivateEndpoint.
+func (in *ServerlessPrivateEndpoint) DeepCopy() *ServerlessPrivateEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerlessPrivateEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerlessSpec) DeepCopyInto(out *ServerlessSpec) {
 	*out = *in
@@ -1097,6 +1246,26 @@ func (in *ServerlessSpec) DeepCopyInto(out *ServerlessSpec) {
 		*out = new(ProviderSettingsSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TerminationProtectionEnabled != nil {
+		in, out := &in.TerminationProtectionEnabled, &out.TerminationProtectionEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BackupOptions != nil {
+		in, out := &in.BackupOptions, &out.BackupOptions
+		*out = new(ServerlessBackupOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]TagSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrivateEndpoints != nil {
+		in, out := &in.PrivateEndpoints, &out.PrivateEndpoints
+		*out = make([]ServerlessPrivateEndpoint, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerlessSpec.
@@ -1133,3 +1302,18 @@ func (in *Specs) DeepCopy() *Specs {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TagSpec) DeepCopyInto(out *TagSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TagSpec.
+func (in *TagSpec) DeepCopy() *TagSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TagSpec)
+	in.DeepCopyInto(out)
+	return out
+}
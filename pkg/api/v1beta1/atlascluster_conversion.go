@@ -0,0 +1,160 @@
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/compat"
+)
+
+var _ conversion.Convertible = &AtlasCluster{}
+
+// ConvertTo converts the AtlasCluster to the v1 (hub) version. Fails if the cluster variant
+// doesn't match the configuration specified.
+func (c *AtlasCluster) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*mdbv1.AtlasCluster)
+	if !ok {
+		return fmt.Errorf("expected *v1.AtlasCluster but got %T", dstRaw)
+	}
+	if err := c.Spec.validateUnion(); err != nil {
+		return err
+	}
+
+	dst.ObjectMeta = c.ObjectMeta
+	dst.Status = c.Status
+	dst.Spec = mdbv1.AtlasClusterSpec{
+		Project:           c.Spec.Project,
+		ClusterSpec:       c.Spec.Regular,
+		ServerlessSpec:    c.Spec.Serverless,
+		BackupScheduleRef: c.Spec.BackupScheduleRef,
+		ProcessArgs:       c.Spec.ProcessArgs,
+	}
+
+	if c.Spec.Advanced != nil {
+		dst.Spec.AdvancedClusterSpec = &mdbv1.AdvancedClusterSpec{}
+		if err := compat.JSONCopy(dst.Spec.AdvancedClusterSpec, c.Spec.Advanced); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1 (hub) version of the AtlasCluster to this version. The read-only fields of
+// the v1 advanced cluster spec are moved to the status (if it doesn't contain them already), the Atlas
+// identifiers ('id', 'groupId') and the creation date are dropped as Atlas provides them anyway.
+// Fails if the v1 resource doesn't specify exactly one cluster configuration.
+func (c *AtlasCluster) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*mdbv1.AtlasCluster)
+	if !ok {
+		return fmt.Errorf("expected *v1.AtlasCluster but got %T", srcRaw)
+	}
+
+	// This version can't represent the v1 resource with several configurations, so the conversion fails instead of
+	// dropping all of them but one
+	if err := validateV1Configurations(src.Spec); err != nil {
+		return err
+	}
+
+	c.ObjectMeta = src.ObjectMeta
+	c.Status = src.Status
+	c.Spec = AtlasClusterSpec{
+		Project:           src.Spec.Project,
+		BackupScheduleRef: src.Spec.BackupScheduleRef,
+		ProcessArgs:       src.Spec.ProcessArgs,
+	}
+
+	switch {
+	case src.IsAdvancedCluster():
+		c.Spec.Type = AdvancedClusterVariant
+		c.Spec.Advanced = &AdvancedClusterSpec{}
+		if err := compat.JSONCopy(c.Spec.Advanced, src.Spec.AdvancedClusterSpec); err != nil {
+			return err
+		}
+		c.moveReadOnlyFieldsToStatus(src.Spec.AdvancedClusterSpec)
+	case src.IsServerless():
+		c.Spec.Type = ServerlessClusterVariant
+		c.Spec.Serverless = src.Spec.ServerlessSpec
+	case src.Spec.ClusterSpec != nil:
+		c.Spec.Type = RegularClusterVariant
+		c.Spec.Regular = src.Spec.ClusterSpec
+	}
+	return nil
+}
+
+func (c *AtlasCluster) moveReadOnlyFieldsToStatus(advancedSpec *mdbv1.AdvancedClusterSpec) {
+	if c.Status.StateName == "" {
+		c.Status.StateName = advancedSpec.StateName
+	}
+	if c.Status.MongoDBVersion == "" {
+		c.Status.MongoDBVersion = advancedSpec.MongoDBVersion
+	}
+	if c.Status.ConnectionStrings == nil && advancedSpec.ConnectionStrings != nil {
+		c.Status.ConnectionStrings = &status.ConnectionStrings{
+			Standard:    advancedSpec.ConnectionStrings.Standard,
+			StandardSrv: advancedSpec.ConnectionStrings.StandardSrv,
+			Private:     advancedSpec.ConnectionStrings.Private,
+			PrivateSrv:  advancedSpec.ConnectionStrings.PrivateSrv,
+		}
+	}
+}
+
+// validateUnion makes sure the configuration matching the type is specified and the others are not.
+func (s AtlasClusterSpec) validateUnion() error {
+	variants := []ClusterVariant{RegularClusterVariant, AdvancedClusterVariant, ServerlessClusterVariant}
+	specified := map[ClusterVariant]bool{
+		RegularClusterVariant:    s.Regular != nil,
+		AdvancedClusterVariant:   s.Advanced != nil,
+		ServerlessClusterVariant: s.Serverless != nil,
+	}
+	isSet, ok := specified[s.Type]
+	if !ok {
+		return fmt.Errorf("spec.type must be one of Regular, Advanced or Serverless, got %q", s.Type)
+	}
+	if !isSet {
+		return fmt.Errorf("spec.%s must be specified for the %s cluster type", variantField(s.Type), s.Type)
+	}
+	for _, variant := range variants {
+		if variant != s.Type && specified[variant] {
+			return fmt.Errorf("spec.%s must not be specified for the %s cluster type", variantField(variant), s.Type)
+		}
+	}
+	return nil
+}
+
+// validateV1Configurations checks that the v1 spec specifies exactly one of the cluster configurations.
+func validateV1Configurations(spec mdbv1.AtlasClusterSpec) error {
+	var specified []string
+	if spec.ClusterSpec != nil {
+		specified = append(specified, "spec.clusterSpec")
+	}
+	if spec.AdvancedClusterSpec != nil {
+		specified = append(specified, "spec.advancedClusterSpec")
+	}
+	if spec.ServerlessSpec != nil {
+		specified = append(specified, "spec.serverlessSpec")
+	}
+	switch len(specified) {
+	case 0:
+		return errors.New("expected exactly one of spec.clusterSpec, spec.advancedClusterSpec or spec.serverlessSpec, none were present")
+	case 1:
+		return nil
+	default:
+		return fmt.Errorf("expected exactly one of spec.clusterSpec, spec.advancedClusterSpec or spec.serverlessSpec, got %s", strings.Join(specified, ", "))
+	}
+}
+
+func variantField(variant ClusterVariant) string {
+	switch variant {
+	case AdvancedClusterVariant:
+		return "advanced"
+	case ServerlessClusterVariant:
+		return "serverless"
+	default:
+		return "regular"
+	}
+}
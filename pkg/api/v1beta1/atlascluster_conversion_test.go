@@ -0,0 +1,105 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+)
+
+func TestConvertFrom(t *testing.T) {
+	t.Run("Regular cluster", func(t *testing.T) {
+		src := mdbv1.DefaultAWSCluster("ns", "my-project")
+		dst := &AtlasCluster{}
+		require.NoError(t, dst.ConvertFrom(src))
+
+		assert.Equal(t, RegularClusterVariant, dst.Spec.Type)
+		assert.Equal(t, src.Spec.ClusterSpec, dst.Spec.Regular)
+		assert.Nil(t, dst.Spec.Advanced)
+		assert.Nil(t, dst.Spec.Serverless)
+		assert.Equal(t, src.Spec.Project, dst.Spec.Project)
+	})
+
+	t.Run("Advanced cluster read-only fields are moved to status", func(t *testing.T) {
+		src := mdbv1.DefaultAwsAdvancedCluster("ns", "my-project")
+		src.Spec.AdvancedClusterSpec.ID = "123"
+		src.Spec.AdvancedClusterSpec.StateName = "IDLE"
+		src.Spec.AdvancedClusterSpec.MongoDBVersion = "5.0.6"
+		src.Spec.AdvancedClusterSpec.ConnectionStrings = &mdbv1.ConnectionStrings{Standard: "mongodb://foo"}
+
+		dst := &AtlasCluster{}
+		require.NoError(t, dst.ConvertFrom(src))
+
+		assert.Equal(t, AdvancedClusterVariant, dst.Spec.Type)
+		assert.Equal(t, src.Spec.AdvancedClusterSpec.Name, dst.Spec.Advanced.Name)
+		assert.Equal(t, src.Spec.AdvancedClusterSpec.ReplicationSpecs, dst.Spec.Advanced.ReplicationSpecs)
+		assert.Equal(t, "IDLE", dst.Status.StateName)
+		assert.Equal(t, "5.0.6", dst.Status.MongoDBVersion)
+		assert.Equal(t, "mongodb://foo", dst.Status.ConnectionStrings.Standard)
+	})
+
+	t.Run("Status takes precedence over the read-only fields", func(t *testing.T) {
+		src := mdbv1.DefaultAwsAdvancedCluster("ns", "my-project")
+		src.Spec.AdvancedClusterSpec.StateName = "CREATING"
+		src.Status.StateName = "IDLE"
+
+		dst := &AtlasCluster{}
+		require.NoError(t, dst.ConvertFrom(src))
+
+		assert.Equal(t, "IDLE", dst.Status.StateName)
+	})
+
+	t.Run("More than one configuration", func(t *testing.T) {
+		src := mdbv1.DefaultAWSCluster("ns", "my-project")
+		src.Spec.ServerlessSpec = &mdbv1.ServerlessSpec{Name: "serverless"}
+
+		err := (&AtlasCluster{}).ConvertFrom(src)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.clusterSpec, spec.serverlessSpec")
+	})
+
+	t.Run("No configuration", func(t *testing.T) {
+		src := mdbv1.DefaultAWSCluster("ns", "my-project")
+		src.Spec.ClusterSpec = nil
+
+		assert.Error(t, (&AtlasCluster{}).ConvertFrom(src))
+	})
+}
+
+func TestConvertTo(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		for _, src := range []*mdbv1.AtlasCluster{
+			mdbv1.DefaultAWSCluster("ns", "my-project"),
+			mdbv1.DefaultAwsAdvancedCluster("ns", "my-project"),
+			mdbv1.NewDefaultAWSServerlessInstance("ns", "my-project"),
+		} {
+			converted := &AtlasCluster{}
+			require.NoError(t, converted.ConvertFrom(src))
+
+			dst := &mdbv1.AtlasCluster{}
+			require.NoError(t, converted.ConvertTo(dst))
+			assert.Equal(t, src, dst)
+		}
+	})
+
+	t.Run("Type doesn't match the configuration", func(t *testing.T) {
+		src := &AtlasCluster{Spec: AtlasClusterSpec{Type: AdvancedClusterVariant, Regular: &mdbv1.ClusterSpec{}}}
+		err := src.ConvertTo(&mdbv1.AtlasCluster{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.advanced must be specified")
+	})
+
+	t.Run("More than one configuration", func(t *testing.T) {
+		src := &AtlasCluster{Spec: AtlasClusterSpec{Type: RegularClusterVariant, Regular: &mdbv1.ClusterSpec{}, Serverless: &mdbv1.ServerlessSpec{}}}
+		err := src.ConvertTo(&mdbv1.AtlasCluster{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.serverless must not be specified")
+	})
+
+	t.Run("Unknown type", func(t *testing.T) {
+		src := &AtlasCluster{Spec: AtlasClusterSpec{Type: "Dedicated", Regular: &mdbv1.ClusterSpec{}}}
+		assert.Error(t, src.ConvertTo(&mdbv1.AtlasCluster{}))
+	})
+}
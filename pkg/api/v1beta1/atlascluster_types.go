@@ -0,0 +1,151 @@
+/*
+Copyright (C) MongoDB, Inc. 2020-present.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may
+not use this file except in compliance with the License. You may obtain
+a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+)
+
+func init() {
+	SchemeBuilder.Register(&AtlasCluster{}, &AtlasClusterList{})
+}
+
+// ClusterVariant is the kind of the Atlas deployment described by the AtlasCluster
+type ClusterVariant string
+
+const (
+	// RegularClusterVariant is the cluster managed by the regular cluster API. Configured by 'spec.regular'.
+	RegularClusterVariant ClusterVariant = "Regular"
+	// AdvancedClusterVariant is the cluster managed by the advanced cluster API. Configured by 'spec.advanced'.
+	AdvancedClusterVariant ClusterVariant = "Advanced"
+	// ServerlessClusterVariant is the serverless instance. Configured by 'spec.serverless'.
+	ServerlessClusterVariant ClusterVariant = "Serverless"
+)
+
+// AtlasClusterSpec defines the desired state of AtlasCluster. Exactly one of 'regular', 'advanced' or 'serverless'
+// must be specified - the one matching the 'type'.
+// +union
+type AtlasClusterSpec struct {
+	// Project is a reference to AtlasProject resource the cluster belongs to
+	Project mdbv1.ResourceRefNamespaced `json:"projectRef"`
+
+	// Type is the kind of the Atlas deployment. Defines which of 'regular', 'advanced' or 'serverless' is used.
+	// +unionDiscriminator
+	// +kubebuilder:validation:Enum=Regular;Advanced;Serverless
+	Type ClusterVariant `json:"type"`
+
+	// Configuration for the regular cluster API. https://docs.atlas.mongodb.com/reference/api/clusters/
+	// +optional
+	Regular *mdbv1.ClusterSpec `json:"regular,omitempty"`
+
+	// Configuration for the advanced cluster API. https://docs.atlas.mongodb.com/reference/api/clusters-advanced/
+	// +optional
+	Advanced *AdvancedClusterSpec `json:"advanced,omitempty"`
+
+	// Configuration for the serverless instance API. https://docs.atlas.mongodb.com/reference/api/serverless-instances/
+	// +optional
+	Serverless *mdbv1.ServerlessSpec `json:"serverless,omitempty"`
+
+	// Backup schedule for the AtlasCluster
+	// +optional
+	BackupScheduleRef mdbv1.ResourceRefNamespaced `json:"backupRef,omitempty"`
+
+	// ProcessArgs allows to modify Advanced Configuration Options
+	// +optional
+	ProcessArgs *mdbv1.ProcessArgs `json:"processArgs,omitempty"`
+}
+
+// AdvancedClusterSpec is the desired state of the cluster managed by the advanced cluster API.
+// Unlike v1 it contains only the fields that can be set by the user - the data reported by Atlas
+// (state, MongoDB version, connection strings) is available in the status.
+type AdvancedClusterSpec struct {
+	// Name of the cluster as it appears in Atlas. After Atlas creates the cluster, you can't change its name.
+	Name string `json:"name"`
+
+	// Type of the cluster that you want to create.
+	// +kubebuilder:validation:Enum=REPLICASET;SHARDED;GEOSHARDED
+	// +optional
+	ClusterType string `json:"clusterType,omitempty"`
+
+	// Flag that indicates whether the cluster uses Cloud Backups for backups.
+	// +optional
+	BackupEnabled *bool `json:"backupEnabled,omitempty"`
+
+	// Configuration of BI Connector for Atlas on this cluster.
+	// +optional
+	BiConnector *mdbv1.BiConnectorSpec `json:"biConnector,omitempty"`
+
+	// Capacity, in gigabytes, of the host's root volume.
+	// +optional
+	DiskSizeGB *int `json:"diskSizeGB,omitempty"`
+
+	// Cloud service provider that offers Encryption at Rest.
+	// +optional
+	EncryptionAtRestProvider string `json:"encryptionAtRestProvider,omitempty"`
+
+	// Collection of key-value pairs that tag and categorize the cluster.
+	// +optional
+	Labels []mdbv1.LabelSpec `json:"labels,omitempty"`
+
+	// Version of the cluster to deploy.
+	// +optional
+	MongoDBMajorVersion string `json:"mongoDBMajorVersion,omitempty"`
+
+	// Flag that indicates whether the cluster should be paused.
+	// +optional
+	Paused *bool `json:"paused,omitempty"`
+
+	// Flag that indicates the cluster uses continuous cloud backups.
+	// +optional
+	PitEnabled *bool `json:"pitEnabled,omitempty"`
+
+	// Configuration for cluster regions.
+	// +optional
+	ReplicationSpecs []*mdbv1.AdvancedReplicationSpec `json:"replicationSpecs,omitempty"`
+
+	// Type of the root certificate that the cluster uses.
+	// +optional
+	RootCertType string `json:"rootCertType,omitempty"`
+
+	// Release cadence that Atlas uses for this cluster.
+	// +optional
+	VersionReleaseSystem string `json:"versionReleaseSystem,omitempty"`
+
+	// Flag that indicates whether termination protection is enabled on the cluster. If set to true, Atlas won't
+	// delete the cluster and the Operator won't remove the AtlasCluster resource until the deletion is confirmed.
+	// +optional
+	TerminationProtectionEnabled *bool `json:"terminationProtectionEnabled,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:unservedversion
+
+// AtlasCluster is the Schema for the atlasclusters API. The version is served only if the conversion webhook
+// is enabled (see 'config/release/dev/webhook').
+type AtlasCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AtlasClusterSpec          `json:"spec,omitempty"`
+	Status status.AtlasClusterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AtlasClusterList contains a list of AtlasCluster
+type AtlasClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AtlasCluster `json:"items"`
+}
@@ -0,0 +1,175 @@
+// +build !ignore_autogenerated
+
+/*
+Copyright (C) MongoDB, Inc. 2020-present.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may
+not use this file except in compliance with the License. You may obtain
+a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdvancedClusterSpec) DeepCopyInto(out *AdvancedClusterSpec) {
+	*out = *in
+	if in.BackupEnabled != nil {
+		in, out := &in.BackupEnabled, &out.BackupEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BiConnector != nil {
+		in, out := &in.BiConnector, &out.BiConnector
+		*out = new(v1.BiConnectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiskSizeGB != nil {
+		in, out := &in.DiskSizeGB, &out.DiskSizeGB
+		*out = new(int)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]v1.LabelSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PitEnabled != nil {
+		in, out := &in.PitEnabled, &out.PitEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReplicationSpecs != nil {
+		in, out := &in.ReplicationSpecs, &out.ReplicationSpecs
+		*out = make([]*v1.AdvancedReplicationSpec, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(v1.AdvancedReplicationSpec)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.TerminationProtectionEnabled != nil {
+		in, out := &in.TerminationProtectionEnabled, &out.TerminationProtectionEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedClusterSpec.
+func (in *AdvancedClusterSpec) DeepCopy() *AdvancedClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AdvancedClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasCluster) DeepCopyInto(out *AtlasCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasCluster.
+func (in *AtlasCluster) DeepCopy() *AtlasCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasClusterList) DeepCopyInto(out *AtlasClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AtlasCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasClusterList.
+func (in *AtlasClusterList) DeepCopy() *AtlasClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AtlasClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AtlasClusterSpec) DeepCopyInto(out *AtlasClusterSpec) {
+	*out = *in
+	out.Project = in.Project
+	if in.Regular != nil {
+		in, out := &in.Regular, &out.Regular
+		*out = new(v1.ClusterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Advanced != nil {
+		in, out := &in.Advanced, &out.Advanced
+		*out = new(AdvancedClusterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Serverless != nil {
+		in, out := &in.Serverless, &out.Serverless
+		*out = new(v1.ServerlessSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.BackupScheduleRef = in.BackupScheduleRef
+	if in.ProcessArgs != nil {
+		in, out := &in.ProcessArgs, &out.ProcessArgs
+		*out = new(v1.ProcessArgs)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AtlasClusterSpec.
+func (in *AtlasClusterSpec) DeepCopy() *AtlasClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AtlasClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -19,4 +19,7 @@ const (
 
 	// Resource not found
 	ResourceNotFound = "RESOURCE_NOT_FOUND"
+
+	// Error indicates that the IP Access List entry doesn't exist
+	IPAccessListEntryNotFound = "ATLAS_NETWORK_PERMISSION_ENTRY_NOT_FOUND"
 )
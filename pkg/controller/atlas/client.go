@@ -4,23 +4,41 @@ import (
 	"fmt"
 	"net/http"
 	"runtime"
+	"time"
 
 	"go.mongodb.org/atlas/mongodbatlas"
 	"go.uber.org/zap"
 
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/metrics"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/httputil"
 )
 
 // ProductVersion is used for sending the current Operator version in the User-Agent string
 var ProductVersion = "unknown"
 
+const (
+	// maxRetries is the number of times the failed idempotent requests to Atlas are retried
+	maxRetries = 3
+	// minRetryBackoff and maxRetryBackoff limit the delay between the retries of the failed requests
+	minRetryBackoff = 500 * time.Millisecond
+	maxRetryBackoff = 10 * time.Second
+)
+
 // Client is the central place to create a client for Atlas using specified API keys and a server URL.
 // Note, that the default HTTP transport is reused globally by Go so all caching, keep-alive etc will be in action.
-func Client(atlasDomain string, connection Connection, log *zap.SugaredLogger) (mongodbatlas.Client, error) {
+// The options passed are applied to the transport before the retries and the authentication, so they are invoked for
+// each request sent to Atlas. Each request is observed in the 'metrics.AtlasRequestDuration'
+// and traced as the child of the span found in the request context.
+func Client(atlasDomain string, connection Connection, log *zap.SugaredLogger, opts ...httputil.ClientOpt) (mongodbatlas.Client, error) {
+	withMetrics := httputil.Metrics(metrics.AtlasRequestDuration)
+	withTracing := httputil.Tracing()
+	withRetry := httputil.Retry(maxRetries, minRetryBackoff, maxRetryBackoff)
 	withDigest := httputil.Digest(connection.PublicKey, connection.PrivateKey)
 	withLogging := httputil.LoggingTransport(log)
 
-	httpClient, err := httputil.DecorateClient(basicClient(), withDigest, withLogging)
+	opts = append([]httputil.ClientOpt{withMetrics, withTracing}, opts...)
+	opts = append(opts, withRetry, withDigest, withLogging)
+	httpClient, err := httputil.DecorateClient(basicClient(), opts...)
 	if err != nil {
 		return mongodbatlas.Client{}, err
 	}
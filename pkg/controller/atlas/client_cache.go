@@ -0,0 +1,132 @@
+package atlas
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/httputil"
+)
+
+// ClientCache keeps the Atlas clients built for the connection Secrets so that the reconcilers reuse the HTTP
+// connections and the digest authentication state instead of creating a new client on each reconciliation.
+// The clients are keyed by the Secret and invalidated as soon as the Secret 'resourceVersion' changes.
+// The requests of the clients for the same Atlas organization are rate limited together to stay within the Atlas API
+// rate limits. The cache is safe for concurrent use and is meant to be shared by all the reconcilers.
+type ClientCache struct {
+	atlasDomain string
+	log         *zap.SugaredLogger
+
+	mu       sync.Mutex
+	clients  map[client.ObjectKey]cachedClient
+	limiters map[string]*rate.Limiter
+}
+
+const (
+	// organizationRequestsPerSecond and organizationRequestsBurst configure the token bucket limiting the requests to
+	// Atlas sent on behalf of a single organization
+	organizationRequestsPerSecond = 10
+	organizationRequestsBurst     = 50
+)
+
+type cachedClient struct {
+	resourceVersion string
+	connection      Connection
+	client          mongodbatlas.Client
+	// readOnlyClient fails the requests changing Atlas (see httputil.ReadOnly)
+	readOnlyClient mongodbatlas.Client
+}
+
+func NewClientCache(atlasDomain string, log *zap.SugaredLogger) *ClientCache {
+	return &ClientCache{
+		atlasDomain: atlasDomain,
+		log:         log,
+		clients:     map[client.ObjectKey]cachedClient{},
+		limiters:    map[string]*rate.Limiter{},
+	}
+}
+
+// Get returns the connection and the Atlas client for the AtlasProject Secret or the default Operator one if the
+// former is not specified (see ReadConnection).
+func (c *ClientCache) Get(log *zap.SugaredLogger, kubeClient client.Reader, operatorAPISecret client.ObjectKey, projectOverrideSecretRef *client.ObjectKey) (Connection, mongodbatlas.Client, error) {
+	cached, err := c.get(log, kubeClient, operatorAPISecret, projectOverrideSecretRef)
+	return cached.connection, cached.client, err
+}
+
+// GetReadOnly is the same as Get but the client returned fails all the requests which may change Atlas. It is used
+// by the reconciliations in the plan mode to guarantee that no changes are made.
+func (c *ClientCache) GetReadOnly(log *zap.SugaredLogger, kubeClient client.Reader, operatorAPISecret client.ObjectKey, projectOverrideSecretRef *client.ObjectKey) (Connection, mongodbatlas.Client, error) {
+	cached, err := c.get(log, kubeClient, operatorAPISecret, projectOverrideSecretRef)
+	return cached.connection, cached.readOnlyClient, err
+}
+
+func (c *ClientCache) get(log *zap.SugaredLogger, kubeClient client.Reader, operatorAPISecret client.ObjectKey, projectOverrideSecretRef *client.ObjectKey) (cachedClient, error) {
+	secretRef := operatorAPISecret
+	if projectOverrideSecretRef != nil {
+		secretRef = *projectOverrideSecretRef
+	}
+
+	secret := &corev1.Secret{}
+	if err := kubeClient.Get(context.Background(), secretRef, secret); err != nil {
+		if apiErrors.IsNotFound(err) {
+			c.Invalidate(secretRef)
+		}
+		return cachedClient{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.clients[secretRef]; ok && cached.resourceVersion == secret.ResourceVersion {
+		return cached, nil
+	}
+
+	connection, err := connectionFromSecret(secretRef, secret)
+	if err != nil {
+		delete(c.clients, secretRef)
+		return cachedClient{}, err
+	}
+	// The client outlives the reconciliation so it logs with the cache logger rather than the reconciler one
+	limiter := c.organizationLimiter(connection.OrgID)
+	atlasClient, err := Client(c.atlasDomain, connection, c.log, httputil.RateLimit(limiter))
+	if err != nil {
+		return cachedClient{}, err
+	}
+	readOnlyClient, err := Client(c.atlasDomain, connection, c.log, httputil.RateLimit(limiter), httputil.ReadOnly())
+	if err != nil {
+		return cachedClient{}, err
+	}
+
+	log.Debugf("Created Atlas client for the connection Secret %v (resourceVersion %s)", secretRef, secret.ResourceVersion)
+	cached := cachedClient{
+		resourceVersion: secret.ResourceVersion,
+		connection:      connection,
+		client:          atlasClient,
+		readOnlyClient:  readOnlyClient,
+	}
+	c.clients[secretRef] = cached
+	return cached, nil
+}
+
+// organizationLimiter returns the token bucket shared by the clients of the organization. Must be called under the lock.
+func (c *ClientCache) organizationLimiter(orgID string) *rate.Limiter {
+	limiter, ok := c.limiters[orgID]
+	if !ok {
+		limiter = rate.NewLimiter(organizationRequestsPerSecond, organizationRequestsBurst)
+		c.limiters[orgID] = limiter
+	}
+	return limiter
+}
+
+// Invalidate removes the client built for the Secret from the cache.
+func (c *ClientCache) Invalidate(secretRef client.ObjectKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.clients, secretRef)
+}
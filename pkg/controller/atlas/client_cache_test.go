@@ -0,0 +1,91 @@
+package atlas
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+func TestClientCache(t *testing.T) {
+	globalSecret := kube.ObjectKey("ns", "global")
+	projectSecret := kube.ObjectKey("ns", "project")
+	newSecret := func(name, publicKey string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Data: map[string][]byte{
+				"orgId":         []byte("org"),
+				"publicApiKey":  []byte(publicKey),
+				"privateApiKey": []byte("private"),
+			},
+		}
+	}
+
+	t.Run("Client is reused until the Secret changes", func(t *testing.T) {
+		kubeClient := fake.NewClientBuilder().WithObjects(newSecret("global", "public")).Build()
+		cache := NewClientCache("https://cloud.mongodb.com", zap.S())
+
+		connection, first, err := cache.Get(zap.S(), kubeClient, globalSecret, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "public", connection.PublicKey)
+
+		_, second, err := cache.Get(zap.S(), kubeClient, globalSecret, nil)
+		require.NoError(t, err)
+		assert.Same(t, first.Clusters, second.Clusters)
+
+		require.NoError(t, kubeClient.Update(context.Background(), newSecret("global", "rotated")))
+
+		connection, third, err := cache.Get(zap.S(), kubeClient, globalSecret, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "rotated", connection.PublicKey)
+		assert.NotSame(t, first.Clusters, third.Clusters)
+	})
+
+	t.Run("Clients are kept per Secret", func(t *testing.T) {
+		kubeClient := fake.NewClientBuilder().WithObjects(newSecret("global", "public"), newSecret("project", "project-public")).Build()
+		cache := NewClientCache("https://cloud.mongodb.com", zap.S())
+
+		_, global, err := cache.Get(zap.S(), kubeClient, globalSecret, nil)
+		require.NoError(t, err)
+		connection, project, err := cache.Get(zap.S(), kubeClient, globalSecret, &projectSecret)
+		require.NoError(t, err)
+
+		assert.Equal(t, "project-public", connection.PublicKey)
+		assert.NotSame(t, global.Clusters, project.Clusters)
+		assert.Len(t, cache.clients, 2)
+		// Both Secrets belong to the same organization so the rate limit is shared
+		assert.Len(t, cache.limiters, 1)
+	})
+
+	t.Run("Client is removed when the Secret is deleted", func(t *testing.T) {
+		secret := newSecret("global", "public")
+		kubeClient := fake.NewClientBuilder().WithObjects(secret).Build()
+		cache := NewClientCache("https://cloud.mongodb.com", zap.S())
+
+		_, _, err := cache.Get(zap.S(), kubeClient, globalSecret, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, kubeClient.Delete(context.Background(), secret))
+		_, _, err = cache.Get(zap.S(), kubeClient, globalSecret, nil)
+		assert.Error(t, err)
+		assert.Empty(t, cache.clients)
+	})
+
+	t.Run("Invalid Secret", func(t *testing.T) {
+		secret := newSecret("global", "public")
+		delete(secret.Data, "orgId")
+		kubeClient := fake.NewClientBuilder().WithObjects(secret).Build()
+		cache := NewClientCache("https://cloud.mongodb.com", zap.S())
+
+		_, _, err := cache.Get(zap.S(), kubeClient, globalSecret, nil)
+		assert.EqualError(t, err, "the following fields are missing in the Secret ns/global: [orgId]")
+		assert.Empty(t, cache.clients)
+	})
+}
@@ -40,6 +40,10 @@ func readAtlasConnectionFromSecret(kubeClient client.Client, secretRef client.Ob
 	if err := kubeClient.Get(context.Background(), secretRef, secret); err != nil {
 		return Connection{}, err
 	}
+	return connectionFromSecret(secretRef, secret)
+}
+
+func connectionFromSecret(secretRef client.ObjectKey, secret *corev1.Secret) (Connection, error) {
 	secretData := make(map[string]string)
 	for k, v := range secret.Data {
 		secretData[k] = string(v)
@@ -0,0 +1,121 @@
+package atlas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// readinessCheckInterval is how often the Atlas connectivity is checked. The readiness probes get the result of
+	// the last check so that they don't send requests to Atlas and stay fast.
+	readinessCheckInterval = time.Minute
+	readinessCheckTimeout  = time.Second * 30
+)
+
+var errNotCheckedYet = errors.New("the check hasn't been performed yet")
+
+// ReadinessChecker checks that the global Operator Secret contains the Atlas API keys and that Atlas is reachable
+// and accepts them. The checks are performed periodically in background once the checker is added to the manager,
+// CredentialsCheck and AtlasCheck report the results of the last one.
+type ReadinessChecker struct {
+	clients   *ClientCache
+	reader    client.Reader
+	secretRef client.ObjectKey
+	log       *zap.SugaredLogger
+
+	mu             sync.RWMutex
+	credentialsErr error
+	atlasErr       error
+}
+
+// NewReadinessChecker creates the checker of the global Operator Secret. The 'reader' is expected to read the Secret
+// from the API server directly as the Secret may be not cached by the manager.
+func NewReadinessChecker(clients *ClientCache, reader client.Reader, secretRef client.ObjectKey, log *zap.SugaredLogger) *ReadinessChecker {
+	return &ReadinessChecker{
+		clients:        clients,
+		reader:         reader,
+		secretRef:      secretRef,
+		log:            log,
+		credentialsErr: errNotCheckedYet,
+		atlasErr:       errNotCheckedYet,
+	}
+}
+
+// Start implements manager.Runnable.
+func (c *ReadinessChecker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(readinessCheckInterval)
+	defer ticker.Stop()
+	for {
+		c.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The replicas which are not leaders need to report
+// their readiness as well.
+func (c *ReadinessChecker) NeedLeaderElection() bool {
+	return false
+}
+
+// Check performs the checks and stores their results.
+func (c *ReadinessChecker) Check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+
+	credentialsErr, atlasErr := c.check(ctx)
+	if credentialsErr != nil {
+		c.log.Warnf("Readiness check failed: %v", credentialsErr)
+	} else if atlasErr != nil {
+		c.log.Warnf("Readiness check failed: %v", atlasErr)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.credentialsErr = credentialsErr
+	c.atlasErr = atlasErr
+}
+
+func (c *ReadinessChecker) check(ctx context.Context) (credentialsErr error, atlasErr error) {
+	connection, atlasClient, err := c.clients.Get(c.log, c.reader, c.secretRef, nil)
+	if err != nil {
+		err = fmt.Errorf("failed to read the Atlas API keys from the Secret %v: %w", c.secretRef, err)
+		return err, fmt.Errorf("not checked as the Atlas API keys are not available: %w", err)
+	}
+
+	_, _, err = atlasClient.Organizations.Get(ctx, connection.OrgID)
+	if err != nil {
+		var apiErr *mongodbatlas.ErrorResponse
+		if errors.As(err, &apiErr) && apiErr.Response != nil &&
+			(apiErr.Response.StatusCode == http.StatusUnauthorized || apiErr.Response.StatusCode == http.StatusForbidden) {
+			return nil, fmt.Errorf("the Atlas API keys from the Secret %v are not valid for the organization %s: %w", c.secretRef, connection.OrgID, err)
+		}
+		return nil, fmt.Errorf("the Atlas API is not reachable: %w", err)
+	}
+	return nil, nil
+}
+
+// CredentialsCheck implements healthz.Checker reporting if the global Secret exists and contains the Atlas API keys.
+func (c *ReadinessChecker) CredentialsCheck(_ *http.Request) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.credentialsErr
+}
+
+// AtlasCheck implements healthz.Checker reporting if Atlas is reachable and accepts the API keys.
+func (c *ReadinessChecker) AtlasCheck(_ *http.Request) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.atlasErr
+}
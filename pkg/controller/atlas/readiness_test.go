@@ -0,0 +1,76 @@
+package atlas
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+func TestReadinessChecker(t *testing.T) {
+	globalSecret := kube.ObjectKey("ns", "global")
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "global", Namespace: "ns"},
+		Data: map[string][]byte{
+			"orgId":         []byte("org"),
+			"publicApiKey":  []byte("public"),
+			"privateApiKey": []byte("private"),
+		},
+	}
+	atlasServer := func(code int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/atlas/v1.0/orgs/org", r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(code)
+			_, _ = w.Write([]byte(`{"id": "org", "name": "Organization"}`))
+		}))
+	}
+
+	t.Run("Not ready until checked", func(t *testing.T) {
+		checker := NewReadinessChecker(NewClientCache("https://cloud.mongodb.com", zap.S()), fake.NewClientBuilder().Build(), globalSecret, zap.S())
+
+		assert.Error(t, checker.CredentialsCheck(nil))
+		assert.Error(t, checker.AtlasCheck(nil))
+	})
+	t.Run("Secret is missing", func(t *testing.T) {
+		checker := NewReadinessChecker(NewClientCache("https://cloud.mongodb.com", zap.S()), fake.NewClientBuilder().Build(), globalSecret, zap.S())
+		checker.Check(context.Background())
+
+		assert.Contains(t, checker.CredentialsCheck(nil).Error(), "failed to read the Atlas API keys from the Secret ns/global")
+		assert.Contains(t, checker.AtlasCheck(nil).Error(), "not checked")
+	})
+	t.Run("Secret misses the keys", func(t *testing.T) {
+		invalid := secret.DeepCopy()
+		delete(invalid.Data, "privateApiKey")
+		checker := NewReadinessChecker(NewClientCache("https://cloud.mongodb.com", zap.S()), fake.NewClientBuilder().WithObjects(invalid).Build(), globalSecret, zap.S())
+		checker.Check(context.Background())
+
+		assert.Contains(t, checker.CredentialsCheck(nil).Error(), "the following fields are missing in the Secret ns/global: [privateApiKey]")
+	})
+	t.Run("Atlas rejects the keys", func(t *testing.T) {
+		server := atlasServer(http.StatusUnauthorized)
+		defer server.Close()
+		checker := NewReadinessChecker(NewClientCache(server.URL, zap.S()), fake.NewClientBuilder().WithObjects(secret.DeepCopy()).Build(), globalSecret, zap.S())
+		checker.Check(context.Background())
+
+		assert.NoError(t, checker.CredentialsCheck(nil))
+		assert.Contains(t, checker.AtlasCheck(nil).Error(), "the Atlas API keys from the Secret ns/global are not valid for the organization org")
+	})
+	t.Run("Ready", func(t *testing.T) {
+		server := atlasServer(http.StatusOK)
+		defer server.Close()
+		checker := NewReadinessChecker(NewClientCache(server.URL, zap.S()), fake.NewClientBuilder().WithObjects(secret.DeepCopy()).Build(), globalSecret, zap.S())
+		checker.Check(context.Background())
+
+		assert.NoError(t, checker.CredentialsCheck(nil))
+		assert.NoError(t, checker.AtlasCheck(nil))
+	})
+}
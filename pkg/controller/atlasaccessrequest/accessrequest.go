@@ -0,0 +1,294 @@
+package atlasaccessrequest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/ownership"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/password"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
+)
+
+const (
+	AccessGrantedEvent = "AccessGranted"
+	AccessRevokedEvent = "AccessRevoked"
+
+	// The temporary database users are always authenticated against the 'admin' database
+	adminDatabase = "admin"
+
+	usernameKey  = "username"
+	passwordKey  = "password"
+	expiresAtKey = "expiresAt"
+)
+
+// grantAccess ensures that the temporary database user and the IP Access List entry of the request exist in Atlas.
+// Both are created with the expiration date so Atlas removes them even if the Operator isn't running at the time.
+// The IP Access List entry specified in the AtlasProject is left intact (and isn't tracked by the request), otherwise
+// the permanent entry would expire together with the request.
+func (r *AtlasAccessRequestReconciler) grantAccess(ctx *workflow.Context, atlasProject *mdbv1.AtlasProject, request *mdbv1.AtlasAccessRequest) workflow.Result {
+	defer ctx.StartSpan("grantAccess")()
+	projectID := atlasProject.ID()
+	username := request.Username()
+	entry := IPAccessList(request)
+	entryID := entry.Identifier().(string)
+	expiresAt := timeutil.FormatISO8601(request.ExpiresAt())
+	trackedEntry := entryID
+	if projectSpecifiesEntry(atlasProject, entryID) {
+		trackedEntry = ""
+	}
+
+	if ctx.PlanOnly {
+		if request.Status.Username == "" {
+			ctx.PlanChange(workflow.PlanCreate, "database user "+username, "")
+		}
+		if trackedEntry != "" && request.Status.IPAccessListEntry != entryID {
+			ctx.PlanChange(workflow.PlanCreate, fmt.Sprintf("IP access list entry %s", entryID), "")
+		}
+		return workflow.OK()
+	}
+
+	userPassword, result := r.ensureCredentialsSecret(ctx, request)
+	if !result.IsOk() {
+		return result
+	}
+
+	if result := ensureDatabaseUser(ctx, projectID, request, userPassword); !result.IsOk() {
+		return result
+	}
+
+	// The requester IP may have changed - the entry for the old one is not needed any more
+	if old := request.Status.IPAccessListEntry; old != "" && old != entryID && !projectSpecifiesEntry(atlasProject, old) {
+		if err := deleteIPAccessListEntry(ctx, projectID, request, old); err != nil {
+			return workflow.Terminate(workflow.AccessRequestNotGrantedInAtlas, err.Error())
+		}
+	}
+	if trackedEntry != "" {
+		atlasEntry, err := entry.ToAtlas()
+		if err != nil {
+			return workflow.Terminate(workflow.Internal, err.Error())
+		}
+		if _, _, err = ctx.Client.ProjectIPAccessList.Create(ctx.Context, projectID, []*mongodbatlas.ProjectIPAccessList{atlasEntry}); err != nil {
+			return workflow.Terminate(workflow.AccessRequestNotGrantedInAtlas, err.Error())
+		}
+	}
+
+	if request.Status.Username == "" {
+		r.EventRecorder.Eventf(request, corev1.EventTypeNormal, AccessGrantedEvent,
+			"Database user %s with the roles %s is allowed to connect from %s until %s, reason: %q",
+			username, formatRoles(request.Spec.Roles), request.Spec.RequesterIP, expiresAt, request.Spec.Reason)
+	}
+	ctx.EnsureStatusOption(status.AtlasAccessRequestGrantedOption(username, trackedEntry, expiresAt, request.CredentialsSecretName()))
+	return workflow.OK()
+}
+
+// revokeAccess removes the temporary database user, the IP Access List entry and the credentials Secret of the request.
+// The IP Access List entry is kept if it's specified in the AtlasProject as well.
+func (r *AtlasAccessRequestReconciler) revokeAccess(ctx *workflow.Context, atlasProject *mdbv1.AtlasProject, request *mdbv1.AtlasAccessRequest) workflow.Result {
+	defer ctx.StartSpan("revokeAccess")()
+	projectID := atlasProject.ID()
+	username := request.Username()
+	entry := request.Status.IPAccessListEntry
+
+	if ctx.PlanOnly {
+		if request.Status.Username != "" {
+			ctx.PlanChange(workflow.PlanDelete, "database user "+username, "")
+		}
+		if entry != "" && !projectSpecifiesEntry(atlasProject, entry) {
+			ctx.PlanChange(workflow.PlanDelete, fmt.Sprintf("IP access list entry %s", entry), "")
+		}
+		return workflow.OK()
+	}
+
+	if projectID != "" {
+		if err := deleteDatabaseUser(ctx, projectID, request); err != nil {
+			return workflow.Terminate(workflow.AccessRequestNotRevokedInAtlas, err.Error())
+		}
+		if entry != "" && !projectSpecifiesEntry(atlasProject, entry) {
+			if err := deleteIPAccessListEntry(ctx, projectID, request, entry); err != nil {
+				return workflow.Terminate(workflow.AccessRequestNotRevokedInAtlas, err.Error())
+			}
+		}
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: request.CredentialsSecretName(), Namespace: request.Namespace}}
+	if err := r.Client.Delete(ctx.Context, secret); err != nil && !apiErrors.IsNotFound(err) {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+
+	if request.Status.Username != "" {
+		r.EventRecorder.Eventf(request, corev1.EventTypeNormal, AccessRevokedEvent,
+			"Database user %s and the access from %s are removed", username, request.Spec.RequesterIP)
+	}
+	return workflow.OK()
+}
+
+// ensureCredentialsSecret creates the Secret with the generated password of the temporary database user. The password
+// of the existing Secret is reused. The Secret is owned by the request so it's removed together with it.
+func (r *AtlasAccessRequestReconciler) ensureCredentialsSecret(ctx *workflow.Context, request *mdbv1.AtlasAccessRequest) (string, workflow.Result) {
+	secret := &corev1.Secret{}
+	key := kube.ObjectKey(request.Namespace, request.CredentialsSecretName())
+	err := r.Client.Get(ctx.Context, key, secret)
+	if err != nil && !apiErrors.IsNotFound(err) {
+		return "", workflow.Terminate(workflow.AccessRequestCredentialsNotSaved, err.Error())
+	}
+	exists := err == nil
+
+	userPassword := string(secret.Data[passwordKey])
+	if userPassword == "" {
+		if userPassword, err = password.Generate(password.DefaultLength); err != nil {
+			return "", workflow.Terminate(workflow.Internal, err.Error())
+		}
+	}
+
+	secret.Name = key.Name
+	secret.Namespace = key.Namespace
+	// The label is required for the Secret to be visible to the Operator (see the cache configuration)
+	secret.Labels = map[string]string{connectionsecret.TypeLabelKey: connectionsecret.CredLabelVal}
+	secret.Data = map[string][]byte{
+		usernameKey:  []byte(request.Username()),
+		passwordKey:  []byte(userPassword),
+		expiresAtKey: []byte(timeutil.FormatISO8601(request.ExpiresAt())),
+	}
+	if err = controllerutil.SetControllerReference(request, secret, r.Scheme); err != nil {
+		return "", workflow.Terminate(workflow.Internal, err.Error())
+	}
+
+	if exists {
+		err = r.Client.Update(ctx.Context, secret)
+	} else {
+		err = r.Client.Create(ctx.Context, secret)
+	}
+	if err != nil {
+		return "", workflow.Terminate(workflow.AccessRequestCredentialsNotSaved, err.Error())
+	}
+	return userPassword, workflow.OK()
+}
+
+func ensureDatabaseUser(ctx *workflow.Context, projectID string, request *mdbv1.AtlasAccessRequest, userPassword string) workflow.Result {
+	username := request.Username()
+	user := &mongodbatlas.DatabaseUser{
+		DatabaseName:    adminDatabase,
+		GroupID:         projectID,
+		Username:        username,
+		Password:        userPassword,
+		DeleteAfterDate: timeutil.FormatISO8601(request.ExpiresAt()),
+		Roles:           toAtlasRoles(request.Spec.Roles),
+		Labels:          ownership.Labels(request),
+	}
+
+	atlasUser, _, err := ctx.Client.DatabaseUsers.Get(ctx.Context, adminDatabase, projectID, username)
+	if err != nil {
+		var apiError *mongodbatlas.ErrorResponse
+		if !errors.As(err, &apiError) || apiError.ErrorCode != atlas.UsernameNotFound {
+			return workflow.Terminate(workflow.AccessRequestNotGrantedInAtlas, err.Error())
+		}
+		if _, _, err = ctx.Client.DatabaseUsers.Create(ctx.Context, projectID, user); err != nil {
+			return workflow.Terminate(workflow.AccessRequestNotGrantedInAtlas, err.Error())
+		}
+		ctx.Log.Infow("Created temporary database user", "projectID", projectID, "username", username)
+		return workflow.OK()
+	}
+
+	if result := ownership.Check(ctx, request, "database user "+username, atlasUser.Labels, request.Status.Username == username); !result.IsOk() {
+		return result
+	}
+	if _, _, err = ctx.Client.DatabaseUsers.Update(ctx.Context, projectID, username, user); err != nil {
+		return workflow.Terminate(workflow.AccessRequestNotGrantedInAtlas, err.Error())
+	}
+	return workflow.OK()
+}
+
+func deleteDatabaseUser(ctx *workflow.Context, projectID string, request *mdbv1.AtlasAccessRequest) error {
+	username := request.Username()
+	atlasUser, _, err := ctx.Client.DatabaseUsers.Get(ctx.Context, adminDatabase, projectID, username)
+	var apiError *mongodbatlas.ErrorResponse
+	switch {
+	case errors.As(err, &apiError) && apiError.ErrorCode == atlas.UsernameNotFound:
+		// Atlas has removed the expired user already
+		return nil
+	case err != nil:
+		return err
+	case !ownership.IsOwned(request, atlasUser.Labels, false):
+		ctx.Log.Infow("Not removing the database user from Atlas as it's not managed by the resource", "username", username)
+		return nil
+	}
+
+	if _, err = ctx.Client.DatabaseUsers.Delete(ctx.Context, adminDatabase, projectID, username); err != nil {
+		if errors.As(err, &apiError) && apiError.ErrorCode == atlas.UsernameNotFound {
+			return nil
+		}
+		return err
+	}
+	ctx.Log.Infow("Removed temporary database user", "projectID", projectID, "username", username)
+	return nil
+}
+
+func deleteIPAccessListEntry(ctx *workflow.Context, projectID string, request *mdbv1.AtlasAccessRequest, entry string) error {
+	_, err := ctx.Client.ProjectIPAccessList.Delete(ctx.Context, projectID, entry)
+	var apiError *mongodbatlas.ErrorResponse
+	if errors.As(err, &apiError) && (apiError.ErrorCode == atlas.IPAccessListEntryNotFound || apiError.ErrorCode == atlas.ResourceNotFound) {
+		// Atlas has removed the expired entry already
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	ctx.Log.Infow("Removed temporary IP Access List entry", "projectID", projectID, "entry", entry, "accessRequest", kube.ObjectKeyFromObject(request))
+	return nil
+}
+
+// projectSpecifiesEntry returns true if the IP Access List entry is specified in the AtlasProject directly.
+func projectSpecifiesEntry(atlasProject *mdbv1.AtlasProject, entry string) bool {
+	for _, l := range atlasProject.Spec.ProjectIPAccessList {
+		if l.Source == nil && l.Identifier() == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// IPAccessList returns the IP Access List entry allowing the access for the requester. The entry expires together
+// with the request.
+func IPAccessList(request *mdbv1.AtlasAccessRequest) project.IPAccessList {
+	result := project.NewIPAccessList().
+		WithComment(fmt.Sprintf("Access request %s", kube.ObjectKeyFromObject(request))).
+		WithDeleteAfterDate(timeutil.FormatISO8601(request.ExpiresAt()))
+	if strings.Contains(request.Spec.RequesterIP, "/") {
+		return result.WithCIDR(request.Spec.RequesterIP)
+	}
+	return result.WithIP(request.Spec.RequesterIP)
+}
+
+func toAtlasRoles(roles []mdbv1.RoleSpec) []mongodbatlas.Role {
+	result := make([]mongodbatlas.Role, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, mongodbatlas.Role{RoleName: role.RoleName, DatabaseName: role.DatabaseName, CollectionName: role.CollectionName})
+	}
+	return result
+}
+
+func formatRoles(roles []mdbv1.RoleSpec) string {
+	result := make([]string, 0, len(roles))
+	for _, role := range roles {
+		scope := role.DatabaseName
+		if role.CollectionName != "" {
+			scope += "." + role.CollectionName
+		}
+		result = append(result, role.RoleName+"@"+scope)
+	}
+	return strings.Join(result, ", ")
+}
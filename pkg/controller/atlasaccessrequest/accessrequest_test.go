@@ -0,0 +1,215 @@
+package atlasaccessrequest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+const testProjectID = "5f5f5f5f5f5f5f5f5f5f5f5f"
+
+// fakeAtlas keeps the database users and the IP Access List entries of the single Atlas project
+type fakeAtlas struct {
+	users   map[string]mongodbatlas.DatabaseUser
+	entries map[string]bool
+}
+
+func (f *fakeAtlas) server(t *testing.T) *httptest.Server {
+	usersPath := "/api/atlas/v1.0/groups/" + testProjectID + "/databaseUsers"
+	accessListPath := "/api/atlas/v1.0/groups/" + testProjectID + "/accessList"
+	notFound := func(w http.ResponseWriter, code string) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errorCode": "` + code + `", "error": 404}`))
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := r.URL.Path
+		switch {
+		case r.Method == http.MethodPost && path == usersPath:
+			user := mongodbatlas.DatabaseUser{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&user))
+			f.users[user.Username] = user
+			_ = json.NewEncoder(w).Encode(user)
+		case strings.HasPrefix(path, usersPath+"/admin/"):
+			username := strings.TrimPrefix(path, usersPath+"/admin/")
+			user, ok := f.users[username]
+			if !ok {
+				notFound(w, "USERNAME_NOT_FOUND")
+				return
+			}
+			switch r.Method {
+			case http.MethodGet:
+				_ = json.NewEncoder(w).Encode(user)
+			case http.MethodPatch:
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&user))
+				f.users[username] = user
+				_ = json.NewEncoder(w).Encode(user)
+			case http.MethodDelete:
+				delete(f.users, username)
+				w.WriteHeader(http.StatusNoContent)
+			}
+		case r.Method == http.MethodPost && path == accessListPath:
+			var entries []mongodbatlas.ProjectIPAccessList
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&entries))
+			for _, e := range entries {
+				f.entries[e.IPAddress+e.CIDRBlock] = true
+			}
+			_, _ = w.Write([]byte(`{"results": []}`))
+		case r.Method == http.MethodDelete && strings.HasPrefix(path, accessListPath+"/"):
+			entry := strings.TrimPrefix(path, accessListPath+"/")
+			if !f.entries[entry] {
+				notFound(w, "ATLAS_NETWORK_PERMISSION_ENTRY_NOT_FOUND")
+				return
+			}
+			delete(f.entries, entry)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+}
+
+func testRequest() *mdbv1.AtlasAccessRequest {
+	request := mdbv1.NewAccessRequest("ns", "debugging", "my-project", "192.0.2.10", 2*time.Hour).
+		WithRole("read", "orders", "").
+		WithReason("incident 42")
+	request.UID = types.UID("0123456789abcdef")
+	request.CreationTimestamp = metav1.Now()
+	return request
+}
+
+func testReconciler(t *testing.T, request *mdbv1.AtlasAccessRequest) (*AtlasAccessRequestReconciler, *record.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, mdbv1.AddToScheme(scheme))
+	recorder := record.NewFakeRecorder(10)
+	return &AtlasAccessRequestReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(request).Build(),
+		Scheme:        scheme,
+		EventRecorder: recorder,
+	}, recorder
+}
+
+func testContext(t *testing.T, server *httptest.Server) *workflow.Context {
+	client, err := mongodbatlas.New(server.Client(), mongodbatlas.SetBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	ctx := workflow.NewContext(zap.S(), nil)
+	ctx.Context = context.Background()
+	ctx.Client = *client
+	return ctx
+}
+
+func statusOf(t *testing.T, ctx *workflow.Context) status.AtlasAccessRequestStatus {
+	result := status.AtlasAccessRequestStatus{}
+	for _, o := range ctx.StatusOptions() {
+		option, ok := o.(status.AtlasAccessRequestStatusOption)
+		require.True(t, ok)
+		option(&result)
+	}
+	return result
+}
+
+func TestGrantAndRevokeAccess(t *testing.T) {
+	atlas := &fakeAtlas{users: map[string]mongodbatlas.DatabaseUser{}, entries: map[string]bool{}}
+	server := atlas.server(t)
+	defer server.Close()
+
+	request := testRequest()
+	reconciler, recorder := testReconciler(t, request)
+	atlasProject := mdbv1.NewProject("ns", "my-project", "Test Project")
+	atlasProject.Status.ID = testProjectID
+
+	ctx := testContext(t, server)
+	require.True(t, reconciler.grantAccess(ctx, atlasProject, request).IsOk())
+
+	user, ok := atlas.users["debugging-01234567"]
+	require.True(t, ok)
+	assert.Equal(t, []mongodbatlas.Role{{RoleName: "read", DatabaseName: "orders"}}, user.Roles)
+	assert.NotEmpty(t, user.DeleteAfterDate)
+	assert.True(t, atlas.entries["192.0.2.10"])
+
+	secret := &corev1.Secret{}
+	require.NoError(t, reconciler.Client.Get(context.Background(), kube.ObjectKey("ns", "debugging-credentials"), secret))
+	assert.Equal(t, "debugging-01234567", string(secret.Data["username"]))
+	assert.Equal(t, user.Password, string(secret.Data["password"]))
+	assert.Equal(t, "debugging", secret.OwnerReferences[0].Name)
+	assert.Contains(t, <-recorder.Events, AccessGrantedEvent)
+
+	request.Status = statusOf(t, ctx)
+	assert.Equal(t, "192.0.2.10", request.Status.IPAccessListEntry)
+
+	t.Run("Grant again keeps the password", func(t *testing.T) {
+		require.True(t, reconciler.grantAccess(testContext(t, server), atlasProject, request).IsOk())
+		assert.Equal(t, user.Password, atlas.users["debugging-01234567"].Password)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		require.True(t, reconciler.revokeAccess(testContext(t, server), atlasProject, request).IsOk())
+
+		assert.Empty(t, atlas.users)
+		assert.Empty(t, atlas.entries)
+		assert.Error(t, reconciler.Client.Get(context.Background(), kube.ObjectKey("ns", "debugging-credentials"), &corev1.Secret{}))
+		assert.Contains(t, <-recorder.Events, AccessRevokedEvent)
+
+		// Atlas may have removed the expired user and entry already
+		require.True(t, reconciler.revokeAccess(testContext(t, server), atlasProject, request).IsOk())
+	})
+}
+
+func TestRevokeAccessKeepsProjectEntry(t *testing.T) {
+	atlas := &fakeAtlas{users: map[string]mongodbatlas.DatabaseUser{}, entries: map[string]bool{"192.0.2.10": true}}
+	server := atlas.server(t)
+	defer server.Close()
+
+	request := testRequest()
+	request.Status.IPAccessListEntry = "192.0.2.10"
+	reconciler, _ := testReconciler(t, request)
+
+	atlasProject := mdbv1.NewProject("ns", "my-project", "Test Project").WithIPAccessList(project.NewIPAccessList().WithIP("192.0.2.10"))
+	atlasProject.Status.ID = testProjectID
+	require.True(t, reconciler.revokeAccess(testContext(t, server), atlasProject, request).IsOk())
+
+	assert.True(t, atlas.entries["192.0.2.10"])
+}
+
+func TestGrantAccessKeepsProjectEntry(t *testing.T) {
+	atlas := &fakeAtlas{users: map[string]mongodbatlas.DatabaseUser{}, entries: map[string]bool{}}
+	server := atlas.server(t)
+	defer server.Close()
+
+	request := testRequest()
+	reconciler, _ := testReconciler(t, request)
+
+	atlasProject := mdbv1.NewProject("ns", "my-project", "Test Project").WithIPAccessList(project.NewIPAccessList().WithIP("192.0.2.10"))
+	atlasProject.Status.ID = testProjectID
+	ctx := testContext(t, server)
+	require.True(t, reconciler.grantAccess(ctx, atlasProject, request).IsOk())
+
+	// the permanent entry is left to the project reconciler
+	assert.False(t, atlas.entries["192.0.2.10"])
+	assert.Contains(t, atlas.users, "debugging-01234567")
+	assert.Empty(t, statusOf(t, ctx).IPAccessListEntry)
+}
@@ -0,0 +1,226 @@
+/*
+Copyright (C) MongoDB, Inc. 2020-present.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may
+not use this file except in compliance with the License. You may obtain
+a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package atlasaccessrequest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/tracing"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/watch"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+// AtlasAccessRequestReconciler reconciles an AtlasAccessRequest object
+type AtlasAccessRequestReconciler struct {
+	watch.ResourceWatcher
+	Client           client.Client
+	Log              *zap.SugaredLogger
+	Scheme           *runtime.Scheme
+	AtlasClients     *atlas.ClientCache
+	GlobalAPISecret  client.ObjectKey
+	EventRecorder    record.EventRecorder
+	GlobalPredicates []predicate.Predicate
+	// MaxConcurrentReconciles is the number of the AtlasAccessRequests reconciled in parallel, defaults to 1
+	MaxConcurrentReconciles int
+	// PlanMode makes all the AtlasAccessRequests reconciled in the plan mode (see customresource.ReconciliationShouldOnlyPlan)
+	PlanMode bool
+}
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasaccessrequests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasaccessrequests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasaccessrequests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasaccessrequests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",namespace=default,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",namespace=default,resources=events,verbs=create;patch
+
+func (r *AtlasAccessRequestReconciler) Reconcile(context context.Context, req ctrl.Request) (ctrl.Result, error) {
+	context, span := tracing.StartReconcile(context, "AtlasAccessRequest", req.NamespacedName)
+	defer span.End()
+	log := tracing.Logger(context, r.Log.With("atlasaccessrequest", req.NamespacedName))
+
+	request := &mdbv1.AtlasAccessRequest{}
+	result := customresource.PrepareResource(r.Client, req, request, log)
+	if !result.IsOk() {
+		return result.ReconcileResult(), nil
+	}
+
+	if shouldSkip := customresource.ReconciliationShouldBeSkipped(request); shouldSkip {
+		log.Infow(fmt.Sprintf("-> Skipping AtlasAccessRequest reconciliation as annotation %s=%s", customresource.ReconciliationPolicyAnnotation, customresource.ReconciliationPolicySkip), "spec", request.Spec)
+		return workflow.OK().ReconcileResult(), nil
+	}
+
+	ctx := customresource.MarkReconciliationStarted(r.Client, request, log)
+	ctx.Context = context
+	ctx.ObserveOnly = customresource.ReconciliationShouldOnlyObserve(request)
+	ctx.PlanOnly = r.PlanMode || ctx.ObserveOnly || customresource.ReconciliationShouldOnlyPlan(request)
+
+	log.Infow("-> Starting AtlasAccessRequest reconciliation", "spec", request.Spec, "status", request.Status, "planOnly", ctx.PlanOnly, "observeOnly", ctx.ObserveOnly)
+	defer statushandler.Update(ctx, r.Client, r.EventRecorder, request)
+	defer ctx.CompletePlan()
+
+	// The access granted before is revoked on removal even if the spec has become invalid
+	if err := validate.AccessRequest(request); err != nil && request.GetDeletionTimestamp().IsZero() {
+		result := workflow.Terminate(workflow.AccessRequestInvalidSpec, err.Error())
+		ctx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.SetConditionTrue(status.ValidationSucceeded)
+
+	project := &mdbv1.AtlasProject{}
+	if err := r.Client.Get(context, request.AtlasProjectObjectKey(), project); err != nil {
+		if apiErrors.IsNotFound(err) && !request.GetDeletionTimestamp().IsZero() {
+			// Nothing can be revoked without the project, Atlas removes the expired user and entry anyway
+			log.Infow("The AtlasProject doesn't exist, not revoking the access in Atlas", "project", request.AtlasProjectObjectKey())
+			return r.removeFinalizer(ctx, request).ReconcileResult(), nil
+		}
+		result := workflow.Terminate(workflow.AccessRequestProjectNotReady, err.Error())
+		ctx.SetConditionFromResult(status.AccessGrantedType, result)
+		return result.ReconcileResult(), nil
+	}
+	if project.ID() == "" && request.GetDeletionTimestamp().IsZero() {
+		result := workflow.Terminate(workflow.AccessRequestProjectNotReady, fmt.Sprintf("the project %s is not created in Atlas yet", request.AtlasProjectObjectKey()))
+		ctx.SetConditionFromResult(status.AccessGrantedType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	// The request is reconciled again once the project connection Secret is fixed
+	if project.ConnectionSecretObjectKey() != nil {
+		r.EnsureResourcesAreWatched(req.NamespacedName, "Secret", log, *project.ConnectionSecretObjectKey())
+	}
+
+	getAtlasClient := r.AtlasClients.Get
+	if ctx.PlanOnly {
+		getAtlasClient = r.AtlasClients.GetReadOnly
+	}
+	connection, atlasClient, err := getAtlasClient(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
+	if err != nil {
+		result := workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
+		if project.ConnectionSecretObjectKey() == nil {
+			// The global Secret is not watched so the reconciliation needs to be retried until it's fixed
+			result = result.WithRetryClass(workflow.RetryTransient)
+		}
+		ctx.SetConditionFromResult(status.AccessGrantedType, result)
+		return result.ReconcileResult(), nil
+	}
+	ctx.Connection = connection
+	ctx.Client = atlasClient
+
+	if !request.GetDeletionTimestamp().IsZero() {
+		if controllerutil.ContainsFinalizer(request, customresource.AccessRevocationFinalizer) && !ctx.PlanOnly {
+			if result := r.revokeAccess(ctx, project, request); !result.IsOk() {
+				ctx.SetConditionFromResult(status.AccessGrantedType, result)
+				return result.ReconcileResult(), nil
+			}
+		}
+		return r.removeFinalizer(ctx, request).ReconcileResult(), nil
+	}
+
+	if request.Status.Expired {
+		return r.expired(ctx).ReconcileResult(), nil
+	}
+
+	if !time.Now().Before(request.ExpiresAt()) {
+		if result := r.revokeAccess(ctx, project, request); !result.IsOk() {
+			ctx.SetConditionFromResult(status.AccessGrantedType, result)
+			return result.ReconcileResult(), nil
+		}
+		if !ctx.PlanOnly {
+			ctx.EnsureStatusOption(status.AtlasAccessRequestExpiredOption())
+		}
+		return r.expired(ctx).ReconcileResult(), nil
+	}
+
+	if !ctx.PlanOnly && !controllerutil.ContainsFinalizer(request, customresource.AccessRevocationFinalizer) {
+		controllerutil.AddFinalizer(request, customresource.AccessRevocationFinalizer)
+		if err := r.Client.Update(context, request); err != nil {
+			result := workflow.Terminate(workflow.Internal, err.Error())
+			ctx.SetConditionFromResult(status.AccessGrantedType, result)
+			return result.ReconcileResult(), nil
+		}
+	}
+
+	if result := r.grantAccess(ctx, project, request); !result.IsOk() {
+		ctx.SetConditionFromResult(status.AccessGrantedType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	if ctx.PlanOnly {
+		return workflow.OK().ReconcileResult(), nil
+	}
+	ctx.SetConditionTrue(status.AccessGrantedType)
+	ctx.SetConditionTrue(status.ReadyType)
+	// The access is revoked once it expires
+	return workflow.OK().WithRetry(time.Until(request.ExpiresAt())).ReconcileResult(), nil
+}
+
+// expired reports the access as expired. The request is kept for auditing until the user removes it.
+func (r *AtlasAccessRequestReconciler) expired(ctx *workflow.Context) workflow.Result {
+	result := workflow.Terminate(workflow.AccessRequestExpired, "The access has expired and has been revoked in Atlas").WithoutRetry()
+	ctx.SetConditionFromResult(status.AccessGrantedType, result)
+	return result
+}
+
+func (r *AtlasAccessRequestReconciler) removeFinalizer(ctx *workflow.Context, request *mdbv1.AtlasAccessRequest) workflow.Result {
+	if !controllerutil.ContainsFinalizer(request, customresource.AccessRevocationFinalizer) {
+		return workflow.OK()
+	}
+	controllerutil.RemoveFinalizer(request, customresource.AccessRevocationFinalizer)
+	if err := r.Client.Update(ctx.Context, request); err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	return workflow.OK()
+}
+
+func (r *AtlasAccessRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := controller.New("AtlasAccessRequest", mgr, controller.Options{
+		Reconciler:              r,
+		RateLimiter:             workflow.RateLimiter(),
+		MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource AtlasAccessRequest
+	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasAccessRequest{}}, &handler.EnqueueRequestForObject{}, r.GlobalPredicates...)
+	if err != nil {
+		return err
+	}
+
+	// Watch for the project connection Secrets
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, watch.NewSecretHandler(r.WatchedResources))
+	if err != nil {
+		return err
+	}
+	return nil
+}
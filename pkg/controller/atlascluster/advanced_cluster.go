@@ -11,14 +11,16 @@ import (
 	"go.uber.org/zap"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/ownership"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/compat"
 )
 
 func (r *AtlasClusterReconciler) ensureAdvancedClusterState(ctx *workflow.Context, project *mdbv1.AtlasProject, cluster *mdbv1.AtlasCluster) (*mongodbatlas.AdvancedCluster, workflow.Result) {
+	defer ctx.StartSpan("ensureAdvancedClusterState")()
 	advancedClusterSpec := cluster.Spec.AdvancedClusterSpec
 
-	advancedCluster, resp, err := ctx.Client.AdvancedClusters.Get(context.Background(), project.Status.ID, advancedClusterSpec.Name)
+	advancedCluster, resp, err := ctx.Client.AdvancedClusters.Get(ctx.Context, project.Status.ID, advancedClusterSpec.Name)
 
 	if err != nil {
 		if resp == nil {
@@ -33,17 +35,25 @@ func (r *AtlasClusterReconciler) ensureAdvancedClusterState(ctx *workflow.Contex
 		if err != nil {
 			return advancedCluster, workflow.Terminate(workflow.Internal, err.Error())
 		}
+		advancedCluster.Labels = ownership.WithLabels(advancedCluster.Labels, cluster)
+
+		if ctx.PlanOnly {
+			ctx.PlanChange(workflow.PlanCreate, "cluster "+advancedClusterSpec.Name, AdvancedClustersDiff(mongodbatlas.AdvancedCluster{}, *advancedCluster))
+			return nil, workflow.Planned("the cluster is not created in the plan mode")
+		}
 
 		ctx.Log.Infof("Advanced Cluster %s doesn't exist in Atlas - creating", advancedClusterSpec.Name)
-		advancedCluster, _, err = ctx.Client.AdvancedClusters.Create(context.Background(), project.Status.ID, advancedCluster)
+		advancedCluster, _, err = ctx.Client.AdvancedClusters.Create(ctx.Context, project.Status.ID, advancedCluster)
 		if err != nil {
 			return advancedCluster, workflow.Terminate(workflow.ClusterNotCreatedInAtlas, err.Error())
 		}
+	} else if result := ownership.Check(ctx, cluster, "cluster "+advancedClusterSpec.Name, advancedCluster.Labels, cluster.Status.StateName != ""); !result.IsOk() {
+		return nil, result
 	}
 
 	switch advancedCluster.StateName {
 	case "IDLE":
-		return advancedClusterIdle(ctx, project, cluster, advancedCluster)
+		return r.advancedClusterIdle(ctx, project, cluster, advancedCluster)
 
 	case "CREATING":
 		return advancedCluster, workflow.InProgress(workflow.ClusterCreating, "cluster is provisioning")
@@ -58,16 +68,28 @@ func (r *AtlasClusterReconciler) ensureAdvancedClusterState(ctx *workflow.Contex
 	}
 }
 
-func advancedClusterIdle(ctx *workflow.Context, project *mdbv1.AtlasProject, cluster *mdbv1.AtlasCluster, advancedCluster *mongodbatlas.AdvancedCluster) (*mongodbatlas.AdvancedCluster, workflow.Result) {
+func (r *AtlasClusterReconciler) advancedClusterIdle(ctx *workflow.Context, project *mdbv1.AtlasProject, cluster *mdbv1.AtlasCluster, advancedCluster *mongodbatlas.AdvancedCluster) (*mongodbatlas.AdvancedCluster, workflow.Result) {
 	resultingCluster, err := MergedAdvancedCluster(*advancedCluster, cluster.Spec)
 	if err != nil {
 		return advancedCluster, workflow.Terminate(workflow.Internal, err.Error())
 	}
+	resultingCluster.Labels = ownership.WithLabels(resultingCluster.Labels, cluster)
 
 	if done := AdvancedClustersEqual(ctx.Log, *advancedCluster, resultingCluster); done {
+		clearPendingApproval(ctx)
 		return advancedCluster, workflow.OK()
 	}
 
+	if ctx.PlanOnly {
+		ctx.PlanChange(workflow.PlanUpdate, "cluster "+cluster.Spec.AdvancedClusterSpec.Name, AdvancedClustersDiff(*advancedCluster, resultingCluster))
+		return advancedCluster, ctx.UpdatePlanned("the cluster is not updated in the plan mode")
+	}
+
+	riskyChanges := advancedClusterRiskyChanges(*advancedCluster, resultingCluster)
+	if result := ensureChangesApproved(ctx, cluster, r.changeApprovalRequired(cluster), riskyChanges); !result.IsOk() {
+		return advancedCluster, result
+	}
+
 	if cluster.Spec.AdvancedClusterSpec.Paused != nil {
 		if advancedCluster.Paused == nil || *advancedCluster.Paused != *cluster.Spec.AdvancedClusterSpec.Paused {
 			// paused is different from Atlas
@@ -83,7 +105,7 @@ func advancedClusterIdle(ctx *workflow.Context, project *mdbv1.AtlasProject, clu
 
 	resultingCluster = cleanupAdvancedCluster(resultingCluster)
 
-	advancedCluster, _, err = ctx.Client.AdvancedClusters.Update(context.Background(), project.Status.ID, cluster.Spec.AdvancedClusterSpec.Name, &resultingCluster)
+	advancedCluster, _, err = ctx.Client.AdvancedClusters.Update(ctx.Context, project.Status.ID, cluster.Spec.AdvancedClusterSpec.Name, &resultingCluster)
 	if err != nil {
 		return advancedCluster, workflow.Terminate(workflow.ClusterNotUpdatedInAtlas, err.Error())
 	}
@@ -124,7 +146,7 @@ func MergedAdvancedCluster(advancedCluster mongodbatlas.AdvancedCluster, spec md
 
 // AdvancedClustersEqual compares two Atlas Advanced Clusters
 func AdvancedClustersEqual(log *zap.SugaredLogger, clusterAtlas mongodbatlas.AdvancedCluster, clusterOperator mongodbatlas.AdvancedCluster) bool {
-	d := cmp.Diff(clusterAtlas, clusterOperator, cmpopts.EquateEmpty())
+	d := AdvancedClustersDiff(clusterAtlas, clusterOperator)
 	if d != "" {
 		log.Debugf("Clusters are different: %s", d)
 	}
@@ -132,15 +154,20 @@ func AdvancedClustersEqual(log *zap.SugaredLogger, clusterAtlas mongodbatlas.Adv
 	return d == ""
 }
 
+// AdvancedClustersDiff returns the human-readable difference between two Atlas Advanced Clusters, empty if they are equal
+func AdvancedClustersDiff(clusterAtlas mongodbatlas.AdvancedCluster, clusterOperator mongodbatlas.AdvancedCluster) string {
+	return cmp.Diff(clusterAtlas, clusterOperator, cmpopts.EquateEmpty())
+}
+
 // GetAllClusterNames returns all cluster names including regular and advanced clusters.
-func GetAllClusterNames(client mongodbatlas.Client, projectID string) ([]string, error) {
+func GetAllClusterNames(ctx context.Context, client mongodbatlas.Client, projectID string) ([]string, error) {
 	var clusterNames []string
-	clusters, _, err := client.Clusters.List(context.Background(), projectID, &mongodbatlas.ListOptions{})
+	clusters, _, err := client.Clusters.List(ctx, projectID, &mongodbatlas.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
 
-	advancedClusters, _, err := client.AdvancedClusters.List(context.Background(), projectID, &mongodbatlas.ListOptions{})
+	advancedClusters, _, err := client.AdvancedClusters.List(ctx, projectID, &mongodbatlas.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,191 @@
+package atlascluster
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	corev1 "k8s.io/api/core/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+// The changes requiring the approval are the ones which are either destructive (backups are removed, shards are
+// drained) or can't be easily reverted (the MongoDB major version) or may degrade the performance of the cluster.
+
+var instanceSizeNumber = regexp.MustCompile(`^[A-Z]+(\d+)`)
+
+// changeApprovalRequired returns true if the risky changes of the cluster must be approved before they are applied
+func (r *AtlasClusterReconciler) changeApprovalRequired(cluster *mdbv1.AtlasCluster) bool {
+	return r.RequireChangeApproval || customresource.ChangeApprovalRequired(cluster)
+}
+
+// ensureChangesApproved holds the update of the cluster if the changes require the approval which hasn't been given
+// yet. The pending changes are reported in the PendingApproval condition along with the hash which the user needs to
+// set to the approval annotation. The hash depends on the changes so the approval can't be reused for the next ones.
+func ensureChangesApproved(ctx *workflow.Context, cluster *mdbv1.AtlasCluster, approvalRequired bool, riskyChanges []string) workflow.Result {
+	if !approvalRequired || len(riskyChanges) == 0 {
+		clearPendingApproval(ctx)
+		return workflow.OK()
+	}
+
+	hash := changesHash(cluster.GetClusterName(), riskyChanges)
+	if customresource.ChangesApproved(cluster, hash) {
+		ctx.Log.Infow("The cluster changes requiring approval are approved", "changes", riskyChanges, "hash", hash)
+		clearPendingApproval(ctx)
+		return workflow.OK()
+	}
+
+	message := fmt.Sprintf("the changes require approval, set the annotation %s=%s to apply them: %s",
+		customresource.ChangesApprovedAnnotation, hash, strings.Join(riskyChanges, "; "))
+	ctx.EnsureCondition(status.Condition{
+		Type:    status.PendingApprovalType,
+		Status:  corev1.ConditionTrue,
+		Reason:  string(workflow.ClusterChangesNotApproved),
+		Message: message,
+	})
+	return workflow.Terminate(workflow.ClusterChangesNotApproved, message)
+}
+
+// clearPendingApproval resets the PendingApproval condition if it was set by the previous reconciliations
+func clearPendingApproval(ctx *workflow.Context) {
+	for _, condition := range ctx.Conditions() {
+		if condition.Type == status.PendingApprovalType && condition.Status == corev1.ConditionTrue {
+			ctx.SetConditionFalse(status.PendingApprovalType)
+			return
+		}
+	}
+}
+
+func changesHash(clusterName string, changes []string) string {
+	sum := sha256.Sum256([]byte(clusterName + "\n" + strings.Join(changes, "\n")))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// regularClusterRiskyChanges returns the summary of the changes requiring approval made by the update of the regular cluster
+func regularClusterRiskyChanges(atlasCluster, resultingCluster mongodbatlas.Cluster) []string {
+	var changes []string
+	if atlasCluster.ProviderSettings != nil && resultingCluster.ProviderSettings != nil {
+		changes = appendInstanceSizeDowngrade(changes, "instance size downgrade", atlasCluster.ProviderSettings.InstanceSizeName, resultingCluster.ProviderSettings.InstanceSizeName)
+	}
+	changes = appendDiskShrink(changes, atlasCluster.DiskSizeGB, resultingCluster.DiskSizeGB)
+	changes = appendMajorVersionChange(changes, atlasCluster.MongoDBMajorVersion, resultingCluster.MongoDBMajorVersion)
+	changes = appendShardsReduction(changes, regularClusterShards(atlasCluster), regularClusterShards(resultingCluster))
+	changes = appendDisabled(changes, "cloud backup", atlasCluster.ProviderBackupEnabled, resultingCluster.ProviderBackupEnabled)
+	changes = appendDisabled(changes, "legacy backup", atlasCluster.BackupEnabled, resultingCluster.BackupEnabled)
+	changes = appendDisabled(changes, "point in time restore", atlasCluster.PitEnabled, resultingCluster.PitEnabled)
+	return changes
+}
+
+// advancedClusterRiskyChanges returns the summary of the changes requiring approval made by the update of the advanced cluster.
+// The region configs are compared by their positions, the same way they are merged (see MergedAdvancedCluster).
+func advancedClusterRiskyChanges(atlasCluster, resultingCluster mongodbatlas.AdvancedCluster) []string {
+	var changes []string
+	for i, atlasReplicationSpec := range atlasCluster.ReplicationSpecs {
+		if i >= len(resultingCluster.ReplicationSpecs) || atlasReplicationSpec == nil || resultingCluster.ReplicationSpecs[i] == nil {
+			break
+		}
+		resultingRegionConfigs := resultingCluster.ReplicationSpecs[i].RegionConfigs
+		for j, atlasRegion := range atlasReplicationSpec.RegionConfigs {
+			if j >= len(resultingRegionConfigs) || atlasRegion == nil || resultingRegionConfigs[j] == nil {
+				break
+			}
+			resultingRegion := resultingRegionConfigs[j]
+			region := fmt.Sprintf("%s %s", atlasRegion.ProviderName, atlasRegion.RegionName)
+			changes = appendSpecsDowngrade(changes, "electable nodes in "+region, atlasRegion.ElectableSpecs, resultingRegion.ElectableSpecs)
+			changes = appendSpecsDowngrade(changes, "read-only nodes in "+region, atlasRegion.ReadOnlySpecs, resultingRegion.ReadOnlySpecs)
+			changes = appendSpecsDowngrade(changes, "analytics nodes in "+region, atlasRegion.AnalyticsSpecs, resultingRegion.AnalyticsSpecs)
+		}
+	}
+	changes = appendDiskShrink(changes, atlasCluster.DiskSizeGB, resultingCluster.DiskSizeGB)
+	changes = appendMajorVersionChange(changes, atlasCluster.MongoDBMajorVersion, resultingCluster.MongoDBMajorVersion)
+	changes = appendShardsReduction(changes, advancedClusterShards(atlasCluster), advancedClusterShards(resultingCluster))
+	changes = appendDisabled(changes, "cloud backup", atlasCluster.BackupEnabled, resultingCluster.BackupEnabled)
+	changes = appendDisabled(changes, "point in time restore", atlasCluster.PitEnabled, resultingCluster.PitEnabled)
+	return changes
+}
+
+func appendSpecsDowngrade(changes []string, nodes string, atlasSpecs, resultingSpecs *mongodbatlas.Specs) []string {
+	if atlasSpecs == nil || resultingSpecs == nil {
+		return changes
+	}
+	return appendInstanceSizeDowngrade(changes, "instance size downgrade of "+nodes, atlasSpecs.InstanceSize, resultingSpecs.InstanceSize)
+}
+
+// appendInstanceSizeDowngrade compares the sizes by their number ignoring the class (M40, R40 and M40_NVME are the
+// same size)
+func appendInstanceSizeDowngrade(changes []string, description string, atlasSize, resultingSize string) []string {
+	atlasNumber, atlasOk := instanceSize(atlasSize)
+	resultingNumber, resultingOk := instanceSize(resultingSize)
+	if atlasOk && resultingOk && resultingNumber < atlasNumber {
+		changes = append(changes, fmt.Sprintf("%s %s -> %s", description, atlasSize, resultingSize))
+	}
+	return changes
+}
+
+func instanceSize(name string) (int, bool) {
+	match := instanceSizeNumber.FindStringSubmatch(name)
+	if match == nil {
+		return 0, false
+	}
+	size, err := strconv.Atoi(match[1])
+	return size, err == nil
+}
+
+func appendDiskShrink(changes []string, atlasSize, resultingSize *float64) []string {
+	if atlasSize != nil && resultingSize != nil && *resultingSize < *atlasSize {
+		changes = append(changes, fmt.Sprintf("disk size shrink %v -> %v GB", *atlasSize, *resultingSize))
+	}
+	return changes
+}
+
+func appendMajorVersionChange(changes []string, atlasVersion, resultingVersion string) []string {
+	if atlasVersion != "" && resultingVersion != "" && atlasVersion != resultingVersion {
+		changes = append(changes, fmt.Sprintf("MongoDB major version change %s -> %s", atlasVersion, resultingVersion))
+	}
+	return changes
+}
+
+func appendShardsReduction(changes []string, atlasShards, resultingShards int64) []string {
+	if resultingShards > 0 && resultingShards < atlasShards {
+		changes = append(changes, fmt.Sprintf("number of shards reduction %d -> %d", atlasShards, resultingShards))
+	}
+	return changes
+}
+
+func appendDisabled(changes []string, feature string, atlasEnabled, resultingEnabled *bool) []string {
+	if atlasEnabled != nil && *atlasEnabled && resultingEnabled != nil && !*resultingEnabled {
+		changes = append(changes, feature+" disabled")
+	}
+	return changes
+}
+
+func regularClusterShards(cluster mongodbatlas.Cluster) int64 {
+	var shards int64
+	for _, spec := range cluster.ReplicationSpecs {
+		if spec.NumShards != nil {
+			shards += *spec.NumShards
+		}
+	}
+	if shards == 0 && cluster.NumShards != nil {
+		shards = *cluster.NumShards
+	}
+	return shards
+}
+
+func advancedClusterShards(cluster mongodbatlas.AdvancedCluster) int64 {
+	var shards int64
+	for _, spec := range cluster.ReplicationSpecs {
+		if spec != nil {
+			shards += int64(spec.NumShards)
+		}
+	}
+	return shards
+}
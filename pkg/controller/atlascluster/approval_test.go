@@ -0,0 +1,120 @@
+package atlascluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+func TestRegularClusterRiskyChanges(t *testing.T) {
+	atlasCluster := mongodbatlas.Cluster{
+		ProviderSettings:      &mongodbatlas.ProviderSettings{InstanceSizeName: "M30"},
+		DiskSizeGB:            float64ptr(40),
+		MongoDBMajorVersion:   "4.4",
+		ReplicationSpecs:      []mongodbatlas.ReplicationSpec{{NumShards: int64ptr(3)}},
+		ProviderBackupEnabled: toptr.Boolptr(true),
+		PitEnabled:            toptr.Boolptr(true),
+	}
+
+	t.Run("Safe changes", func(t *testing.T) {
+		resultingCluster := atlasCluster
+		resultingCluster.ProviderSettings = &mongodbatlas.ProviderSettings{InstanceSizeName: "R40"}
+		resultingCluster.DiskSizeGB = float64ptr(80)
+		resultingCluster.ReplicationSpecs = []mongodbatlas.ReplicationSpec{{NumShards: int64ptr(4)}}
+
+		assert.Empty(t, regularClusterRiskyChanges(atlasCluster, resultingCluster))
+	})
+	t.Run("Risky changes", func(t *testing.T) {
+		resultingCluster := mongodbatlas.Cluster{
+			ProviderSettings:      &mongodbatlas.ProviderSettings{InstanceSizeName: "M10"},
+			DiskSizeGB:            float64ptr(20),
+			MongoDBMajorVersion:   "5.0",
+			ReplicationSpecs:      []mongodbatlas.ReplicationSpec{{NumShards: int64ptr(2)}},
+			ProviderBackupEnabled: toptr.Boolptr(false),
+			PitEnabled:            toptr.Boolptr(false),
+		}
+
+		assert.Equal(t, []string{
+			"instance size downgrade M30 -> M10",
+			"disk size shrink 40 -> 20 GB",
+			"MongoDB major version change 4.4 -> 5.0",
+			"number of shards reduction 3 -> 2",
+			"cloud backup disabled",
+			"point in time restore disabled",
+		}, regularClusterRiskyChanges(atlasCluster, resultingCluster))
+	})
+}
+
+func TestAdvancedClusterRiskyChanges(t *testing.T) {
+	newCluster := func(electableSize, analyticsSize string, shards int) mongodbatlas.AdvancedCluster {
+		return mongodbatlas.AdvancedCluster{
+			ReplicationSpecs: []*mongodbatlas.AdvancedReplicationSpec{{
+				NumShards: shards,
+				RegionConfigs: []*mongodbatlas.AdvancedRegionConfig{{
+					ProviderName:   "AWS",
+					RegionName:     "US_EAST_1",
+					ElectableSpecs: &mongodbatlas.Specs{InstanceSize: electableSize},
+					AnalyticsSpecs: &mongodbatlas.Specs{InstanceSize: analyticsSize},
+				}},
+			}},
+		}
+	}
+
+	assert.Empty(t, advancedClusterRiskyChanges(newCluster("M30", "M30", 1), newCluster("M40_NVME", "M30", 2)))
+	assert.Equal(t, []string{
+		"instance size downgrade of analytics nodes in AWS US_EAST_1 M30 -> M20",
+		"number of shards reduction 2 -> 1",
+	}, advancedClusterRiskyChanges(newCluster("M30", "M30", 2), newCluster("M30", "M20", 1)))
+}
+
+func TestEnsureChangesApproved(t *testing.T) {
+	riskyChanges := []string{"instance size downgrade M30 -> M10"}
+	newCluster := func(annotations map[string]string) *v1.AtlasCluster {
+		cluster := v1.DefaultAWSCluster("default", "my-project")
+		cluster.Annotations = annotations
+		return cluster
+	}
+
+	t.Run("Approval is not required", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), nil)
+		assert.True(t, ensureChangesApproved(ctx, newCluster(nil), false, riskyChanges).IsOk())
+		assert.True(t, ensureChangesApproved(ctx, newCluster(nil), true, nil).IsOk())
+		assert.Empty(t, ctx.Conditions())
+	})
+	t.Run("Changes are held until approved", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), nil)
+		cluster := newCluster(nil)
+
+		result := ensureChangesApproved(ctx, cluster, true, riskyChanges)
+		assert.False(t, result.IsOk())
+		assert.Equal(t, workflow.RetryPermanent, result.RetryClass())
+
+		hash := changesHash(cluster.GetClusterName(), riskyChanges)
+		pending := ctx.Conditions()[0]
+		assert.Equal(t, status.PendingApprovalType, pending.Type)
+		assert.Equal(t, corev1.ConditionTrue, pending.Status)
+		assert.Contains(t, pending.Message, customresource.ChangesApprovedAnnotation+"="+hash)
+		assert.Contains(t, pending.Message, riskyChanges[0])
+
+		// The approval of the other changes doesn't count
+		cluster.Annotations = map[string]string{customresource.ChangesApprovedAnnotation: changesHash(cluster.GetClusterName(), []string{"cloud backup disabled"})}
+		assert.False(t, ensureChangesApproved(ctx, cluster, true, riskyChanges).IsOk())
+
+		cluster.Annotations = map[string]string{customresource.ChangesApprovedAnnotation: hash}
+		assert.True(t, ensureChangesApproved(ctx, cluster, true, riskyChanges).IsOk())
+		assert.Equal(t, corev1.ConditionFalse, ctx.Conditions()[0].Status)
+	})
+}
+
+func float64ptr(f float64) *float64 {
+	return &f
+}
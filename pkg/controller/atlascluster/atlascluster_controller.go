@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -29,8 +30,11 @@ import (
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/validate"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"go.mongodb.org/atlas/mongodbatlas"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
@@ -46,7 +50,9 @@ import (
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/ownership"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/tracing"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/watch"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
@@ -58,10 +64,17 @@ type AtlasClusterReconciler struct {
 	Client           client.Client
 	Log              *zap.SugaredLogger
 	Scheme           *runtime.Scheme
-	AtlasDomain      string
+	AtlasClients     *atlas.ClientCache
 	GlobalAPISecret  client.ObjectKey
 	GlobalPredicates []predicate.Predicate
 	EventRecorder    record.EventRecorder
+	// MaxConcurrentReconciles is the number of the AtlasClusters reconciled in parallel, defaults to 1
+	MaxConcurrentReconciles int
+	// PlanMode makes all the AtlasClusters reconciled in the plan mode (see customresource.ReconciliationShouldOnlyPlan)
+	PlanMode bool
+	// RequireChangeApproval holds the risky changes of all the AtlasClusters until they are approved
+	// (see customresource.ChangeApprovalRequired)
+	RequireChangeApproval bool
 }
 
 // +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasclusters,verbs=get;list;watch;create;update;patch;delete
@@ -83,7 +96,9 @@ type AtlasClusterReconciler struct {
 // +kubebuilder:rbac:groups="",namespace=default,resources=events,verbs=create;patch
 
 func (r *AtlasClusterReconciler) Reconcile(context context.Context, req ctrl.Request) (ctrl.Result, error) {
-	log := r.Log.With("atlascluster", req.NamespacedName)
+	context, span := tracing.StartReconcile(context, "AtlasCluster", req.NamespacedName)
+	defer span.End()
+	log := tracing.Logger(context, r.Log.With("atlascluster", req.NamespacedName))
 
 	cluster := &mdbv1.AtlasCluster{}
 	result := customresource.PrepareResource(r.Client, req, cluster, log)
@@ -97,37 +112,69 @@ func (r *AtlasClusterReconciler) Reconcile(context context.Context, req ctrl.Req
 	}
 
 	ctx := customresource.MarkReconciliationStarted(r.Client, cluster, log)
-	log.Infow("-> Starting AtlasCluster reconciliation", "spec", cluster.Spec, "status", cluster.Status)
+	ctx.Context = context
+	ctx.ObserveOnly = customresource.ReconciliationShouldOnlyObserve(cluster)
+	ctx.PlanOnly = r.PlanMode || ctx.ObserveOnly || customresource.ReconciliationShouldOnlyPlan(cluster)
+	log.Infow("-> Starting AtlasCluster reconciliation", "spec", cluster.Spec, "status", cluster.Status, "planOnly", ctx.PlanOnly, "observeOnly", ctx.ObserveOnly)
 	defer statushandler.Update(ctx, r.Client, r.EventRecorder, cluster)
+	defer ctx.CompletePlan()
+
+	if !cluster.GetDeletionTimestamp().IsZero() {
+		return r.handleProtectedDeletion(ctx, cluster).ReconcileResult(), nil
+	}
 
 	if err := validate.ClusterSpec(cluster.Spec); err != nil {
-		result := workflow.Terminate(workflow.Internal, err.Error())
+		result := workflow.Terminate(workflow.Internal, err.Error()).WithoutRetry()
 		ctx.SetConditionFromResult(status.ValidationSucceeded, result)
 		return result.ReconcileResult(), nil
 	}
 	ctx.SetConditionTrue(status.ValidationSucceeded)
 
+	// The protection is added before any changes are made in Atlas but removed only after the termination protection
+	// is disabled in Atlas, otherwise the Operator wouldn't be able to delete the cluster
+	if deletionShouldBeProtected(cluster, atlasTerminationProtection(ctx, cluster)) {
+		if result := r.ensureDeletionProtection(ctx, cluster, true); !result.IsOk() {
+			ctx.SetConditionFromResult(status.ClusterReadyType, result)
+			return result.ReconcileResult(), nil
+		}
+	}
+
 	project := &mdbv1.AtlasProject{}
 	if result := r.readProjectResource(cluster, project); !result.IsOk() {
 		ctx.SetConditionFromResult(status.ClusterReadyType, result)
 		return result.ReconcileResult(), nil
 	}
 
-	connection, err := atlas.ReadConnection(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
+	// The cluster is reconciled again once the project connection Secret is fixed
+	var connectionSecrets []client.ObjectKey
+	if project.ConnectionSecretObjectKey() != nil {
+		connectionSecrets = append(connectionSecrets, *project.ConnectionSecretObjectKey())
+	}
+	r.EnsureResourcesAreWatched(req.NamespacedName, "Secret", log, connectionSecrets...)
+
+	getAtlasClient := r.AtlasClients.Get
+	if ctx.PlanOnly {
+		getAtlasClient = r.AtlasClients.GetReadOnly
+	}
+	connection, atlasClient, err := getAtlasClient(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
 	if err != nil {
 		result := workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
+		if project.ConnectionSecretObjectKey() == nil {
+			// The global Secret is not watched so the reconciliation needs to be retried until it's fixed
+			result = result.WithRetryClass(workflow.RetryTransient)
+		}
 		ctx.SetConditionFromResult(status.ClusterReadyType, result)
 		return result.ReconcileResult(), nil
 	}
 	ctx.Connection = connection
+	ctx.Client = atlasClient
 
-	atlasClient, err := atlas.Client(r.AtlasDomain, connection, log)
-	if err != nil {
-		result := workflow.Terminate(workflow.Internal, err.Error())
-		ctx.SetConditionFromResult(status.ClusterReadyType, result)
-		return result.ReconcileResult(), nil
+	if customresource.MigrationToAdvancedRequested(cluster) {
+		if result := r.migrateToAdvancedCluster(ctx, project, cluster); !result.IsOk() {
+			ctx.SetConditionFromResult(status.ClusterReadyType, result)
+			return result.ReconcileResult(), nil
+		}
 	}
-	ctx.Client = atlasClient
 
 	handleCluster := r.selectClusterHandler(cluster)
 	if result, _ := handleCluster(ctx, project, cluster, req); !result.IsOk() {
@@ -142,6 +189,11 @@ func (r *AtlasClusterReconciler) Reconcile(context context.Context, req ctrl.Req
 		}
 	}
 
+	if result := r.ensureDeletionProtection(ctx, cluster, deletionShouldBeProtected(cluster, atlasTerminationProtection(ctx, cluster))); !result.IsOk() {
+		ctx.SetConditionFromResult(status.ClusterReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
 	return workflow.OK().ReconcileResult(), nil
 }
 
@@ -156,6 +208,7 @@ func (r *AtlasClusterReconciler) selectClusterHandler(cluster *mdbv1.AtlasCluste
 }
 
 func (r *AtlasClusterReconciler) handleClusterBackupSchedule(ctx *workflow.Context, c *mdbv1.AtlasCluster, projectID, cName string, backupEnabled bool, req ctrl.Request) error {
+	defer ctx.StartSpan("handleClusterBackupSchedule")()
 	if c.Spec.BackupScheduleRef.Name == "" && c.Spec.BackupScheduleRef.Namespace == "" {
 		r.Log.Debug("no backup schedule configured for the cluster")
 		return nil
@@ -170,7 +223,7 @@ func (r *AtlasClusterReconciler) handleClusterBackupSchedule(ctx *workflow.Conte
 	// Process backup schedule
 	bSchedule := &mdbv1.AtlasBackupSchedule{}
 	bKey := types.NamespacedName{Namespace: c.Spec.BackupScheduleRef.Namespace, Name: c.Spec.BackupScheduleRef.Name}
-	err := r.Client.Get(context.Background(), bKey, bSchedule)
+	err := r.Client.Get(ctx.Context, bKey, bSchedule)
 	if err != nil {
 		return fmt.Errorf("%v backupschedule resource is not found. e: %w", c.Spec.BackupScheduleRef, err)
 	}
@@ -179,7 +232,7 @@ func (r *AtlasClusterReconciler) handleClusterBackupSchedule(ctx *workflow.Conte
 	// Process backup policy for the schedule
 	bPolicy := &mdbv1.AtlasBackupPolicy{}
 	pKey := types.NamespacedName{Namespace: bSchedule.Spec.PolicyRef.Namespace, Name: bSchedule.Spec.PolicyRef.Name}
-	err = r.Client.Get(context.Background(), pKey, bPolicy)
+	err = r.Client.Get(ctx.Context, pKey, bPolicy)
 	if err != nil {
 		return fmt.Errorf("unable to get backuppolicy resource %s/%s. e: %w", bSchedule.Spec.PolicyRef.Namespace, bSchedule.Spec.PolicyRef.Name, err)
 	}
@@ -199,7 +252,6 @@ func (r *AtlasClusterReconciler) handleClusterBackupSchedule(ctx *workflow.Conte
 	// No matter what happens we should add watchers to both schedule and policy
 	defer func() {
 		r.EnsureMultiplesResourcesAreWatched(req.NamespacedName, r.Log, resourcesToWatch...)
-		r.Log.Debugf("watched backup schedule resources: %v\r\n", r.WatchedResources)
 	}()
 
 	apiPolicy := mongodbatlas.Policy{}
@@ -214,7 +266,11 @@ func (r *AtlasClusterReconciler) handleClusterBackupSchedule(ctx *workflow.Conte
 	}
 	apiScheduleRes.Policies = []mongodbatlas.Policy{apiPolicy}
 
-	currentSchedule, _, err := ctx.Client.CloudProviderSnapshotBackupPolicies.Delete(context.Background(), projectID, cName)
+	if ctx.PlanOnly {
+		return planBackupSchedule(ctx, projectID, cName, apiScheduleRes)
+	}
+
+	currentSchedule, _, err := ctx.Client.CloudProviderSnapshotBackupPolicies.Delete(ctx.Context, projectID, cName)
 	if err != nil {
 		r.Log.Debugf("unable to delete current backup policy for project: %v:%v, %v", projectID, cName, err)
 	}
@@ -225,18 +281,56 @@ func (r *AtlasClusterReconciler) handleClusterBackupSchedule(ctx *workflow.Conte
 	apiScheduleRes.Policies[0].ID = currentSchedule.Policies[0].ID
 
 	r.Log.Debugf("applying backupschedule policy: %v", *apiScheduleRes)
-	if _, _, err := ctx.Client.CloudProviderSnapshotBackupPolicies.Update(context.Background(), projectID, cName, apiScheduleRes); err != nil {
+	if _, _, err := ctx.Client.CloudProviderSnapshotBackupPolicies.Update(ctx.Context, projectID, cName, apiScheduleRes); err != nil {
 		return fmt.Errorf("unable to create backupschedule %v. e: %w", bKey, err)
 	}
 	r.Log.Infof("successfully updated backupschedule for cluster %v", cName)
 	return nil
 }
 
+// planBackupSchedule reports the update of the backup schedule if the one in Atlas differs from the desired one.
+// The backup policy is not deleted and recreated as it's done by handleClusterBackupSchedule.
+func planBackupSchedule(ctx *workflow.Context, projectID, cName string, desired *mongodbatlas.CloudProviderSnapshotBackupPolicy) error {
+	current, _, err := ctx.Client.CloudProviderSnapshotBackupPolicies.Get(ctx.Context, projectID, cName)
+	if err != nil {
+		return fmt.Errorf("unable to get backupschedule for the cluster %v. e: %w", cName, err)
+	}
+
+	if d := backupSchedulesDiff(*current, *desired); d != "" {
+		ctx.PlanChange(workflow.PlanUpdate, "backup schedule of "+cName, d)
+	}
+	return nil
+}
+
+// backupSchedulesDiff compares only the fields managed by the AtlasBackupSchedule and AtlasBackupPolicy
+func backupSchedulesDiff(atlasSchedule, operatorSchedule mongodbatlas.CloudProviderSnapshotBackupPolicy) string {
+	normalize := func(schedule mongodbatlas.CloudProviderSnapshotBackupPolicy) mongodbatlas.CloudProviderSnapshotBackupPolicy {
+		result := mongodbatlas.CloudProviderSnapshotBackupPolicy{
+			ReferenceHourOfDay:    schedule.ReferenceHourOfDay,
+			ReferenceMinuteOfHour: schedule.ReferenceMinuteOfHour,
+			RestoreWindowDays:     schedule.RestoreWindowDays,
+			UpdateSnapshots:       schedule.UpdateSnapshots,
+		}
+		for _, policy := range schedule.Policies {
+			items := make([]mongodbatlas.PolicyItem, 0, len(policy.PolicyItems))
+			for _, item := range policy.PolicyItems {
+				item.ID = ""
+				items = append(items, item)
+			}
+			result.Policies = append(result.Policies, mongodbatlas.Policy{PolicyItems: items})
+		}
+		return result
+	}
+	return cmp.Diff(normalize(atlasSchedule), normalize(operatorSchedule), cmpopts.EquateEmpty())
+}
+
 // handleAdvancedCluster ensures the state of the cluster using the Advanced Cluster API
 func (r *AtlasClusterReconciler) handleAdvancedCluster(ctx *workflow.Context, project *mdbv1.AtlasProject, cluster *mdbv1.AtlasCluster, req reconcile.Request) (workflow.Result, error) {
 	c, result := r.ensureAdvancedClusterState(ctx, project, cluster)
 	if c != nil && c.StateName != "" {
-		ctx.EnsureStatusOption(status.AtlasClusterStateNameOption(c.StateName))
+		ctx.
+			EnsureStatusOption(status.AtlasClusterStateNameOption(c.StateName)).
+			EnsureStatusOption(status.AtlasClusterTerminationProtectionOption(c.TerminationProtectionEnabled))
 	}
 
 	if !result.IsOk() {
@@ -264,15 +358,30 @@ func (r *AtlasClusterReconciler) handleAdvancedCluster(ctx *workflow.Context, pr
 
 // handleServerlessInstance ensures the state of the serverless instance using the serverless API
 func (r *AtlasClusterReconciler) handleServerlessInstance(ctx *workflow.Context, project *mdbv1.AtlasProject, cluster *mdbv1.AtlasCluster, req reconcile.Request) (workflow.Result, error) {
-	c, result := ensureServerlessInstanceState(ctx, project, cluster.Spec.ServerlessSpec)
-	return r.ensureConnectionSecretsAndSetStatusOptions(ctx, project, cluster, result, c)
+	instance, result := ensureServerlessInstanceState(ctx, project, cluster)
+	if instance == nil {
+		return r.ensureConnectionSecretsAndSetStatusOptions(ctx, project, cluster, result, nil)
+	}
+
+	ctx.
+		EnsureStatusOption(status.AtlasClusterTerminationProtectionOption(instance.TerminationProtectionEnabled)).
+		EnsureStatusOption(status.AtlasClusterServerlessBackupOption(instance.ServerlessBackupOptions)).
+		EnsureStatusOption(status.AtlasClusterTagsOption(serverlessTagsToStatus(instance.Tags)))
+
+	if result.IsOk() {
+		result = ensureServerlessPrivateEndpoints(ctx, project.ID(), cluster)
+	}
+
+	return r.ensureConnectionSecretsAndSetStatusOptions(ctx, project, cluster, result, &instance.Cluster)
 }
 
 // handleRegularCluster ensures the state of the cluster using the Regular Cluster API
 func (r *AtlasClusterReconciler) handleRegularCluster(ctx *workflow.Context, project *mdbv1.AtlasProject, cluster *mdbv1.AtlasCluster, req reconcile.Request) (workflow.Result, error) {
-	c, result := ensureClusterState(ctx, project, cluster)
+	c, result := r.ensureClusterState(ctx, project, cluster)
 	if c != nil && c.StateName != "" {
-		ctx.EnsureStatusOption(status.AtlasClusterStateNameOption(c.StateName))
+		ctx.
+			EnsureStatusOption(status.AtlasClusterStateNameOption(c.StateName)).
+			EnsureStatusOption(status.AtlasClusterTerminationProtectionOption(c.TerminationProtectionEnabled))
 	}
 
 	if !result.IsOk() {
@@ -313,8 +422,9 @@ func (r *AtlasClusterReconciler) ensureConnectionSecretsAndSetStatusOptions(ctx
 }
 
 func (r *AtlasClusterReconciler) handleAdvancedOptions(ctx *workflow.Context, project *mdbv1.AtlasProject, cluster *mdbv1.AtlasCluster) workflow.Result {
+	defer ctx.StartSpan("handleAdvancedOptions")()
 	clusterName := cluster.GetClusterName()
-	atlasArgs, _, err := ctx.Client.Clusters.GetProcessArgs(context.Background(), project.Status.ID, clusterName)
+	atlasArgs, _, err := ctx.Client.Clusters.GetProcessArgs(ctx.Context, project.Status.ID, clusterName)
 	if err != nil {
 		return workflow.Terminate(workflow.Internal, "cannot get process args")
 	}
@@ -324,8 +434,17 @@ func (r *AtlasClusterReconciler) handleAdvancedOptions(ctx *workflow.Context, pr
 	}
 
 	if !cluster.Spec.ProcessArgs.IsEqual(atlasArgs) {
-		options := mongodbatlas.ProcessArgs(*cluster.Spec.ProcessArgs)
-		args, resp, err := ctx.Client.Clusters.UpdateProcessArgs(context.Background(), project.Status.ID, clusterName, &options)
+		options, err := cluster.Spec.ProcessArgs.ToAtlas()
+		if err != nil {
+			return workflow.Terminate(workflow.Internal, "cannot convert process args: "+err.Error())
+		}
+
+		if ctx.PlanOnly {
+			ctx.PlanChange(workflow.PlanUpdate, "advanced configuration options of "+clusterName, cmp.Diff(atlasArgs, options, cmpopts.EquateEmpty()))
+			return workflow.OK()
+		}
+
+		args, resp, err := ctx.Client.Clusters.UpdateProcessArgs(ctx.Context, project.Status.ID, clusterName, options)
 		ctx.Log.Debugw("ProcessArgs Update", "args", args, "resp", resp.Body, "err", err)
 		if err != nil {
 			return workflow.Terminate(workflow.Internal, "cannot update process args")
@@ -345,7 +464,11 @@ func (r *AtlasClusterReconciler) readProjectResource(cluster *mdbv1.AtlasCluster
 }
 
 func (r *AtlasClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	c, err := controller.New("AtlasCluster", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("AtlasCluster", mgr, controller.Options{
+		Reconciler:              r,
+		RateLimiter:             workflow.RateLimiter(),
+		MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+	})
 	if err != nil {
 		return err
 	}
@@ -356,6 +479,12 @@ func (r *AtlasClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// Watch for the project connection Secrets
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, watch.NewSecretHandler(r.WatchedResources))
+	if err != nil {
+		return err
+	}
+
 	// Watch for Backup schedules
 	err = c.Watch(&source.Kind{Type: &mdbv1.AtlasBackupSchedule{}}, watch.NewBackupScheduleHandler(r.WatchedResources))
 	if err != nil {
@@ -393,6 +522,8 @@ func (r *AtlasClusterReconciler) Delete(e event.DeleteEvent) error {
 
 	if customresource.ResourceShouldBeLeftInAtlas(cluster) {
 		log.Infof("Not removing Atlas Cluster from Atlas as the '%s' annotation is set", customresource.ResourcePolicyAnnotation)
+	} else if r.PlanMode || customresource.ReconciliationShouldOnlyPlan(cluster) || customresource.ReconciliationShouldOnlyObserve(cluster) {
+		log.Infow("Not removing Atlas Cluster from Atlas in the plan or observe mode", "plannedChange", workflow.PlanDelete+" cluster "+cluster.GetClusterName())
 	} else if err := r.deleteClusterFromAtlas(cluster, project, log); err != nil {
 		log.Error("Failed to remove cluster from Atlas: %s", err)
 	}
@@ -416,20 +547,63 @@ func (r *AtlasClusterReconciler) Delete(e event.DeleteEvent) error {
 }
 
 func (r *AtlasClusterReconciler) deleteClusterFromAtlas(cluster *mdbv1.AtlasCluster, project *mdbv1.AtlasProject, log *zap.SugaredLogger) error {
-	connection, err := atlas.ReadConnection(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
+	_, atlasClient, err := r.AtlasClients.Get(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
 	if err != nil {
-		return err
+		return fmt.Errorf("cannot build Atlas client: %w", err)
 	}
 
-	atlasClient, err := atlas.Client(r.AtlasDomain, connection, log)
-	if err != nil {
-		return fmt.Errorf("cannot build Atlas client: %w", err)
+	// The termination protection enabled in Atlas (the spec may not specify it) is disabled before the deletion only if
+	// the user has confirmed it, see handleProtectedDeletion
+	var terminationProtection *bool
+	if cluster.IsServerless() {
+		// The serverless instances don't have the ownership labels, see ensureServerlessInstanceState
+		if !ownership.IsOwned(cluster, nil, cluster.Status.StateName != "") {
+			log.Info("Not removing the serverless instance from Atlas as it's not managed by the resource")
+			return nil
+		}
+		instance, resp, err := getServerlessInstance(context.Background(), atlasClient, project.Status.ID, cluster.GetClusterName())
+		switch {
+		case resp != nil && resp.StatusCode == http.StatusNotFound:
+			log.Info("Serverless instance doesn't exist or is already deleted")
+			return nil
+		case err != nil:
+			return fmt.Errorf("cannot read the serverless instance: %w", err)
+		}
+		terminationProtection = instance.TerminationProtectionEnabled
+	} else {
+		// The Advanced Clusters API returns the regular clusters as well
+		atlasCluster, _, err := atlasClient.AdvancedClusters.Get(context.Background(), project.Status.ID, cluster.GetClusterName())
+		var apiError *mongodbatlas.ErrorResponse
+		switch {
+		case errors.As(err, &apiError) && apiError.ErrorCode == atlas.ClusterNotFound:
+			log.Info("Cluster doesn't exist or is already deleted")
+			return nil
+		case err != nil:
+			return fmt.Errorf("cannot check the ownership of the cluster: %w", err)
+		case !ownership.IsOwned(cluster, atlasCluster.Labels, cluster.Status.StateName != ""):
+			log.Info("Not removing the cluster from Atlas as it's not managed by the resource")
+			return nil
+		}
+		terminationProtection = atlasCluster.TerminationProtectionEnabled
+	}
+	protected := terminationProtection != nil && *terminationProtection
+	if protected && !customresource.DeletionIsConfirmed(cluster) {
+		log.Infof("Not removing the cluster from Atlas as the termination protection is enabled and the deletion isn't confirmed with the %s=%s annotation",
+			customresource.DeletionConfirmedAnnotation, customresource.DeletionConfirmedTrue)
+		return nil
 	}
 
 	go func() {
 		timeout := time.Now().Add(workflow.DefaultTimeout)
 
 		for time.Now().Before(timeout) {
+			if protected {
+				// The deletion has been confirmed by the user so the termination protection needs to be disabled first
+				if err := disableTerminationProtection(context.Background(), atlasClient, project.Status.ID, cluster); err != nil {
+					log.Errorw("Cannot disable termination protection for Atlas cluster", "error", err)
+				}
+			}
+
 			deleteClusterFunc := atlasClient.Clusters.Delete
 			if cluster.Spec.AdvancedClusterSpec != nil {
 				deleteClusterFunc = atlasClient.AdvancedClusters.Delete
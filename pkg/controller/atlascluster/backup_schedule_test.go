@@ -0,0 +1,43 @@
+package atlascluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+func TestBackupSchedulesDiff(t *testing.T) {
+	operatorSchedule := testBackupSchedule()
+
+	t.Run("Schedules match ignoring the fields set by Atlas", func(t *testing.T) {
+		atlasSchedule := testBackupSchedule()
+		atlasSchedule.ClusterID = "123"
+		atlasSchedule.NextSnapshot = "2022-01-01T10:30:00Z"
+		atlasSchedule.Policies[0].ID = "456"
+		atlasSchedule.Policies[0].PolicyItems[0].ID = "789"
+
+		assert.Empty(t, backupSchedulesDiff(atlasSchedule, operatorSchedule))
+	})
+	t.Run("Schedules differ", func(t *testing.T) {
+		atlasSchedule := testBackupSchedule()
+		atlasSchedule.ReferenceHourOfDay = toptr.Int64ptr(11)
+
+		assert.NotEmpty(t, backupSchedulesDiff(atlasSchedule, operatorSchedule))
+	})
+}
+
+func testBackupSchedule() mongodbatlas.CloudProviderSnapshotBackupPolicy {
+	return mongodbatlas.CloudProviderSnapshotBackupPolicy{
+		ClusterName:           "test",
+		ReferenceHourOfDay:    toptr.Int64ptr(10),
+		ReferenceMinuteOfHour: toptr.Int64ptr(30),
+		RestoreWindowDays:     toptr.Int64ptr(2),
+		UpdateSnapshots:       toptr.Boolptr(false),
+		Policies: []mongodbatlas.Policy{{PolicyItems: []mongodbatlas.PolicyItem{
+			{FrequencyInterval: 6, FrequencyType: "hourly", RetentionValue: 2, RetentionUnit: "days"},
+		}}},
+	}
+}
@@ -1,7 +1,6 @@
 package atlascluster
 
 import (
-	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -16,13 +15,15 @@ import (
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/ownership"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/compat"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/stringutil"
 )
 
-func ensureClusterState(ctx *workflow.Context, project *mdbv1.AtlasProject, cluster *mdbv1.AtlasCluster) (atlasCluster *mongodbatlas.Cluster, _ workflow.Result) {
-	atlasCluster, resp, err := ctx.Client.Clusters.Get(context.Background(), project.Status.ID, cluster.Spec.ClusterSpec.Name)
+func (r *AtlasClusterReconciler) ensureClusterState(ctx *workflow.Context, project *mdbv1.AtlasProject, cluster *mdbv1.AtlasCluster) (atlasCluster *mongodbatlas.Cluster, _ workflow.Result) {
+	defer ctx.StartSpan("ensureClusterState")()
+	atlasCluster, resp, err := ctx.Client.Clusters.Get(ctx.Context, project.Status.ID, cluster.Spec.ClusterSpec.Name)
 	if err != nil {
 		if resp == nil {
 			return atlasCluster, workflow.Terminate(workflow.Internal, err.Error())
@@ -36,17 +37,25 @@ func ensureClusterState(ctx *workflow.Context, project *mdbv1.AtlasProject, clus
 		if err != nil {
 			return atlasCluster, workflow.Terminate(workflow.Internal, err.Error())
 		}
+		atlasCluster.Labels = ownership.WithLabels(atlasCluster.Labels, cluster)
+
+		if ctx.PlanOnly {
+			ctx.PlanChange(workflow.PlanCreate, "cluster "+cluster.Spec.ClusterSpec.Name, cmp.Diff(mongodbatlas.Cluster{}, *atlasCluster, cmpopts.EquateEmpty()))
+			return nil, workflow.Planned("the cluster is not created in the plan mode")
+		}
 
 		ctx.Log.Infof("Cluster %s doesn't exist in Atlas - creating", cluster.Spec.ClusterSpec.Name)
-		atlasCluster, _, err = ctx.Client.Clusters.Create(context.Background(), project.Status.ID, atlasCluster)
+		atlasCluster, _, err = ctx.Client.Clusters.Create(ctx.Context, project.Status.ID, atlasCluster)
 		if err != nil {
 			return atlasCluster, workflow.Terminate(workflow.ClusterNotCreatedInAtlas, err.Error())
 		}
+	} else if result := ownership.Check(ctx, cluster, "cluster "+cluster.Spec.ClusterSpec.Name, atlasCluster.Labels, cluster.Status.StateName != ""); !result.IsOk() {
+		return nil, result
 	}
 
 	switch atlasCluster.StateName {
 	case "IDLE":
-		return regularClusterIdle(ctx, project, cluster, atlasCluster)
+		return r.regularClusterIdle(ctx, project, cluster, atlasCluster)
 	case "CREATING":
 		return atlasCluster, workflow.InProgress(workflow.ClusterCreating, "cluster is provisioning")
 
@@ -60,16 +69,28 @@ func ensureClusterState(ctx *workflow.Context, project *mdbv1.AtlasProject, clus
 	}
 }
 
-func regularClusterIdle(ctx *workflow.Context, project *mdbv1.AtlasProject, cluster *mdbv1.AtlasCluster, atlasCluster *mongodbatlas.Cluster) (*mongodbatlas.Cluster, workflow.Result) {
+func (r *AtlasClusterReconciler) regularClusterIdle(ctx *workflow.Context, project *mdbv1.AtlasProject, cluster *mdbv1.AtlasCluster, atlasCluster *mongodbatlas.Cluster) (*mongodbatlas.Cluster, workflow.Result) {
 	resultingCluster, err := MergedCluster(*atlasCluster, cluster.Spec)
 	if err != nil {
 		return atlasCluster, workflow.Terminate(workflow.Internal, err.Error())
 	}
+	resultingCluster.Labels = ownership.WithLabels(resultingCluster.Labels, cluster)
 
 	if done := ClustersEqual(ctx.Log, *atlasCluster, resultingCluster); done {
+		clearPendingApproval(ctx)
 		return atlasCluster, workflow.OK()
 	}
 
+	if ctx.PlanOnly {
+		ctx.PlanChange(workflow.PlanUpdate, "cluster "+cluster.Spec.ClusterSpec.Name, ClustersDiff(*atlasCluster, resultingCluster))
+		return atlasCluster, ctx.UpdatePlanned("the cluster is not updated in the plan mode")
+	}
+
+	riskyChanges := regularClusterRiskyChanges(*atlasCluster, resultingCluster)
+	if result := ensureChangesApproved(ctx, cluster, r.changeApprovalRequired(cluster), riskyChanges); !result.IsOk() {
+		return atlasCluster, result
+	}
+
 	if cluster.Spec.ClusterSpec.Paused != nil {
 		if atlasCluster.Paused == nil || *atlasCluster.Paused != *cluster.Spec.ClusterSpec.Paused {
 			// paused is different from Atlas
@@ -85,7 +106,7 @@ func regularClusterIdle(ctx *workflow.Context, project *mdbv1.AtlasProject, clus
 
 	resultingCluster = cleanupCluster(resultingCluster)
 
-	atlasCluster, _, err = ctx.Client.Clusters.Update(context.Background(), project.Status.ID, cluster.Spec.ClusterSpec.Name, &resultingCluster)
+	atlasCluster, _, err = ctx.Client.Clusters.Update(ctx.Context, project.Status.ID, cluster.Spec.ClusterSpec.Name, &resultingCluster)
 	if err != nil {
 		return atlasCluster, workflow.Terminate(workflow.ClusterNotUpdatedInAtlas, err.Error())
 	}
@@ -178,10 +199,7 @@ func mergeRegionConfigs(atlasSpecs []mongodbatlas.ReplicationSpec, operatorSpecs
 
 // ClustersEqual compares two Atlas Clusters
 func ClustersEqual(log *zap.SugaredLogger, clusterAtlas mongodbatlas.Cluster, clusterOperator mongodbatlas.Cluster) bool {
-	clusterAtlas = removeOutdatedFields(&clusterAtlas, &clusterOperator)
-	clusterOperator = removeOutdatedFields(&clusterOperator, nil)
-
-	d := cmp.Diff(clusterAtlas, clusterOperator, cmpopts.EquateEmpty())
+	d := ClustersDiff(clusterAtlas, clusterOperator)
 	if d != "" {
 		log.Debugf("Clusters are different: %s", d)
 	}
@@ -189,9 +207,18 @@ func ClustersEqual(log *zap.SugaredLogger, clusterAtlas mongodbatlas.Cluster, cl
 	return d == ""
 }
 
+// ClustersDiff returns the human-readable difference between two Atlas Clusters, empty if they are equal
+func ClustersDiff(clusterAtlas mongodbatlas.Cluster, clusterOperator mongodbatlas.Cluster) string {
+	clusterAtlas = removeOutdatedFields(&clusterAtlas, &clusterOperator)
+	clusterOperator = removeOutdatedFields(&clusterOperator, nil)
+
+	return cmp.Diff(clusterAtlas, clusterOperator, cmpopts.EquateEmpty())
+}
+
 func (r *AtlasClusterReconciler) ensureConnectionSecrets(ctx *workflow.Context, project *mdbv1.AtlasProject, name string, connectionStrings *mongodbatlas.ConnectionStrings, clusterResource *mdbv1.AtlasCluster) workflow.Result {
+	defer ctx.StartSpan("ensureConnectionSecrets")()
 	databaseUsers := mdbv1.AtlasDatabaseUserList{}
-	err := r.Client.List(context.TODO(), &databaseUsers, client.InNamespace(project.Namespace))
+	err := r.Client.List(ctx.Context, &databaseUsers, client.InNamespace(project.Namespace))
 	if err != nil {
 		return workflow.Terminate(workflow.Internal, err.Error())
 	}
@@ -222,10 +249,16 @@ func (r *AtlasClusterReconciler) ensureConnectionSecrets(ctx *workflow.Context,
 		}
 
 		data := connectionsecret.ConnectionData{
-			DBUserName: dbUser.Spec.Username,
-			ConnURL:    connectionStrings.Standard,
-			SrvConnURL: connectionStrings.StandardSrv,
-			Password:   password,
+			DBUserName:              dbUser.Spec.Username,
+			ConnURL:                 connectionStrings.Standard,
+			SrvConnURL:              connectionStrings.StandardSrv,
+			PvtConnURL:              connectionStrings.Private,
+			PvtSrvConnURL:           connectionStrings.PrivateSrv,
+			PrivateEndpointConnURLs: connectionsecret.PrivateEndpointConnURLsFromAtlas(connectionStrings),
+			Password:                password,
+		}
+		if clusterResource.IsServerless() {
+			data.PvtConnURL, data.PvtSrvConnURL = serverlessPrivateConnectionStrings(connectionStrings)
 		}
 
 		secretName, err := connectionsecret.Ensure(r.Client, project.Namespace, project.Spec.Name, project.ID(), name, data)
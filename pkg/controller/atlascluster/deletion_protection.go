@@ -0,0 +1,95 @@
+package atlascluster
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+// deletionShouldBeProtected returns true if the removal of the AtlasCluster resource must be confirmed by the user.
+// The termination protection may be enabled in the spec or in Atlas ('atlasProtected'), Atlas keeps it enabled if the
+// spec doesn't specify it. There's no need to protect the resource if the cluster is left in Atlas anyway.
+func deletionShouldBeProtected(cluster *mdbv1.AtlasCluster, atlasProtected bool) bool {
+	return (cluster.TerminationProtectionEnabled() || atlasProtected) && !customresource.ResourceShouldBeLeftInAtlas(cluster)
+}
+
+// atlasTerminationProtection returns true if Atlas reports the termination protection enabled for the cluster. The
+// state read by the current reconciliation takes precedence over the one in the status.
+func atlasTerminationProtection(ctx *workflow.Context, cluster *mdbv1.AtlasCluster) bool {
+	clusterStatus := cluster.Status
+	for _, o := range ctx.StatusOptions() {
+		if option, ok := o.(status.AtlasClusterStatusOption); ok {
+			option(&clusterStatus)
+		}
+	}
+	return clusterStatus.TerminationProtectionEnabled != nil && *clusterStatus.TerminationProtectionEnabled
+}
+
+// ensureDeletionProtection adds or removes the deletion protection finalizer to/from the AtlasCluster resource.
+func (r *AtlasClusterReconciler) ensureDeletionProtection(ctx *workflow.Context, cluster *mdbv1.AtlasCluster, protected bool) workflow.Result {
+	if protected == controllerutil.ContainsFinalizer(cluster, customresource.DeletionProtectionFinalizer) {
+		return workflow.OK()
+	}
+
+	if protected {
+		ctx.Log.Infof("Termination protection is enabled - adding the %s finalizer", customresource.DeletionProtectionFinalizer)
+		controllerutil.AddFinalizer(cluster, customresource.DeletionProtectionFinalizer)
+	} else {
+		ctx.Log.Infof("Termination protection is disabled - removing the %s finalizer", customresource.DeletionProtectionFinalizer)
+		controllerutil.RemoveFinalizer(cluster, customresource.DeletionProtectionFinalizer)
+	}
+
+	if err := r.Client.Update(ctx.Context, cluster); err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	return workflow.OK()
+}
+
+// handleProtectedDeletion holds the removal of the AtlasCluster resource with the deletion protection finalizer until
+// the user confirms it with the annotation. The cluster is removed from Atlas in the 'Delete' handler as usual once
+// the finalizer is removed. The spec isn't checked as the termination protection may be removed from it along with
+// the deletion while it's still enabled in Atlas.
+func (r *AtlasClusterReconciler) handleProtectedDeletion(ctx *workflow.Context, cluster *mdbv1.AtlasCluster) workflow.Result {
+	if !controllerutil.ContainsFinalizer(cluster, customresource.DeletionProtectionFinalizer) {
+		return workflow.OK()
+	}
+
+	if !customresource.ResourceShouldBeLeftInAtlas(cluster) && !customresource.DeletionIsConfirmed(cluster) {
+		result := workflow.Terminate(
+			workflow.ClusterDeletionNotConfirmed,
+			fmt.Sprintf("termination protection is enabled for the cluster, set the annotation %s=%s to confirm the deletion", customresource.DeletionConfirmedAnnotation, customresource.DeletionConfirmedTrue),
+		).WithoutRetry()
+		ctx.SetConditionFromResult(status.ClusterReadyType, result)
+		return result
+	}
+
+	return r.ensureDeletionProtection(ctx, cluster, false)
+}
+
+// disableTerminationProtection turns off the termination protection in Atlas so that the cluster can be deleted.
+func disableTerminationProtection(ctx context.Context, client mongodbatlas.Client, projectID string, cluster *mdbv1.AtlasCluster) error {
+	var err error
+	switch {
+	case cluster.IsAdvancedCluster():
+		_, _, err = client.AdvancedClusters.Update(ctx, projectID, cluster.GetClusterName(), &mongodbatlas.AdvancedCluster{
+			TerminationProtectionEnabled: toptr.Boolptr(false),
+		})
+	case cluster.IsServerless():
+		_, _, err = updateServerlessInstance(ctx, client, projectID, cluster.GetClusterName(), serverlessUpdate{
+			TerminationProtectionEnabled: toptr.Boolptr(false),
+		})
+	default:
+		_, _, err = client.Clusters.Update(ctx, projectID, cluster.GetClusterName(), &mongodbatlas.Cluster{
+			TerminationProtectionEnabled: toptr.Boolptr(false),
+		})
+	}
+	return err
+}
@@ -0,0 +1,106 @@
+package atlascluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	v1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+func TestDeletionShouldBeProtected(t *testing.T) {
+	t.Run("Termination protection is not set", func(t *testing.T) {
+		assert.False(t, deletionShouldBeProtected(v1.DefaultAWSCluster("default", "my-project"), false))
+	})
+
+	t.Run("Termination protection is enabled in Atlas only", func(t *testing.T) {
+		assert.True(t, deletionShouldBeProtected(v1.DefaultAWSCluster("default", "my-project"), true))
+	})
+
+	t.Run("Termination protection is enabled", func(t *testing.T) {
+		cluster := v1.DefaultAwsAdvancedCluster("default", "my-project")
+		cluster.Spec.AdvancedClusterSpec.TerminationProtectionEnabled = toptr.Boolptr(true)
+		assert.True(t, deletionShouldBeProtected(cluster, false))
+	})
+
+	t.Run("Cluster is left in Atlas", func(t *testing.T) {
+		cluster := v1.DefaultAWSCluster("default", "my-project")
+		cluster.Spec.ClusterSpec.TerminationProtectionEnabled = toptr.Boolptr(true)
+		cluster.Annotations = map[string]string{customresource.ResourcePolicyAnnotation: customresource.ResourcePolicyKeep}
+		assert.False(t, deletionShouldBeProtected(cluster, true))
+	})
+}
+
+func TestHandleProtectedDeletion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1.AddToScheme(scheme))
+
+	newReconciler := func(cluster *v1.AtlasCluster) *AtlasClusterReconciler {
+		return &AtlasClusterReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).Build()}
+	}
+	newProtectedCluster := func() *v1.AtlasCluster {
+		cluster := v1.DefaultAWSCluster("default", "my-project")
+		cluster.Spec.ClusterSpec.TerminationProtectionEnabled = toptr.Boolptr(true)
+		cluster.Finalizers = []string{customresource.DeletionProtectionFinalizer}
+		cluster.DeletionTimestamp = &metav1.Time{}
+		return cluster
+	}
+
+	t.Run("Deletion is held until confirmed", func(t *testing.T) {
+		cluster := newProtectedCluster()
+		r := newReconciler(cluster)
+		ctx := workflow.NewContext(zap.S(), nil)
+
+		result := r.handleProtectedDeletion(ctx, cluster)
+		assert.False(t, result.IsOk())
+
+		updated := &v1.AtlasCluster{}
+		require.NoError(t, r.Client.Get(context.Background(), kube.ObjectKeyFromObject(cluster), updated))
+		assert.True(t, controllerutil.ContainsFinalizer(updated, customresource.DeletionProtectionFinalizer))
+	})
+
+	t.Run("Confirmed deletion removes the finalizer", func(t *testing.T) {
+		cluster := newProtectedCluster()
+		cluster.Annotations = map[string]string{customresource.DeletionConfirmedAnnotation: customresource.DeletionConfirmedTrue}
+		r := newReconciler(cluster)
+		ctx := workflow.NewContext(zap.S(), nil)
+
+		result := r.handleProtectedDeletion(ctx, cluster)
+		assert.True(t, result.IsOk())
+		assert.False(t, controllerutil.ContainsFinalizer(cluster, customresource.DeletionProtectionFinalizer))
+	})
+
+	t.Run("Deletion is held if the protection is removed from the spec", func(t *testing.T) {
+		cluster := newProtectedCluster()
+		cluster.Spec.ClusterSpec.TerminationProtectionEnabled = nil
+		r := newReconciler(cluster)
+		ctx := workflow.NewContext(zap.S(), nil)
+
+		assert.False(t, r.handleProtectedDeletion(ctx, cluster).IsOk())
+		assert.True(t, controllerutil.ContainsFinalizer(cluster, customresource.DeletionProtectionFinalizer))
+	})
+}
+
+func TestAtlasTerminationProtection(t *testing.T) {
+	cluster := v1.DefaultAWSCluster("default", "my-project")
+	cluster.Status.TerminationProtectionEnabled = toptr.Boolptr(true)
+
+	ctx := workflow.NewContext(zap.S(), nil)
+	assert.True(t, atlasTerminationProtection(ctx, cluster))
+
+	ctx.EnsureStatusOption(status.AtlasClusterTerminationProtectionOption(toptr.Boolptr(false)))
+	assert.False(t, atlasTerminationProtection(ctx, cluster))
+}
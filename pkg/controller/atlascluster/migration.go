@@ -0,0 +1,232 @@
+package atlascluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/ownership"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+// migrateToAdvancedCluster rewrites the 'spec.clusterSpec' of the AtlasCluster into the equivalent
+// 'spec.advancedClusterSpec'. The cluster in Atlas is not changed: the Advanced Clusters API manages the regular
+// clusters as well, so the migrated resource is checked against the cluster returned by this API and the resource is
+// rewritten only if they are equal. In the plan mode the migration is only recorded as a planned change.
+func (r *AtlasClusterReconciler) migrateToAdvancedCluster(ctx *workflow.Context, project *mdbv1.AtlasProject, cluster *mdbv1.AtlasCluster) workflow.Result {
+	defer ctx.StartSpan("migrateToAdvancedCluster")()
+	if cluster.Spec.ClusterSpec == nil {
+		ctx.Log.Infof("The cluster doesn't use spec.clusterSpec - removing the %s annotation", customresource.MigrateToAdvancedAnnotation)
+		if ctx.PlanOnly {
+			ctx.PlanChange(workflow.PlanUpdate, "AtlasCluster "+cluster.Name, "remove the "+customresource.MigrateToAdvancedAnnotation+" annotation")
+			return workflow.OK()
+		}
+		return r.removeMigrationAnnotation(cluster)
+	}
+
+	advancedSpec, err := advancedClusterSpecFromLegacy(cluster.Spec.ClusterSpec)
+	if err != nil {
+		return workflow.Terminate(workflow.ClusterMigrationFailed, err.Error()).WithoutRetry()
+	}
+
+	atlasCluster, _, err := ctx.Client.AdvancedClusters.Get(ctx.Context, project.ID(), cluster.GetClusterName())
+	if err != nil {
+		return workflow.Terminate(workflow.ClusterMigrationFailed, err.Error())
+	}
+	if atlasCluster.StateName != "IDLE" {
+		return workflow.InProgress(workflow.ClusterUpdating, "the cluster must be IDLE to be migrated to spec.advancedClusterSpec")
+	}
+
+	migratedSpec := *cluster.Spec.DeepCopy()
+	migratedSpec.ClusterSpec = nil
+	migratedSpec.AdvancedClusterSpec = advancedSpec
+
+	mergedCluster, err := MergedAdvancedCluster(*atlasCluster, migratedSpec)
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	// The ownership labels are not part of the spec
+	atlasCluster.Labels = ownership.WithoutLabels(atlasCluster.Labels)
+	mergedCluster.Labels = ownership.WithoutLabels(mergedCluster.Labels)
+	if !AdvancedClustersEqual(ctx.Log, *atlasCluster, mergedCluster) {
+		return workflow.Terminate(
+			workflow.ClusterMigrationFailed,
+			"spec.advancedClusterSpec translated from spec.clusterSpec doesn't match the cluster in Atlas, the resource is left unchanged (see the debug logs for the difference)",
+		).WithoutRetry()
+	}
+
+	if ctx.PlanOnly {
+		ctx.PlanChange(workflow.PlanUpdate, "AtlasCluster "+cluster.Name+" (migration to spec.advancedClusterSpec)", cmp.Diff(cluster.Spec, migratedSpec, cmpopts.EquateEmpty()))
+		return workflow.OK()
+	}
+
+	ctx.Log.Infow("Migrating spec.clusterSpec to spec.advancedClusterSpec", "advancedClusterSpec", advancedSpec)
+	cluster.Spec = migratedSpec
+	return r.removeMigrationAnnotation(cluster)
+}
+
+func (r *AtlasClusterReconciler) removeMigrationAnnotation(cluster *mdbv1.AtlasCluster) workflow.Result {
+	annotations := cluster.GetAnnotations()
+	delete(annotations, customresource.MigrateToAdvancedAnnotation)
+	cluster.SetAnnotations(annotations)
+
+	if err := r.Client.Update(context.Background(), cluster); err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	return workflow.OK()
+}
+
+// advancedClusterSpecFromLegacy translates the regular cluster spec into the advanced cluster one. The fields not
+// specified in the regular spec are left empty so that the Operator takes them from Atlas.
+func advancedClusterSpecFromLegacy(spec *mdbv1.ClusterSpec) (*mdbv1.AdvancedClusterSpec, error) {
+	if spec.ProviderSettings == nil {
+		return nil, errors.New("spec.clusterSpec.providerSettings must be specified")
+	}
+
+	result := &mdbv1.AdvancedClusterSpec{
+		Name:                         spec.Name,
+		ClusterType:                  string(spec.ClusterType),
+		BackupEnabled:                spec.ProviderBackupEnabled,
+		BiConnector:                  spec.BIConnector,
+		DiskSizeGB:                   spec.DiskSizeGB,
+		EncryptionAtRestProvider:     spec.EncryptionAtRestProvider,
+		Labels:                       spec.Labels,
+		MongoDBMajorVersion:          spec.MongoDBMajorVersion,
+		Paused:                       spec.Paused,
+		PitEnabled:                   spec.PitEnabled,
+		TerminationProtectionEnabled: spec.TerminationProtectionEnabled,
+	}
+	// Same as for the regular clusters the sizes managed by the auto-scaling are taken from Atlas
+	if spec.AutoScaling != nil && spec.AutoScaling.DiskGBEnabled != nil && *spec.AutoScaling.DiskGBEnabled {
+		result.DiskSizeGB = nil
+	}
+
+	if len(spec.ReplicationSpecs) == 0 {
+		if spec.ProviderSettings.RegionName == "" {
+			return nil, errors.New("spec.clusterSpec.providerSettings.regionName must be specified if there are no replicationSpecs")
+		}
+		priority := 7
+		numShards := 1
+		if spec.NumShards != nil {
+			numShards = *spec.NumShards
+		}
+		result.ReplicationSpecs = []*mdbv1.AdvancedReplicationSpec{{
+			NumShards: numShards,
+			RegionConfigs: []*mdbv1.AdvancedRegionConfig{
+				advancedRegionConfig(spec, spec.ProviderSettings.RegionName, &priority, nil, nil, nil),
+			},
+		}}
+		return result, nil
+	}
+
+	for i, replicationSpec := range spec.ReplicationSpecs {
+		if len(replicationSpec.RegionsConfig) == 0 {
+			return nil, fmt.Errorf("spec.clusterSpec.replicationSpecs[%d].regionsConfig must be specified", i)
+		}
+		advancedReplicationSpec := &mdbv1.AdvancedReplicationSpec{
+			NumShards: 1,
+			ZoneName:  replicationSpec.ZoneName,
+		}
+		if replicationSpec.NumShards != nil {
+			advancedReplicationSpec.NumShards = int(*replicationSpec.NumShards)
+		}
+
+		// Atlas returns the regions ordered by the priority
+		regions := make([]string, 0, len(replicationSpec.RegionsConfig))
+		for region := range replicationSpec.RegionsConfig {
+			regions = append(regions, region)
+		}
+		sort.Slice(regions, func(i, j int) bool {
+			pi, pj := regionPriority(replicationSpec.RegionsConfig[regions[i]]), regionPriority(replicationSpec.RegionsConfig[regions[j]])
+			if pi != pj {
+				return pi > pj
+			}
+			return regions[i] < regions[j]
+		})
+
+		for _, region := range regions {
+			config := replicationSpec.RegionsConfig[region]
+			advancedReplicationSpec.RegionConfigs = append(advancedReplicationSpec.RegionConfigs, advancedRegionConfig(
+				spec, region, toIntPtr(config.Priority), toIntPtr(config.ElectableNodes), toIntPtr(config.ReadOnlyNodes), toIntPtr(config.AnalyticsNodes),
+			))
+		}
+		result.ReplicationSpecs = append(result.ReplicationSpecs, advancedReplicationSpec)
+	}
+	return result, nil
+}
+
+func advancedRegionConfig(spec *mdbv1.ClusterSpec, region string, priority, electableNodes, readOnlyNodes, analyticsNodes *int) *mdbv1.AdvancedRegionConfig {
+	settings := spec.ProviderSettings
+	instanceSize := settings.InstanceSizeName
+	if spec.AutoScaling != nil && spec.AutoScaling.Compute != nil && spec.AutoScaling.Compute.Enabled != nil && *spec.AutoScaling.Compute.Enabled {
+		instanceSize = ""
+	}
+	nodeSpecs := func(nodeCount *int) *mdbv1.Specs {
+		specs := &mdbv1.Specs{
+			InstanceSize: instanceSize,
+			NodeCount:    nodeCount,
+		}
+		if settings.ProviderName == provider.ProviderAWS {
+			specs.DiskIOPS = settings.DiskIOPS
+			specs.EbsVolumeType = settings.VolumeType
+		}
+		return specs
+	}
+
+	result := &mdbv1.AdvancedRegionConfig{
+		BackingProviderName: settings.BackingProviderName,
+		Priority:            priority,
+		ProviderName:        string(settings.ProviderName),
+		RegionName:          region,
+		ElectableSpecs:      nodeSpecs(electableNodes),
+	}
+	if readOnlyNodes != nil {
+		result.ReadOnlySpecs = nodeSpecs(readOnlyNodes)
+	}
+	if analyticsNodes != nil {
+		result.AnalyticsSpecs = nodeSpecs(analyticsNodes)
+	}
+
+	// The auto-scaling is configured for the whole cluster in the regular API and for each region in the advanced one
+	if spec.AutoScaling != nil || (settings.AutoScaling != nil && settings.AutoScaling.Compute != nil) {
+		autoScaling := &mdbv1.AutoScalingSpec{}
+		if spec.AutoScaling != nil {
+			autoScaling.DiskGBEnabled = spec.AutoScaling.DiskGBEnabled
+			if spec.AutoScaling.Compute != nil {
+				compute := *spec.AutoScaling.Compute
+				autoScaling.Compute = &compute
+			}
+		}
+		if settings.AutoScaling != nil && settings.AutoScaling.Compute != nil {
+			if autoScaling.Compute == nil {
+				autoScaling.Compute = &mdbv1.ComputeSpec{}
+			}
+			autoScaling.Compute.MinInstanceSize = settings.AutoScaling.Compute.MinInstanceSize
+			autoScaling.Compute.MaxInstanceSize = settings.AutoScaling.Compute.MaxInstanceSize
+		}
+		result.AutoScaling = autoScaling
+	}
+	return result
+}
+
+func regionPriority(config mdbv1.RegionsConfig) int64 {
+	if config.Priority == nil {
+		return 0
+	}
+	return *config.Priority
+}
+
+func toIntPtr(value *int64) *int {
+	if value == nil {
+		return nil
+	}
+	result := int(*value)
+	return &result
+}
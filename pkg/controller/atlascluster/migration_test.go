@@ -0,0 +1,188 @@
+package atlascluster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+func TestAdvancedClusterSpecFromLegacy(t *testing.T) {
+	t.Run("Single region cluster", func(t *testing.T) {
+		cluster := v1.DefaultAWSCluster("default", "my-project")
+		cluster.Spec.ClusterSpec.ProviderBackupEnabled = toptr.Boolptr(true)
+
+		advancedSpec, err := advancedClusterSpecFromLegacy(cluster.Spec.ClusterSpec)
+		require.NoError(t, err)
+
+		assert.Equal(t, cluster.Spec.ClusterSpec.Name, advancedSpec.Name)
+		assert.Equal(t, toptr.Boolptr(true), advancedSpec.BackupEnabled)
+		require.Len(t, advancedSpec.ReplicationSpecs, 1)
+		assert.Equal(t, 1, advancedSpec.ReplicationSpecs[0].NumShards)
+		require.Len(t, advancedSpec.ReplicationSpecs[0].RegionConfigs, 1)
+
+		regionConfig := advancedSpec.ReplicationSpecs[0].RegionConfigs[0]
+		assert.Equal(t, "AWS", regionConfig.ProviderName)
+		assert.Equal(t, cluster.Spec.ClusterSpec.ProviderSettings.RegionName, regionConfig.RegionName)
+		assert.Equal(t, 7, *regionConfig.Priority)
+		assert.Equal(t, cluster.Spec.ClusterSpec.ProviderSettings.InstanceSizeName, regionConfig.ElectableSpecs.InstanceSize)
+		assert.Nil(t, regionConfig.ReadOnlySpecs)
+	})
+
+	t.Run("Multi region cluster", func(t *testing.T) {
+		cluster := v1.DefaultAWSCluster("default", "my-project")
+		cluster.Spec.ClusterSpec.ClusterType = v1.TypeReplicaSet
+		cluster.Spec.ClusterSpec.ReplicationSpecs = []v1.ReplicationSpec{{
+			NumShards: toptr.Int64ptr(1),
+			ZoneName:  "Zone 1",
+			RegionsConfig: map[string]v1.RegionsConfig{
+				"US_WEST_2": {ElectableNodes: toptr.Int64ptr(2), Priority: toptr.Int64ptr(6), ReadOnlyNodes: toptr.Int64ptr(0), AnalyticsNodes: toptr.Int64ptr(1)},
+				"US_EAST_1": {ElectableNodes: toptr.Int64ptr(3), Priority: toptr.Int64ptr(7), ReadOnlyNodes: toptr.Int64ptr(0)},
+			},
+		}}
+
+		advancedSpec, err := advancedClusterSpecFromLegacy(cluster.Spec.ClusterSpec)
+		require.NoError(t, err)
+
+		assert.Equal(t, "REPLICASET", advancedSpec.ClusterType)
+		regionConfigs := advancedSpec.ReplicationSpecs[0].RegionConfigs
+		require.Len(t, regionConfigs, 2)
+		assert.Equal(t, "US_EAST_1", regionConfigs[0].RegionName)
+		assert.Equal(t, 3, *regionConfigs[0].ElectableSpecs.NodeCount)
+		assert.Nil(t, regionConfigs[0].AnalyticsSpecs)
+		assert.Equal(t, "US_WEST_2", regionConfigs[1].RegionName)
+		assert.Equal(t, 6, *regionConfigs[1].Priority)
+		assert.Equal(t, 1, *regionConfigs[1].AnalyticsSpecs.NodeCount)
+	})
+
+	t.Run("Compute auto-scaling", func(t *testing.T) {
+		cluster := v1.DefaultAWSCluster("default", "my-project")
+		cluster.Spec.ClusterSpec.AutoScaling = &v1.AutoScalingSpec{
+			DiskGBEnabled: toptr.Boolptr(true),
+			Compute:       &v1.ComputeSpec{Enabled: toptr.Boolptr(true), ScaleDownEnabled: toptr.Boolptr(true)},
+		}
+		cluster.Spec.ClusterSpec.DiskSizeGB = toptr.Intptr(20)
+		cluster.Spec.ClusterSpec.ProviderSettings.AutoScaling = &v1.AutoScalingSpec{
+			Compute: &v1.ComputeSpec{MinInstanceSize: "M10", MaxInstanceSize: "M40"},
+		}
+
+		advancedSpec, err := advancedClusterSpecFromLegacy(cluster.Spec.ClusterSpec)
+		require.NoError(t, err)
+
+		assert.Nil(t, advancedSpec.DiskSizeGB)
+		regionConfig := advancedSpec.ReplicationSpecs[0].RegionConfigs[0]
+		assert.Empty(t, regionConfig.ElectableSpecs.InstanceSize)
+		assert.Equal(t, &v1.ComputeSpec{Enabled: toptr.Boolptr(true), ScaleDownEnabled: toptr.Boolptr(true), MinInstanceSize: "M10", MaxInstanceSize: "M40"}, regionConfig.AutoScaling.Compute)
+	})
+
+	t.Run("No region", func(t *testing.T) {
+		cluster := v1.DefaultAWSCluster("default", "my-project")
+		cluster.Spec.ClusterSpec.ProviderSettings.RegionName = ""
+
+		_, err := advancedClusterSpecFromLegacy(cluster.Spec.ClusterSpec)
+		assert.Error(t, err)
+	})
+}
+
+func TestMigratedClusterIsEqualToAtlas(t *testing.T) {
+	cluster := v1.DefaultAWSCluster("default", "my-project")
+	advancedSpec, err := advancedClusterSpecFromLegacy(cluster.Spec.ClusterSpec)
+	require.NoError(t, err)
+	cluster.Spec.ClusterSpec = nil
+	cluster.Spec.AdvancedClusterSpec = advancedSpec
+
+	t.Run("Same cluster", func(t *testing.T) {
+		atlas := migratedAtlasCluster(advancedSpec, advancedSpec.ReplicationSpecs[0].RegionConfigs[0].ElectableSpecs.InstanceSize)
+		merged, err := MergedAdvancedCluster(atlas, cluster.Spec)
+		require.NoError(t, err)
+		assert.True(t, AdvancedClustersEqual(zap.S(), atlas, merged))
+	})
+
+	t.Run("Different instance size", func(t *testing.T) {
+		atlas := migratedAtlasCluster(advancedSpec, "M30")
+		merged, err := MergedAdvancedCluster(atlas, cluster.Spec)
+		require.NoError(t, err)
+		assert.False(t, AdvancedClustersEqual(zap.S(), atlas, merged))
+	})
+}
+
+func TestMigrateToAdvancedClusterPlanned(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1.AddToScheme(scheme))
+
+	cluster := v1.DefaultAWSCluster("default", "my-project")
+	cluster.Annotations = map[string]string{customresource.MigrateToAdvancedAnnotation: "true"}
+	advancedSpec, err := advancedClusterSpecFromLegacy(cluster.Spec.ClusterSpec)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet || req.URL.Path != "/api/atlas/v1.5/groups/project-id/clusters/"+advancedSpec.Name {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(migratedAtlasCluster(advancedSpec, advancedSpec.ReplicationSpecs[0].RegionConfigs[0].ElectableSpecs.InstanceSize)))
+	}))
+	defer server.Close()
+	atlasClient, err := mongodbatlas.New(server.Client(), mongodbatlas.SetBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	project := v1.NewProject("default", "my-project", "Test Project")
+	project.Status.ID = "project-id"
+	r := &AtlasClusterReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).Build()}
+	ctx := workflow.NewContext(zap.S(), nil)
+	ctx.Context = context.Background()
+	ctx.Client = *atlasClient
+	ctx.PlanOnly = true
+
+	require.True(t, r.migrateToAdvancedCluster(ctx, project, cluster).IsOk())
+	require.Len(t, ctx.PlannedChanges(), 1)
+	assert.Equal(t, workflow.PlanUpdate, ctx.PlannedChanges()[0].Action)
+
+	// The resource is left unchanged
+	updated := &v1.AtlasCluster{}
+	require.NoError(t, r.Client.Get(context.Background(), kube.ObjectKeyFromObject(cluster), updated))
+	assert.NotNil(t, updated.Spec.ClusterSpec)
+	assert.Nil(t, updated.Spec.AdvancedClusterSpec)
+	assert.Contains(t, updated.Annotations, customresource.MigrateToAdvancedAnnotation)
+}
+
+// migratedAtlasCluster returns the cluster that Atlas reports for the regular cluster migrated to the advanced spec.
+func migratedAtlasCluster(advancedSpec *v1.AdvancedClusterSpec, instanceSize string) mongodbatlas.AdvancedCluster {
+	return mongodbatlas.AdvancedCluster{
+		Name:        advancedSpec.Name,
+		ClusterType: "REPLICASET",
+		StateName:   "IDLE",
+		ReplicationSpecs: []*mongodbatlas.AdvancedReplicationSpec{{
+			ID:        "123",
+			NumShards: 1,
+			ZoneName:  "Zone 1",
+			RegionConfigs: []*mongodbatlas.AdvancedRegionConfig{{
+				ProviderName: "AWS",
+				RegionName:   advancedSpec.ReplicationSpecs[0].RegionConfigs[0].RegionName,
+				Priority:     toptr.Intptr(7),
+				ElectableSpecs: &mongodbatlas.Specs{
+					InstanceSize: instanceSize,
+					NodeCount:    toptr.Intptr(3),
+					DiskIOPS:     toptr.Int64ptr(3000),
+				},
+				ReadOnlySpecs: &mongodbatlas.Specs{InstanceSize: instanceSize, NodeCount: toptr.Intptr(0)},
+			}},
+		}},
+	}
+}
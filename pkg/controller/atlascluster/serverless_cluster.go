@@ -5,49 +5,231 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/go-multierror"
 	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/ownership"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 )
 
-func ensureServerlessInstanceState(ctx *workflow.Context, project *mdbv1.AtlasProject, serverlessSpec *mdbv1.ServerlessSpec) (atlasCluster *mongodbatlas.Cluster, _ workflow.Result) {
-	atlasCluster, resp, err := ctx.Client.ServerlessInstances.Get(context.Background(), project.Status.ID, serverlessSpec.Name)
+const serverlessInstancePath = "api/atlas/v1.0/groups/%s/serverless/%s"
+
+// serverlessInstance is the Atlas serverless instance extended with the fields the Atlas client doesn't support yet.
+type serverlessInstance struct {
+	mongodbatlas.Cluster
+	Tags []mdbv1.TagSpec `json:"tags,omitempty"`
+}
+
+// serverlessUpdate contains the serverless instance fields that Atlas allows to change after the instance is created.
+type serverlessUpdate struct {
+	ServerlessBackupOptions      *mongodbatlas.ServerlessBackupOptions `json:"serverlessBackupOptions,omitempty"`
+	TerminationProtectionEnabled *bool                                 `json:"terminationProtectionEnabled,omitempty"`
+	Tags                         []mdbv1.TagSpec                       `json:"tags,omitempty"`
+}
+
+// ensureServerlessInstanceState creates or updates the serverless instance in Atlas.
+// Atlas doesn't support labels for the serverless instances, so the existing instance is managed only if the resource
+// has managed it before (the state is reported in the status) or if its adoption is confirmed with the annotation. The
+// instance managed by another Operator instance or Kubernetes cluster can't be detected.
+func ensureServerlessInstanceState(ctx *workflow.Context, project *mdbv1.AtlasProject, cluster *mdbv1.AtlasCluster) (atlasInstance *serverlessInstance, _ workflow.Result) {
+	defer ctx.StartSpan("ensureServerlessInstanceState")()
+	serverlessSpec := cluster.Spec.ServerlessSpec
+	atlasInstance, resp, err := getServerlessInstance(ctx.Context, ctx.Client, project.Status.ID, serverlessSpec.Name)
 	if err != nil {
 		if resp == nil {
-			return atlasCluster, workflow.Terminate(workflow.Internal, err.Error())
+			return atlasInstance, workflow.Terminate(workflow.Internal, err.Error())
 		}
 
 		if resp.StatusCode != http.StatusNotFound {
-			return atlasCluster, workflow.Terminate(workflow.ClusterNotCreatedInAtlas, err.Error())
+			return atlasInstance, workflow.Terminate(workflow.ClusterNotCreatedInAtlas, err.Error())
 		}
 
-		ctx.Log.Infof("Serverless Instance %s doesn't exist in Atlas - creating", serverlessSpec.Name)
-		atlasCluster, _, err = ctx.Client.ServerlessInstances.Create(context.Background(), project.Status.ID, &mongodbatlas.ServerlessCreateRequestParams{
+		createRequest := &mongodbatlas.ServerlessCreateRequestParams{
 			Name: serverlessSpec.Name,
 			ProviderSettings: &mongodbatlas.ServerlessProviderSettings{
 				BackingProviderName: serverlessSpec.ProviderSettings.BackingProviderName,
 				ProviderName:        string(serverlessSpec.ProviderSettings.ProviderName),
 				RegionName:          serverlessSpec.ProviderSettings.RegionName,
 			},
-		})
+			ServerlessBackupOptions:      (*mongodbatlas.ServerlessBackupOptions)(serverlessSpec.BackupOptions),
+			TerminationProtectionEnabled: serverlessSpec.TerminationProtectionEnabled,
+		}
+		if ctx.PlanOnly {
+			ctx.PlanChange(workflow.PlanCreate, "serverless instance "+serverlessSpec.Name, cmp.Diff(mongodbatlas.ServerlessCreateRequestParams{}, *createRequest, cmpopts.EquateEmpty()))
+			return nil, workflow.Planned("the serverless instance is not created in the plan mode")
+		}
+
+		ctx.Log.Infof("Serverless Instance %s doesn't exist in Atlas - creating", serverlessSpec.Name)
+		atlasCluster, _, err := ctx.Client.ServerlessInstances.Create(ctx.Context, project.Status.ID, createRequest)
 		if err != nil {
-			return atlasCluster, workflow.Terminate(workflow.ClusterNotCreatedInAtlas, err.Error())
+			return atlasInstance, workflow.Terminate(workflow.ClusterNotCreatedInAtlas, err.Error())
 		}
+		atlasInstance = &serverlessInstance{Cluster: *atlasCluster}
+	} else if result := ownership.Check(ctx, cluster, "serverless instance "+serverlessSpec.Name, nil, cluster.Status.StateName != ""); !result.IsOk() {
+		return nil, result
 	}
 
-	switch atlasCluster.StateName {
+	switch atlasInstance.StateName {
 	case "IDLE":
-		return atlasCluster, workflow.OK()
+		return serverlessInstanceIdle(ctx, project, serverlessSpec, atlasInstance)
 	case "CREATING":
-		return atlasCluster, workflow.InProgress(workflow.ClusterCreating, "cluster is provisioning")
+		return atlasInstance, workflow.InProgress(workflow.ClusterCreating, "cluster is provisioning")
 
 	case "UPDATING", "REPAIRING":
-		return atlasCluster, workflow.InProgress(workflow.ClusterUpdating, "cluster is updating")
+		return atlasInstance, workflow.InProgress(workflow.ClusterUpdating, "cluster is updating")
 
 	// TODO: add "DELETING", "DELETED", handle 404 on delete
 
 	default:
-		return atlasCluster, workflow.Terminate(workflow.Internal, fmt.Sprintf("unknown cluster state %q", atlasCluster.StateName))
+		return atlasInstance, workflow.Terminate(workflow.Internal, fmt.Sprintf("unknown cluster state %q", atlasInstance.StateName))
+	}
+}
+
+func serverlessInstanceIdle(ctx *workflow.Context, project *mdbv1.AtlasProject, serverlessSpec *mdbv1.ServerlessSpec, atlasInstance *serverlessInstance) (*serverlessInstance, workflow.Result) {
+	if err := immutableServerlessFieldsChanged(serverlessSpec, atlasInstance.Cluster); err != nil {
+		// The spec needs to be fixed by the user, so there's no point in retrying
+		result := workflow.Terminate(workflow.ClusterImmutableFieldsChanged, err.Error()).WithoutRetry()
+		ctx.SetConditionFromResult(status.ValidationSucceeded, result)
+		return atlasInstance, result
+	}
+
+	currentUpdate := serverlessUpdateFromAtlas(*atlasInstance)
+	resultingUpdate := mergedServerlessUpdate(currentUpdate, serverlessSpec)
+
+	if done := serverlessUpdatesEqual(ctx.Log, currentUpdate, resultingUpdate); done {
+		return atlasInstance, workflow.OK()
+	}
+
+	if ctx.PlanOnly {
+		ctx.PlanChange(workflow.PlanUpdate, "serverless instance "+serverlessSpec.Name, serverlessUpdatesDiff(currentUpdate, resultingUpdate))
+		return atlasInstance, ctx.UpdatePlanned("the serverless instance is not updated in the plan mode")
+	}
+
+	ctx.Log.Infof("Serverless Instance %s is different from the spec - updating", serverlessSpec.Name)
+	updatedInstance, _, err := updateServerlessInstance(ctx.Context, ctx.Client, project.Status.ID, serverlessSpec.Name, resultingUpdate)
+	if err != nil {
+		return atlasInstance, workflow.Terminate(workflow.ClusterNotUpdatedInAtlas, err.Error())
+	}
+
+	return updatedInstance, workflow.InProgress(workflow.ClusterUpdating, "cluster is updating")
+}
+
+// immutableServerlessFieldsChanged returns an error if the spec requests changes that Atlas can't apply to an existing
+// serverless instance (the instance would need to be recreated for this).
+func immutableServerlessFieldsChanged(serverlessSpec *mdbv1.ServerlessSpec, atlasCluster mongodbatlas.Cluster) error {
+	if serverlessSpec.ProviderSettings == nil || atlasCluster.ProviderSettings == nil {
+		return nil
+	}
+
+	var err error
+	checkField := func(field, specValue, atlasValue string) {
+		if specValue != "" && atlasValue != "" && specValue != atlasValue {
+			err = multierror.Append(err, fmt.Errorf("%s can't be changed from %q to %q", field, atlasValue, specValue))
+		}
+	}
+	checkField("providerSettings.providerName", string(serverlessSpec.ProviderSettings.ProviderName), atlasCluster.ProviderSettings.ProviderName)
+	checkField("providerSettings.backingProviderName", serverlessSpec.ProviderSettings.BackingProviderName, atlasCluster.ProviderSettings.BackingProviderName)
+	checkField("providerSettings.regionName", serverlessSpec.ProviderSettings.RegionName, atlasCluster.ProviderSettings.RegionName)
+
+	if err != nil {
+		return fmt.Errorf("serverless instance %s can't be updated in place, it needs to be deleted and created again to apply the change: %w", serverlessSpec.Name, err)
+	}
+	return nil
+}
+
+func serverlessUpdateFromAtlas(atlasInstance serverlessInstance) serverlessUpdate {
+	return serverlessUpdate{
+		ServerlessBackupOptions:      atlasInstance.ServerlessBackupOptions,
+		TerminationProtectionEnabled: atlasInstance.TerminationProtectionEnabled,
+		Tags:                         atlasInstance.Tags,
+	}
+}
+
+// mergedServerlessUpdate will return the result of applying the ServerlessSpec on top of the updatable fields of the
+// Atlas Serverless Instance. Fields that are not specified in the ServerlessSpec are left as they are in Atlas.
+func mergedServerlessUpdate(current serverlessUpdate, serverlessSpec *mdbv1.ServerlessSpec) serverlessUpdate {
+	result := current
+
+	if serverlessSpec.TerminationProtectionEnabled != nil {
+		result.TerminationProtectionEnabled = serverlessSpec.TerminationProtectionEnabled
+	}
+
+	if serverlessSpec.BackupOptions != nil && serverlessSpec.BackupOptions.ServerlessContinuousBackupEnabled != nil {
+		result.ServerlessBackupOptions = &mongodbatlas.ServerlessBackupOptions{
+			ServerlessContinuousBackupEnabled: serverlessSpec.BackupOptions.ServerlessContinuousBackupEnabled,
+		}
+	}
+
+	if serverlessSpec.Tags != nil {
+		result.Tags = serverlessSpec.Tags
+	}
+
+	return result
+}
+
+// serverlessUpdatesEqual compares the updatable fields of two Atlas Serverless Instances
+func serverlessUpdatesEqual(log *zap.SugaredLogger, atlasUpdate serverlessUpdate, operatorUpdate serverlessUpdate) bool {
+	d := serverlessUpdatesDiff(atlasUpdate, operatorUpdate)
+	if d != "" {
+		log.Debugf("Serverless Instances are different: %s", d)
+	}
+
+	return d == ""
+}
+
+func serverlessUpdatesDiff(atlasUpdate serverlessUpdate, operatorUpdate serverlessUpdate) string {
+	sortTags := cmpopts.SortSlices(func(a, b mdbv1.TagSpec) bool { return a.Key < b.Key })
+
+	return cmp.Diff(atlasUpdate, operatorUpdate, cmpopts.EquateEmpty(), sortTags)
+}
+
+// getServerlessInstance reads the serverless instance from Atlas. The request is made directly as the Atlas client
+// doesn't return the instance tags.
+func getServerlessInstance(ctx context.Context, client mongodbatlas.Client, projectID, name string) (*serverlessInstance, *mongodbatlas.Response, error) {
+	req, err := client.NewRequest(ctx, http.MethodGet, fmt.Sprintf(serverlessInstancePath, projectID, name), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	instance := &serverlessInstance{}
+	resp, err := client.Do(ctx, req, instance)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return instance, resp, nil
+}
+
+// updateServerlessInstance updates the serverless instance in Atlas. The request is made directly as the Atlas client
+// doesn't allow to update the instance tags.
+func updateServerlessInstance(ctx context.Context, client mongodbatlas.Client, projectID, name string, update serverlessUpdate) (*serverlessInstance, *mongodbatlas.Response, error) {
+	req, err := client.NewRequest(ctx, http.MethodPatch, fmt.Sprintf(serverlessInstancePath, projectID, name), update)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	instance := &serverlessInstance{}
+	resp, err := client.Do(ctx, req, instance)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return instance, resp, nil
+}
+
+func serverlessTagsToStatus(tags []mdbv1.TagSpec) []status.Tag {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	result := make([]status.Tag, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, status.Tag{Key: tag.Key, Value: tag.Value})
 	}
+	return result
 }
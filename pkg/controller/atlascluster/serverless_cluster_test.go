@@ -0,0 +1,81 @@
+package atlascluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+
+	v1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+func TestMergedServerlessUpdate(t *testing.T) {
+	atlasInstance := serverlessInstance{
+		Cluster: mongodbatlas.Cluster{
+			TerminationProtectionEnabled: toptr.Boolptr(false),
+			ServerlessBackupOptions:      &mongodbatlas.ServerlessBackupOptions{ServerlessContinuousBackupEnabled: toptr.Boolptr(true)},
+		},
+		Tags: []v1.TagSpec{{Key: "env", Value: "dev"}},
+	}
+
+	t.Run("Fields not specified in the spec are kept from Atlas", func(t *testing.T) {
+		spec := v1.NewDefaultAWSServerlessInstance("default", "my-project").Spec.ServerlessSpec
+
+		current := serverlessUpdateFromAtlas(atlasInstance)
+		merged := mergedServerlessUpdate(current, spec)
+		assert.True(t, serverlessUpdatesEqual(zap.S(), current, merged))
+	})
+
+	t.Run("Changed fields are taken from the spec", func(t *testing.T) {
+		spec := v1.NewDefaultAWSServerlessInstance("default", "my-project").Spec.ServerlessSpec
+		spec.TerminationProtectionEnabled = toptr.Boolptr(true)
+		spec.BackupOptions = &v1.ServerlessBackupOptions{ServerlessContinuousBackupEnabled: toptr.Boolptr(false)}
+		spec.Tags = []v1.TagSpec{{Key: "env", Value: "prod"}}
+
+		current := serverlessUpdateFromAtlas(atlasInstance)
+		merged := mergedServerlessUpdate(current, spec)
+		assert.False(t, serverlessUpdatesEqual(zap.S(), current, merged))
+		assert.True(t, *merged.TerminationProtectionEnabled)
+		assert.False(t, *merged.ServerlessBackupOptions.ServerlessContinuousBackupEnabled)
+		assert.Equal(t, []v1.TagSpec{{Key: "env", Value: "prod"}}, merged.Tags)
+	})
+
+	t.Run("Tags order doesn't matter", func(t *testing.T) {
+		current := serverlessUpdate{Tags: []v1.TagSpec{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}}
+		desired := serverlessUpdate{Tags: []v1.TagSpec{{Key: "b", Value: "2"}, {Key: "a", Value: "1"}}}
+		assert.True(t, serverlessUpdatesEqual(zap.S(), current, desired))
+	})
+}
+
+func TestImmutableServerlessFieldsChanged(t *testing.T) {
+	atlasCluster := mongodbatlas.Cluster{
+		ProviderSettings: &mongodbatlas.ProviderSettings{
+			BackingProviderName: "AWS",
+			ProviderName:        "SERVERLESS",
+			RegionName:          "US_EAST_1",
+		},
+	}
+
+	t.Run("Same provider settings", func(t *testing.T) {
+		spec := v1.NewDefaultAWSServerlessInstance("default", "my-project").Spec.ServerlessSpec
+		assert.NoError(t, immutableServerlessFieldsChanged(spec, atlasCluster))
+	})
+
+	t.Run("Region changed", func(t *testing.T) {
+		spec := v1.NewDefaultAWSServerlessInstance("default", "my-project").Spec.ServerlessSpec
+		spec.ProviderSettings.RegionName = "US_WEST_2"
+		err := immutableServerlessFieldsChanged(spec, atlasCluster)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "providerSettings.regionName")
+	})
+
+	t.Run("Backing provider changed", func(t *testing.T) {
+		spec := v1.NewDefaultAWSServerlessInstance("default", "my-project").Spec.ServerlessSpec
+		spec.ProviderSettings.BackingProviderName = "AZURE"
+		err := immutableServerlessFieldsChanged(spec, atlasCluster)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "providerSettings.backingProviderName")
+	})
+}
@@ -0,0 +1,247 @@
+package atlascluster
+
+import (
+	"fmt"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/set"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/stringutil"
+)
+
+const (
+	serverlessPEStatusInitiating = "INITIATING"
+	serverlessPEStatusDeleting   = "DELETING"
+	serverlessPEStatusFailed     = "FAILED"
+	serverlessPEStatusAvailable  = "AVAILABLE"
+)
+
+// ensureServerlessPrivateEndpoints makes the private endpoints of the serverless instance match the spec.
+// The private endpoints are matched by the name from the spec which is stored as the endpoint comment in Atlas.
+// The private endpoints are not managed at all if the spec doesn't list them. Only the endpoints created by the
+// Operator (tracked in the status) are deleted once they are removed from the spec.
+func ensureServerlessPrivateEndpoints(ctx *workflow.Context, projectID string, cluster *mdbv1.AtlasCluster) workflow.Result {
+	defer ctx.StartSpan("ensureServerlessPrivateEndpoints")()
+	serverlessSpec := cluster.Spec.ServerlessSpec
+	if serverlessSpec.PrivateEndpoints == nil {
+		return workflow.OK()
+	}
+
+	result := syncServerlessPrivateEndpoints(ctx, projectID, serverlessSpec, cluster.Status.ServerlessPrivateEndpointIDs)
+	if len(serverlessSpec.PrivateEndpoints) == 0 && result.IsOk() {
+		return result
+	}
+
+	if result.IsOk() {
+		ctx.SetConditionTrue(status.ServerlessPrivateEndpointReadyType)
+	} else {
+		ctx.SetConditionFromResult(status.ServerlessPrivateEndpointReadyType, result)
+	}
+	return result
+}
+
+func syncServerlessPrivateEndpoints(ctx *workflow.Context, projectID string, serverlessSpec *mdbv1.ServerlessSpec, createdIDs []string) workflow.Result {
+	atlasPEs, _, err := ctx.Client.ServerlessPrivateEndpoints.List(ctx.Context, projectID, serverlessSpec.Name, &mongodbatlas.ListOptions{})
+	if err != nil {
+		return workflow.Terminate(workflow.ServerlessPrivateEndpointFailed, err.Error())
+	}
+
+	statusPEs := serverlessPrivateEndpointsToStatus(atlasPEs)
+	ctx.EnsureStatusOption(status.AtlasClusterServerlessPrivateEndpointsOption(statusPEs))
+	trackedIDs := existingServerlessPrivateEndpointIDs(createdIDs, statusPEs)
+	ctx.EnsureStatusOption(status.AtlasClusterServerlessPrivateEndpointIDsOption(trackedIDs))
+
+	endpointsToCreate := set.Difference(serverlessSpec.PrivateEndpoints, statusPEs)
+	endpointsToUpdate := set.Intersection(serverlessSpec.PrivateEndpoints, statusPEs)
+	endpointsToDelete := serverlessPrivateEndpointsToDelete(statusPEs, serverlessSpec.PrivateEndpoints, trackedIDs)
+
+	ctx.Log.Debugw("Serverless private endpoints to create", "difference", endpointsToCreate)
+	ctx.Log.Debugw("Serverless private endpoints to update", "intersection", endpointsToUpdate)
+	ctx.Log.Debugw("Serverless private endpoints to delete", "difference", endpointsToDelete)
+
+	if ctx.PlanOnly {
+		return planServerlessPrivateEndpoints(ctx, serverlessSpec.Name, endpointsToCreate, endpointsToUpdate, endpointsToDelete, statusPEs)
+	}
+
+	for _, item := range endpointsToDelete {
+		pe := item.(status.ServerlessPrivateEndpoint)
+		if pe.Status == serverlessPEStatusDeleting {
+			continue
+		}
+		if _, err := ctx.Client.ServerlessPrivateEndpoints.Delete(ctx.Context, projectID, serverlessSpec.Name, pe.ID); err != nil {
+			return workflow.Terminate(workflow.ServerlessPrivateEndpointFailed, err.Error())
+		}
+		ctx.Log.Infof("Removed serverless private endpoint %q from Atlas", pe.Name)
+	}
+
+	for _, item := range endpointsToCreate {
+		pe := item.(mdbv1.ServerlessPrivateEndpoint)
+		created, _, err := ctx.Client.ServerlessPrivateEndpoints.Create(ctx.Context, projectID, serverlessSpec.Name, &mongodbatlas.ServerlessPrivateEndpointConnection{
+			Comment: pe.Name,
+		})
+		if err != nil {
+			return workflow.Terminate(workflow.ServerlessPrivateEndpointFailed, err.Error())
+		}
+		if created != nil {
+			trackedIDs = append(trackedIDs, created.ID)
+		}
+		ctx.EnsureStatusOption(status.AtlasClusterServerlessPrivateEndpointIDsOption(trackedIDs))
+		ctx.Log.Infof("Created serverless private endpoint %q in Atlas", pe.Name)
+	}
+
+	providerName := ""
+	if serverlessSpec.ProviderSettings != nil {
+		providerName = serverlessSpec.ProviderSettings.BackingProviderName
+	}
+
+	updated := false
+	for _, pair := range endpointsToUpdate {
+		specPE := pair[0].(mdbv1.ServerlessPrivateEndpoint)
+		statusPE := pair[1].(status.ServerlessPrivateEndpoint)
+		if !serverlessPrivateEndpointNeedsUpdate(specPE, statusPE) {
+			continue
+		}
+
+		if _, _, err := ctx.Client.ServerlessPrivateEndpoints.Update(ctx.Context, projectID, serverlessSpec.Name, statusPE.ID, &mongodbatlas.ServerlessPrivateEndpointConnection{
+			CloudProviderEndpointID:  specPE.CloudProviderEndpointID,
+			PrivateEndpointIPAddress: specPE.PrivateEndpointIPAddress,
+			ProviderName:             providerName,
+		}); err != nil {
+			return workflow.Terminate(workflow.ServerlessPrivateEndpointFailed, err.Error())
+		}
+		ctx.Log.Infof("Updated serverless private endpoint %q in Atlas", specPE.Name)
+		updated = true
+	}
+
+	if len(endpointsToCreate) > 0 || len(endpointsToDelete) > 0 || updated {
+		return workflow.InProgress(workflow.ServerlessPrivateEndpointNotReady, "serverless private endpoints are being updated")
+	}
+
+	return serverlessPrivateEndpointsResult(statusPEs)
+}
+
+// planServerlessPrivateEndpoints reports the changes syncServerlessPrivateEndpoints would make in Atlas
+func planServerlessPrivateEndpoints(ctx *workflow.Context, instanceName string, toCreate []set.Identifiable, toUpdate [][]set.Identifiable, toDelete []set.Identifiable, statusPEs []status.ServerlessPrivateEndpoint) workflow.Result {
+	for _, item := range toDelete {
+		pe := item.(status.ServerlessPrivateEndpoint)
+		if pe.Status == serverlessPEStatusDeleting {
+			continue
+		}
+		ctx.PlanChange(workflow.PlanDelete, fmt.Sprintf("serverless private endpoint %q of %s", pe.Name, instanceName), "")
+	}
+	for _, item := range toCreate {
+		pe := item.(mdbv1.ServerlessPrivateEndpoint)
+		ctx.PlanChange(workflow.PlanCreate, fmt.Sprintf("serverless private endpoint %q of %s", pe.Name, instanceName), "")
+	}
+	for _, pair := range toUpdate {
+		specPE := pair[0].(mdbv1.ServerlessPrivateEndpoint)
+		statusPE := pair[1].(status.ServerlessPrivateEndpoint)
+		if !serverlessPrivateEndpointNeedsUpdate(specPE, statusPE) {
+			continue
+		}
+		ctx.PlanChange(workflow.PlanUpdate, fmt.Sprintf("serverless private endpoint %q of %s", specPE.Name, instanceName),
+			fmt.Sprintf("cloudProviderEndpointID: %q -> %q, privateEndpointIpAddress: %q -> %q",
+				statusPE.CloudProviderEndpointID, specPE.CloudProviderEndpointID, statusPE.PrivateEndpointIPAddress, specPE.PrivateEndpointIPAddress))
+	}
+	return serverlessPrivateEndpointsResult(statusPEs)
+}
+
+// serverlessPrivateEndpointNeedsUpdate returns true if the private endpoint created by the user in their cloud
+// provider needs to be attached to the Atlas private endpoint service. This is only possible after Atlas finishes
+// creating the endpoint service.
+func serverlessPrivateEndpointNeedsUpdate(specPE mdbv1.ServerlessPrivateEndpoint, statusPE status.ServerlessPrivateEndpoint) bool {
+	if specPE.CloudProviderEndpointID == "" {
+		return false
+	}
+	if statusPE.Status == serverlessPEStatusInitiating || statusPE.Status == serverlessPEStatusDeleting {
+		return false
+	}
+	return specPE.CloudProviderEndpointID != statusPE.CloudProviderEndpointID ||
+		specPE.PrivateEndpointIPAddress != statusPE.PrivateEndpointIPAddress
+}
+
+// serverlessPrivateEndpointsResult returns the workflow result based on the statuses of the private endpoints in Atlas.
+func serverlessPrivateEndpointsResult(statusPEs []status.ServerlessPrivateEndpoint) workflow.Result {
+	for _, pe := range statusPEs {
+		if pe.Status == serverlessPEStatusFailed {
+			return workflow.Terminate(workflow.ServerlessPrivateEndpointFailed, fmt.Sprintf("serverless private endpoint %q failed: %s", pe.Name, pe.ErrorMessage))
+		}
+	}
+
+	for _, pe := range statusPEs {
+		if pe.Status != serverlessPEStatusAvailable {
+			return workflow.InProgress(workflow.ServerlessPrivateEndpointNotReady, fmt.Sprintf("serverless private endpoint %q is in %s state", pe.Name, pe.Status))
+		}
+	}
+
+	return workflow.OK()
+}
+
+// serverlessPrivateEndpointsToDelete returns the private endpoints created by the Operator which are not in the spec
+// anymore. The endpoints created in Atlas UI or by other tools are never deleted.
+func serverlessPrivateEndpointsToDelete(statusPEs []status.ServerlessPrivateEndpoint, specPEs []mdbv1.ServerlessPrivateEndpoint, createdIDs []string) []set.Identifiable {
+	created := make([]status.ServerlessPrivateEndpoint, 0, len(createdIDs))
+	for _, pe := range statusPEs {
+		if stringutil.Contains(createdIDs, pe.ID) {
+			created = append(created, pe)
+		}
+	}
+	return set.Difference(created, specPEs)
+}
+
+// existingServerlessPrivateEndpointIDs returns the IDs of the private endpoints created by the Operator which still
+// exist in Atlas
+func existingServerlessPrivateEndpointIDs(createdIDs []string, statusPEs []status.ServerlessPrivateEndpoint) []string {
+	result := make([]string, 0, len(createdIDs))
+	for _, pe := range statusPEs {
+		if stringutil.Contains(createdIDs, pe.ID) {
+			result = append(result, pe.ID)
+		}
+	}
+	return result
+}
+
+func serverlessPrivateEndpointsToStatus(atlasPEs []mongodbatlas.ServerlessPrivateEndpointConnection) []status.ServerlessPrivateEndpoint {
+	if len(atlasPEs) == 0 {
+		return nil
+	}
+
+	result := make([]status.ServerlessPrivateEndpoint, 0, len(atlasPEs))
+	for _, pe := range atlasPEs {
+		result = append(result, status.ServerlessPrivateEndpoint{
+			ID:                           pe.ID,
+			Name:                         pe.Comment,
+			ProviderName:                 pe.ProviderName,
+			EndpointServiceName:          pe.EndpointServiceName,
+			PrivateLinkServiceResourceID: pe.PrivateLinkServiceResourceID,
+			CloudProviderEndpointID:      pe.CloudProviderEndpointID,
+			PrivateEndpointIPAddress:     pe.PrivateEndpointIPAddress,
+			Status:                       pe.Status,
+			ErrorMessage:                 pe.ErrorMessage,
+		})
+	}
+	return result
+}
+
+// serverlessPrivateConnectionStrings returns the generic private connection strings of the serverless instance.
+// Atlas returns a separate entry for each private endpoint, these are put to the connection Secrets with the per-endpoint
+// keys (see connectionsecret.PrivateEndpointConnURLsFromAtlas). The generic connection strings are set only if the
+// instance has a single private endpoint as picking one of several endpoints would be arbitrary.
+func serverlessPrivateConnectionStrings(connectionStrings *mongodbatlas.ConnectionStrings) (standard, srv string) {
+	if connectionStrings == nil {
+		return "", ""
+	}
+	var withStrings []mongodbatlas.PrivateEndpoint
+	for _, pe := range connectionStrings.PrivateEndpoint {
+		if pe.SRVConnectionString != "" || pe.ConnectionString != "" {
+			withStrings = append(withStrings, pe)
+		}
+	}
+	if len(withStrings) != 1 {
+		return "", ""
+	}
+	return withStrings[0].ConnectionString, withStrings[0].SRVConnectionString
+}
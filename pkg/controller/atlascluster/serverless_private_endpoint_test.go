@@ -0,0 +1,95 @@
+package atlascluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	v1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+func TestServerlessPrivateEndpointNeedsUpdate(t *testing.T) {
+	t.Run("No endpoint ID in the spec", func(t *testing.T) {
+		assert.False(t, serverlessPrivateEndpointNeedsUpdate(v1.ServerlessPrivateEndpoint{Name: "pe"}, status.ServerlessPrivateEndpoint{Status: "WAITING_FOR_USER"}))
+	})
+
+	t.Run("Endpoint service is still being created", func(t *testing.T) {
+		specPE := v1.ServerlessPrivateEndpoint{Name: "pe", CloudProviderEndpointID: "vpce-1"}
+		assert.False(t, serverlessPrivateEndpointNeedsUpdate(specPE, status.ServerlessPrivateEndpoint{Status: "INITIATING"}))
+	})
+
+	t.Run("Endpoint ID is not attached yet", func(t *testing.T) {
+		specPE := v1.ServerlessPrivateEndpoint{Name: "pe", CloudProviderEndpointID: "vpce-1"}
+		assert.True(t, serverlessPrivateEndpointNeedsUpdate(specPE, status.ServerlessPrivateEndpoint{Status: "WAITING_FOR_USER"}))
+	})
+
+	t.Run("Endpoint ID is already attached", func(t *testing.T) {
+		specPE := v1.ServerlessPrivateEndpoint{Name: "pe", CloudProviderEndpointID: "vpce-1"}
+		assert.False(t, serverlessPrivateEndpointNeedsUpdate(specPE, status.ServerlessPrivateEndpoint{Status: "AVAILABLE", CloudProviderEndpointID: "vpce-1"}))
+	})
+}
+
+func TestServerlessPrivateEndpointsToDelete(t *testing.T) {
+	statusPEs := []status.ServerlessPrivateEndpoint{
+		{ID: "1", Name: "kept"},
+		{ID: "2", Name: "removed"},
+		{ID: "3", Name: "created-in-ui"},
+		{ID: "4"},
+	}
+	specPEs := []v1.ServerlessPrivateEndpoint{{Name: "kept"}}
+
+	toDelete := serverlessPrivateEndpointsToDelete(statusPEs, specPEs, []string{"1", "2", "5"})
+	assert.Len(t, toDelete, 1)
+	assert.Equal(t, "2", toDelete[0].(status.ServerlessPrivateEndpoint).ID)
+
+	assert.Empty(t, serverlessPrivateEndpointsToDelete(statusPEs, specPEs, nil), "only the endpoints created by the Operator are deleted")
+	assert.Equal(t, []string{"1", "2"}, existingServerlessPrivateEndpointIDs([]string{"1", "2", "5"}, statusPEs))
+}
+
+func TestServerlessPrivateEndpointsResult(t *testing.T) {
+	assert.True(t, serverlessPrivateEndpointsResult(nil).IsOk())
+	assert.True(t, serverlessPrivateEndpointsResult([]status.ServerlessPrivateEndpoint{{Status: "AVAILABLE"}}).IsOk())
+
+	result := serverlessPrivateEndpointsResult([]status.ServerlessPrivateEndpoint{{Name: "a", Status: "AVAILABLE"}, {Name: "b", Status: "WAITING_FOR_USER"}})
+	assert.Equal(t, workflow.InProgress(workflow.ServerlessPrivateEndpointNotReady, `serverless private endpoint "b" is in WAITING_FOR_USER state`), result)
+
+	result = serverlessPrivateEndpointsResult([]status.ServerlessPrivateEndpoint{{Name: "a", Status: "INITIATING"}, {Name: "b", Status: "FAILED", ErrorMessage: "boom"}})
+	assert.Equal(t, workflow.Terminate(workflow.ServerlessPrivateEndpointFailed, `serverless private endpoint "b" failed: boom`), result)
+}
+
+func TestServerlessPrivateConnectionStrings(t *testing.T) {
+	standard, srv := serverlessPrivateConnectionStrings(nil)
+	assert.Empty(t, standard)
+	assert.Empty(t, srv)
+
+	single := &mongodbatlas.ConnectionStrings{
+		StandardSrv: "mongodb+srv://instance.mongodb.net",
+		PrivateEndpoint: []mongodbatlas.PrivateEndpoint{
+			{SRVConnectionString: "mongodb+srv://instance-pe-0.mongodb.net", Endpoints: []mongodbatlas.Endpoint{{EndpointID: "vpce-0", Region: "US_EAST_1"}}},
+		},
+	}
+	standard, srv = serverlessPrivateConnectionStrings(single)
+	assert.Empty(t, standard)
+	assert.Equal(t, "mongodb+srv://instance-pe-0.mongodb.net", srv)
+
+	several := &mongodbatlas.ConnectionStrings{
+		StandardSrv: "mongodb+srv://instance.mongodb.net",
+		PrivateEndpoint: []mongodbatlas.PrivateEndpoint{
+			{SRVConnectionString: "mongodb+srv://instance-pe-0.mongodb.net", Endpoints: []mongodbatlas.Endpoint{{EndpointID: "vpce-0", Region: "US_EAST_1"}}},
+			{SRVConnectionString: "mongodb+srv://instance-pe-1.mongodb.net", Endpoints: []mongodbatlas.Endpoint{{EndpointID: "vpce-1", Region: "US_EAST_1"}}},
+		},
+	}
+	standard, srv = serverlessPrivateConnectionStrings(several)
+	assert.Empty(t, standard)
+	assert.Empty(t, srv, "the generic connection string is ambiguous for several endpoints")
+
+	// every endpoint gets its own connection string
+	assert.Equal(t, []connectionsecret.PrivateEndpointConnURLs{
+		{EndpointID: "vpce-0", Region: "US_EAST_1", SrvConnURL: "mongodb+srv://instance-pe-0.mongodb.net"},
+		{EndpointID: "vpce-1", Region: "US_EAST_1", SrvConnURL: "mongodb+srv://instance-pe-1.mongodb.net"},
+	}, connectionsecret.PrivateEndpointConnURLsFromAtlas(several))
+}
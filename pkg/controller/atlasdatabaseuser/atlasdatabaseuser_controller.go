@@ -40,7 +40,9 @@ import (
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/ownership"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/tracing"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/watch"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
@@ -52,10 +54,14 @@ type AtlasDatabaseUserReconciler struct {
 	Client           client.Client
 	Log              *zap.SugaredLogger
 	Scheme           *runtime.Scheme
-	AtlasDomain      string
+	AtlasClients     *atlas.ClientCache
 	GlobalAPISecret  client.ObjectKey
 	EventRecorder    record.EventRecorder
 	GlobalPredicates []predicate.Predicate
+	// MaxConcurrentReconciles is the number of the AtlasDatabaseUsers reconciled in parallel, defaults to 1
+	MaxConcurrentReconciles int
+	// PlanMode makes all the AtlasDatabaseUsers reconciled in the plan mode (see customresource.ReconciliationShouldOnlyPlan)
+	PlanMode bool
 }
 
 // +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasdatabaseusers,verbs=get;list;watch;create;update;patch;delete
@@ -69,8 +75,9 @@ type AtlasDatabaseUserReconciler struct {
 // +kubebuilder:rbac:groups="",namespace=default,resources=events,verbs=create;patch
 
 func (r *AtlasDatabaseUserReconciler) Reconcile(context context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = context
-	log := r.Log.With("atlasdatabaseuser", req.NamespacedName)
+	context, span := tracing.StartReconcile(context, "AtlasDatabaseUser", req.NamespacedName)
+	defer span.End()
+	log := tracing.Logger(context, r.Log.With("atlasdatabaseuser", req.NamespacedName))
 
 	databaseUser := &mdbv1.AtlasDatabaseUser{}
 	result := customresource.PrepareResource(r.Client, req, databaseUser, log)
@@ -83,16 +90,20 @@ func (r *AtlasDatabaseUserReconciler) Reconcile(context context.Context, req ctr
 		return workflow.OK().ReconcileResult(), nil
 	}
 
-	if databaseUser.Spec.PasswordSecret != nil {
+	if databaseUser.PasswordSecretObjectKey() != nil {
 		r.EnsureResourcesAreWatched(req.NamespacedName, "Secret", log, *databaseUser.PasswordSecretObjectKey())
 	}
 	ctx := customresource.MarkReconciliationStarted(r.Client, databaseUser, log)
+	ctx.Context = context
+	ctx.ObserveOnly = customresource.ReconciliationShouldOnlyObserve(databaseUser)
+	ctx.PlanOnly = r.PlanMode || ctx.ObserveOnly || customresource.ReconciliationShouldOnlyPlan(databaseUser)
 
-	log.Infow("-> Starting AtlasDatabaseUser reconciliation", "spec", databaseUser.Spec, "status", databaseUser.Status)
+	log.Infow("-> Starting AtlasDatabaseUser reconciliation", "spec", databaseUser.Spec, "status", databaseUser.Status, "planOnly", ctx.PlanOnly, "observeOnly", ctx.ObserveOnly)
 	defer statushandler.Update(ctx, r.Client, r.EventRecorder, databaseUser)
+	defer ctx.CompletePlan()
 
 	if err := validate.DatabaseUser(databaseUser); err != nil {
-		result := workflow.Terminate(workflow.Internal, err.Error())
+		result := workflow.Terminate(workflow.Internal, err.Error()).WithoutRetry()
 		ctx.SetConditionFromResult(status.ValidationSucceeded, result)
 		return result.ReconcileResult(), nil
 	}
@@ -104,20 +115,30 @@ func (r *AtlasDatabaseUserReconciler) Reconcile(context context.Context, req ctr
 		return result.ReconcileResult(), nil
 	}
 
-	connection, err := atlas.ReadConnection(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
+	// The user is reconciled again once the project connection Secret is fixed
+	if project.ConnectionSecretObjectKey() != nil {
+		watchedSecrets := []client.ObjectKey{*project.ConnectionSecretObjectKey()}
+		if databaseUser.PasswordSecretObjectKey() != nil {
+			watchedSecrets = append(watchedSecrets, *databaseUser.PasswordSecretObjectKey())
+		}
+		r.EnsureResourcesAreWatched(req.NamespacedName, "Secret", log, watchedSecrets...)
+	}
+
+	getAtlasClient := r.AtlasClients.Get
+	if ctx.PlanOnly {
+		getAtlasClient = r.AtlasClients.GetReadOnly
+	}
+	connection, atlasClient, err := getAtlasClient(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
 	if err != nil {
 		result := workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
+		if project.ConnectionSecretObjectKey() == nil {
+			// The global Secret is not watched so the reconciliation needs to be retried until it's fixed
+			result = result.WithRetryClass(workflow.RetryTransient)
+		}
 		ctx.SetConditionFromResult(status.DatabaseUserReadyType, result)
 		return result.ReconcileResult(), nil
 	}
 	ctx.Connection = connection
-
-	atlasClient, err := atlas.Client(r.AtlasDomain, connection, log)
-	if err != nil {
-		result := workflow.Terminate(workflow.Internal, err.Error())
-		ctx.SetConditionFromResult(status.ClusterReadyType, result)
-		return result.ReconcileResult(), nil
-	}
 	ctx.Client = atlasClient
 
 	result = r.ensureDatabaseUser(ctx, *project, *databaseUser)
@@ -139,7 +160,11 @@ func (r *AtlasDatabaseUserReconciler) readProjectResource(user *mdbv1.AtlasDatab
 }
 
 func (r *AtlasDatabaseUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	c, err := controller.New("AtlasDatabaseUser", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("AtlasDatabaseUser", mgr, controller.Options{
+		Reconciler:              r,
+		RateLimiter:             workflow.RateLimiter(),
+		MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+	})
 	if err != nil {
 		return err
 	}
@@ -150,7 +175,7 @@ func (r *AtlasDatabaseUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
-	// Watch for DatabaseUser password Secrets
+	// Watch for DatabaseUser password and project connection Secrets
 	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, watch.NewSecretHandler(r.WatchedResources))
 	if err != nil {
 		return err
@@ -177,6 +202,8 @@ func (r AtlasDatabaseUserReconciler) Delete(e event.DeleteEvent) error {
 
 	if customresource.ResourceShouldBeLeftInAtlas(dbUser) {
 		log.Infof("Not removing Atlas database user from Atlas as the '%s' annotation is set", customresource.ResourcePolicyAnnotation)
+	} else if r.PlanMode || customresource.ReconciliationShouldOnlyPlan(dbUser) || customresource.ReconciliationShouldOnlyObserve(dbUser) {
+		log.Infow("Not removing Atlas database user from Atlas in the plan or observe mode", "plannedChange", workflow.PlanDelete+" database user "+dbUser.Spec.Username)
 	} else if err := r.deleteUserFromAtlas(dbUser, project, log); err != nil {
 		log.Error("Failed to remove database user from Atlas: %s", err)
 	}
@@ -188,18 +215,26 @@ func (r AtlasDatabaseUserReconciler) Delete(e event.DeleteEvent) error {
 }
 
 func (r AtlasDatabaseUserReconciler) deleteUserFromAtlas(dbUser *mdbv1.AtlasDatabaseUser, project *mdbv1.AtlasProject, log *zap.SugaredLogger) error {
-	connection, err := atlas.ReadConnection(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
-	if err != nil {
-		return err
-	}
-
-	atlasClient, err := atlas.Client(r.AtlasDomain, connection, log)
+	_, atlasClient, err := r.AtlasClients.Get(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
 	if err != nil {
 		return fmt.Errorf("cannot build Atlas client: %w", err)
 	}
 
 	userName := dbUser.Spec.Username
 
+	atlasUser, _, err := atlasClient.DatabaseUsers.Get(context.Background(), dbUser.Spec.DatabaseName, project.ID(), userName)
+	var apiError *mongodbatlas.ErrorResponse
+	switch {
+	case errors.As(err, &apiError) && apiError.ErrorCode == atlas.UsernameNotFound:
+		log.Info("Database user doesn't exist or is already deleted")
+		return nil
+	case err != nil:
+		return fmt.Errorf("cannot check the ownership of the database user: %w", err)
+	case !ownership.IsOwned(dbUser, atlasUser.Labels, dbUser.Status.UserName == userName):
+		log.Info("Not removing the database user from Atlas as it's not managed by the resource")
+		return nil
+	}
+
 	go func() {
 		timeout := time.Now().Add(workflow.DefaultTimeout)
 
@@ -19,12 +19,13 @@ import (
 const ConnectionSecretsEnsuredEvent = "ConnectionSecretsEnsured"
 
 func CreateOrUpdateConnectionSecrets(ctx *workflow.Context, k8sClient client.Client, recorder record.EventRecorder, project mdbv1.AtlasProject, dbUser mdbv1.AtlasDatabaseUser) workflow.Result {
-	clusters, _, err := ctx.Client.Clusters.List(context.Background(), project.ID(), &mongodbatlas.ListOptions{})
+	defer ctx.StartSpan("CreateOrUpdateConnectionSecrets")()
+	clusters, _, err := ctx.Client.Clusters.List(ctx.Context, project.ID(), &mongodbatlas.ListOptions{})
 	if err != nil {
 		return workflow.Terminate(workflow.DatabaseUserConnectionSecretsNotCreated, err.Error())
 	}
 
-	advancedClusters, _, err := ctx.Client.AdvancedClusters.List(context.Background(), project.ID(), &mongodbatlas.ListOptions{})
+	advancedClusters, _, err := ctx.Client.AdvancedClusters.List(ctx.Context, project.ID(), &mongodbatlas.ListOptions{})
 	if err != nil {
 		return workflow.Terminate(workflow.DatabaseUserConnectionSecretsNotCreated, err.Error())
 	}
@@ -105,6 +106,8 @@ func createOrUpdateConnectionSecretsFromClusterSecrets(ctx *workflow.Context, k8
 			PvtConnURL:    cs.connectionStrings.Private,
 			PvtSrvConnURL: cs.connectionStrings.PrivateSrv,
 			Password:      password,
+
+			PrivateEndpointConnURLs: connectionsecret.PrivateEndpointConnURLsFromAtlas(cs.connectionStrings),
 		}
 		var secretName string
 		if secretName, err = connectionsecret.Ensure(k8sClient, dbUser.Namespace, project.Spec.Name, project.ID(), cs.name, data); err != nil {
@@ -156,7 +159,7 @@ func removeStaleByScope(ctx *workflow.Context, k8sClient client.Client, projectI
 			continue
 		}
 		if !stringutil.Contains(scopes, cluster) {
-			if err = k8sClient.Delete(context.Background(), &secrets[i]); err != nil {
+			if err = k8sClient.Delete(ctx.Context, &secrets[i]); err != nil {
 				return err
 			}
 			ctx.Log.Debugw("Removed connection Secret as it's not referenced by the AtlasDatabaseUser anymore", "secretname", s.Name)
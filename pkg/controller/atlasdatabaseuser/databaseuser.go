@@ -18,17 +18,34 @@ import (
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/ownership"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/compat"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
 )
 
 func (r *AtlasDatabaseUserReconciler) ensureDatabaseUser(ctx *workflow.Context, project mdbv1.AtlasProject, dbUser mdbv1.AtlasDatabaseUser) workflow.Result {
+	defer ctx.StartSpan("ensureDatabaseUser")()
+	nextPasswordRotation, result := r.ensurePasswordSecret(ctx, dbUser)
+	if !result.IsOk() {
+		return result
+	}
+
 	apiUser, err := dbUser.ToAtlas(r.Client)
 	if err != nil {
 		return workflow.Terminate(workflow.Internal, err.Error())
 	}
 
+	passwordPlaceholder, err := passwordIsPlaceholder(ctx.Context, r.Client, dbUser)
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	if passwordPlaceholder {
+		// The password of the exported user is unknown, the one in Atlas is kept until the Secret gets the real one
+		apiUser.Password = ""
+	}
+
 	if result := checkUserExpired(ctx.Log, r.Client, project.ID(), dbUser); !result.IsOk() {
 		return result
 	}
@@ -37,7 +54,7 @@ func (r *AtlasDatabaseUserReconciler) ensureDatabaseUser(ctx *workflow.Context,
 		return workflow.Terminate(workflow.DatabaseUserInvalidSpec, err.Error())
 	}
 
-	if result := performUpdateInAtlas(ctx, r.Client, project, dbUser, apiUser); !result.IsOk() {
+	if result := performUpdateInAtlas(ctx, r.Client, project, dbUser, apiUser, passwordPlaceholder); !result.IsOk() {
 		return result
 	}
 
@@ -45,7 +62,9 @@ func (r *AtlasDatabaseUserReconciler) ensureDatabaseUser(ctx *workflow.Context,
 		return result
 	}
 
-	if result := CreateOrUpdateConnectionSecrets(ctx, r.Client, r.EventRecorder, project, dbUser); !result.IsOk() {
+	if passwordPlaceholder {
+		ctx.Log.Infow("The password Secret is a placeholder, the connection Secrets are not created", "secret", dbUser.PasswordSecretObjectKey().Name)
+	} else if result := CreateOrUpdateConnectionSecrets(ctx, r.Client, r.EventRecorder, project, dbUser); !result.IsOk() {
 		return result
 	}
 
@@ -57,16 +76,24 @@ func (r *AtlasDatabaseUserReconciler) ensureDatabaseUser(ctx *workflow.Context,
 	// We mark the status.Username only when everything is finished including connection secrets
 	ctx.EnsureStatusOption(status.AtlasDatabaseUserNameOption(dbUser.Spec.Username))
 
+	if !nextPasswordRotation.IsZero() {
+		// The user is reconciled again to rotate the password
+		return workflow.OK().WithRetry(time.Until(nextPasswordRotation))
+	}
 	return workflow.OK()
 }
 
 func handleUserNameChange(ctx *workflow.Context, projectID string, dbUser mdbv1.AtlasDatabaseUser) workflow.Result {
 	if dbUser.Spec.Username != dbUser.Status.UserName && dbUser.Status.UserName != "" {
+		if ctx.PlanOnly {
+			ctx.PlanChange(workflow.PlanDelete, "database user "+dbUser.Status.UserName, "")
+			return workflow.OK()
+		}
 		ctx.Log.Infow("'spec.username' has changed - removing the old user from Atlas", "newUserName", dbUser.Spec.Username, "oldUserName", dbUser.Status.UserName)
 
 		deleteAttempts := 3
 		for i := 1; i <= deleteAttempts; i++ {
-			_, err := ctx.Client.DatabaseUsers.Delete(context.Background(), dbUser.Spec.DatabaseName, projectID, dbUser.Status.UserName)
+			_, err := ctx.Client.DatabaseUsers.Delete(ctx.Context, dbUser.Spec.DatabaseName, projectID, dbUser.Status.UserName)
 			if err == nil {
 				break
 			}
@@ -97,28 +124,56 @@ func checkUserExpired(log *zap.SugaredLogger, k8sClient client.Client, projectID
 	return workflow.OK()
 }
 
-func performUpdateInAtlas(ctx *workflow.Context, k8sClient client.Client, project mdbv1.AtlasProject, dbUser mdbv1.AtlasDatabaseUser, apiUser *mongodbatlas.DatabaseUser) workflow.Result {
+// passwordIsPlaceholder returns true if the password Secret of the user is the placeholder produced by the export of
+// the existing Atlas user and doesn't contain the real password.
+func passwordIsPlaceholder(ctx context.Context, k8sClient client.Client, dbUser mdbv1.AtlasDatabaseUser) (bool, error) {
+	passwordKey := dbUser.PasswordSecretObjectKey()
+	if passwordKey == nil {
+		return false, nil
+	}
+	secret := &corev1.Secret{}
+	if err := k8sClient.Get(ctx, *passwordKey, secret); err != nil {
+		return false, err
+	}
+	return secret.Annotations[customresource.PasswordPlaceholderAnnotation] == customresource.PasswordPlaceholderTrue, nil
+}
+
+func performUpdateInAtlas(ctx *workflow.Context, k8sClient client.Client, project mdbv1.AtlasProject, dbUser mdbv1.AtlasDatabaseUser, apiUser *mongodbatlas.DatabaseUser, passwordPlaceholder bool) workflow.Result {
 	log := ctx.Log
 
 	secret := &corev1.Secret{}
 	passwordKey := dbUser.PasswordSecretObjectKey()
 	var currentPasswordResourceVersion string
 	if passwordKey != nil {
-		if err := k8sClient.Get(context.Background(), *passwordKey, secret); err != nil {
+		if err := k8sClient.Get(ctx.Context, *passwordKey, secret); err != nil {
 			return workflow.Terminate(workflow.Internal, err.Error())
 		}
 		currentPasswordResourceVersion = secret.ResourceVersion
 	}
+	if passwordPlaceholder {
+		// the placeholder is never sent to Atlas so it's not considered as the password change
+		currentPasswordResourceVersion = dbUser.Status.PasswordVersion
+	}
 
 	retryAfterUpdate := workflow.InProgress(workflow.DatabaseUserClustersAppliedChanges, "Clusters are scheduled to handle database users updates")
 
 	// Try to find the user
-	u, _, err := ctx.Client.DatabaseUsers.Get(context.Background(), dbUser.Spec.DatabaseName, project.ID(), dbUser.Spec.Username)
+	u, _, err := ctx.Client.DatabaseUsers.Get(ctx.Context, dbUser.Spec.DatabaseName, project.ID(), dbUser.Spec.Username)
 	if err != nil {
 		var apiError *mongodbatlas.ErrorResponse
 		if errors.As(err, &apiError) && apiError.ErrorCode == atlas.UsernameNotFound {
+			apiUser.Labels = ownership.WithLabels(apiUser.Labels, &dbUser)
+			if ctx.PlanOnly {
+				withoutPassword := *apiUser
+				withoutPassword.Password = ""
+				ctx.PlanChange(workflow.PlanCreate, "database user "+dbUser.Spec.Username, cmp.Diff(mongodbatlas.DatabaseUser{}, withoutPassword, cmpopts.EquateEmpty()))
+				return workflow.Planned("the database user is not created in the plan mode")
+			}
+			if passwordPlaceholder {
+				return workflow.Terminate(workflow.DatabaseUserInvalidSpec, fmt.Sprintf("the password Secret %s is a placeholder, set the password and remove the %s annotation to create the user", passwordKey.Name, customresource.PasswordPlaceholderAnnotation))
+			}
 			log.Debugw("User doesn't exist. Create new user", "apiUser", apiUser)
-			if _, _, err = ctx.Client.DatabaseUsers.Create(context.Background(), project.ID(), apiUser); err != nil {
+			if _, _, err = ctx.Client.DatabaseUsers.Create(ctx.Context, project.ID(), apiUser); err != nil {
 				return workflow.Terminate(workflow.DatabaseUserNotCreatedInAtlas, err.Error())
 			}
 			ctx.EnsureStatusOption(status.AtlasDatabaseUserPasswordVersion(currentPasswordResourceVersion))
@@ -129,11 +184,24 @@ func performUpdateInAtlas(ctx *workflow.Context, k8sClient client.Client, projec
 			return workflow.Terminate(workflow.DatabaseUserNotCreatedInAtlas, err.Error())
 		}
 	}
+	if result := ownership.Check(ctx, &dbUser, "database user "+dbUser.Spec.Username, u.Labels, dbUser.Status.UserName == dbUser.Spec.Username); !result.IsOk() {
+		return result
+	}
+	// The labels in Atlas are kept if the spec doesn't have any, the ownership labels are added in both cases
+	if len(apiUser.Labels) == 0 {
+		apiUser.Labels = u.Labels
+	}
+	apiUser.Labels = ownership.WithLabels(apiUser.Labels, &dbUser)
+	dbUser.Spec.Labels = labelsToSpec(apiUser.Labels)
+
 	// Update if the spec has changed
 	if shouldUpdate, err := shouldUpdate(ctx.Log, u, dbUser, currentPasswordResourceVersion); err != nil {
 		return workflow.Terminate(workflow.Internal, err.Error())
 	} else if shouldUpdate {
-		_, _, err = ctx.Client.DatabaseUsers.Update(context.Background(), project.ID(), dbUser.Spec.Username, apiUser)
+		if ctx.PlanOnly {
+			return planUserUpdate(ctx, u, dbUser, currentPasswordResourceVersion)
+		}
+		_, _, err = ctx.Client.DatabaseUsers.Update(ctx.Context, project.ID(), dbUser.Spec.Username, apiUser)
 		if err != nil {
 			return workflow.Terminate(workflow.DatabaseUserNotUpdatedInAtlas, err.Error())
 		}
@@ -148,11 +216,32 @@ func performUpdateInAtlas(ctx *workflow.Context, k8sClient client.Client, projec
 	return workflow.OK()
 }
 
+func labelsToSpec(labels []mongodbatlas.Label) []mdbv1.LabelSpec {
+	result := make([]mdbv1.LabelSpec, 0, len(labels))
+	for _, label := range labels {
+		result = append(result, mdbv1.LabelSpec{Key: label.Key, Value: label.Value})
+	}
+	return result
+}
+
+// planUserUpdate reports the update of the database user. The password itself is never reported.
+func planUserUpdate(ctx *workflow.Context, atlasSpec *mongodbatlas.DatabaseUser, dbUser mdbv1.AtlasDatabaseUser, currentPasswordResourceVersion string) workflow.Result {
+	diff, err := userSpecDiff(atlasSpec, dbUser.Spec)
+	if err != nil {
+		return workflow.Terminate(workflow.Internal, err.Error())
+	}
+	if dbUser.Status.PasswordVersion != currentPasswordResourceVersion {
+		diff += "the password is changed"
+	}
+	ctx.PlanChange(workflow.PlanUpdate, "database user "+dbUser.Spec.Username, diff)
+	return ctx.UpdatePlanned("the database user is not updated in the plan mode")
+}
+
 func validateScopes(ctx *workflow.Context, projectID string, user mdbv1.AtlasDatabaseUser) error {
 	for _, s := range user.GetScopes(mdbv1.ClusterScopeType) {
 		var apiError *mongodbatlas.ErrorResponse
-		_, _, regularErr := ctx.Client.Clusters.Get(context.Background(), projectID, s)
-		_, _, advancedErr := ctx.Client.AdvancedClusters.Get(context.Background(), projectID, s)
+		_, _, regularErr := ctx.Client.Clusters.Get(ctx.Context, projectID, s)
+		_, _, advancedErr := ctx.Client.AdvancedClusters.Get(ctx.Context, projectID, s)
 		if errors.As(regularErr, &apiError) && apiError.ErrorCode == atlas.ClusterNotFound && errors.As(advancedErr, &apiError) && apiError.ErrorCode == atlas.ClusterNotFound {
 			return fmt.Errorf(`"scopes" field references cluster named "%s" but such cluster doesn't exist in Atlas'`, s)
 		}
@@ -161,7 +250,7 @@ func validateScopes(ctx *workflow.Context, projectID string, user mdbv1.AtlasDat
 }
 
 func checkClustersHaveReachedGoalState(ctx *workflow.Context, projectID string, user mdbv1.AtlasDatabaseUser) workflow.Result {
-	allClusterNames, err := atlascluster.GetAllClusterNames(ctx.Client, projectID)
+	allClusterNames, err := atlascluster.GetAllClusterNames(ctx.Context, ctx.Client, projectID)
 	if err != nil {
 		return workflow.Terminate(workflow.Internal, err.Error())
 	}
@@ -176,7 +265,7 @@ func checkClustersHaveReachedGoalState(ctx *workflow.Context, projectID string,
 
 	readyClusters := 0
 	for _, c := range clustersToCheck {
-		ready, err := clusterIsReady(ctx.Client, projectID, c)
+		ready, err := clusterIsReady(ctx.Context, ctx.Client, projectID, c)
 		if err != nil {
 			return workflow.Terminate(workflow.Internal, err.Error())
 		}
@@ -194,8 +283,8 @@ func checkClustersHaveReachedGoalState(ctx *workflow.Context, projectID string,
 	return workflow.OK()
 }
 
-func clusterIsReady(client mongodbatlas.Client, projectID, clusterName string) (bool, error) {
-	status, _, err := client.Clusters.Status(context.Background(), projectID, clusterName)
+func clusterIsReady(ctx context.Context, client mongodbatlas.Client, projectID, clusterName string) (bool, error) {
+	status, _, err := client.Clusters.Status(ctx, projectID, clusterName)
 	if err != nil {
 		return false, err
 	}
@@ -237,34 +326,42 @@ func shouldUpdate(log *zap.SugaredLogger, atlasSpec *mongodbatlas.DatabaseUser,
 
 // TODO move to a separate utils (reuse from clusters)
 func userMatchesSpec(log *zap.SugaredLogger, atlasSpec *mongodbatlas.DatabaseUser, operatorSpec mdbv1.AtlasDatabaseUserSpec) (bool, error) {
+	d, err := userSpecDiff(atlasSpec, operatorSpec)
+	if err != nil {
+		return false, err
+	}
+	if d != "" {
+		log.Debugf("Users differs from spec: %s", d)
+	}
+
+	return d == "", nil
+}
+
+// userSpecDiff returns the human-readable difference between the user in Atlas and the spec, empty if they match
+func userSpecDiff(atlasSpec *mongodbatlas.DatabaseUser, operatorSpec mdbv1.AtlasDatabaseUserSpec) (string, error) {
 	userMerged := mongodbatlas.DatabaseUser{}
 	if err := compat.JSONCopy(&userMerged, atlasSpec); err != nil {
-		return false, err
+		return "", err
 	}
 
 	if err := compat.JSONCopy(&userMerged, operatorSpec); err != nil {
-		return false, err
+		return "", err
 	}
 
 	// performing some normalization of dates
 	if atlasSpec.DeleteAfterDate != "" {
 		atlasDeleteDate, err := timeutil.ParseISO8601(atlasSpec.DeleteAfterDate)
 		if err != nil {
-			return false, err
+			return "", err
 		}
 		atlasSpec.DeleteAfterDate = timeutil.FormatISO8601(atlasDeleteDate)
 	}
 	if operatorSpec.DeleteAfterDate != "" {
 		operatorDeleteDate, err := timeutil.ParseISO8601(operatorSpec.DeleteAfterDate)
 		if err != nil {
-			return false, err
+			return "", err
 		}
 		userMerged.DeleteAfterDate = timeutil.FormatISO8601(operatorDeleteDate)
 	}
-	d := cmp.Diff(*atlasSpec, userMerged, cmpopts.EquateEmpty())
-	if d != "" {
-		log.Debugf("Users differs from spec: %s", d)
-	}
-
-	return d == "", nil
+	return cmp.Diff(*atlasSpec, userMerged, cmpopts.EquateEmpty()), nil
 }
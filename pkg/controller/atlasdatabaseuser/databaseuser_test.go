@@ -11,6 +11,7 @@ import (
 	"go.mongodb.org/atlas/mongodbatlas"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -19,6 +20,7 @@ import (
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
 )
@@ -102,6 +104,41 @@ func TestCheckUserExpired(t *testing.T) {
 	})
 }
 
+func TestPasswordIsPlaceholder(t *testing.T) {
+	placeholder := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "placeholder",
+			Namespace:   "ns",
+			Annotations: map[string]string{customresource.PasswordPlaceholderAnnotation: customresource.PasswordPlaceholderTrue},
+		},
+		StringData: map[string]string{"password": "replace-me"},
+	}
+	realSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "real", Namespace: "ns"},
+		StringData: map[string]string{"password": "Passw0rd!"},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(placeholder, realSecret).Build()
+
+	isPlaceholder, err := passwordIsPlaceholder(context.Background(), fakeClient, *mdbv1.DefaultDBUser("ns", "theuser", "").WithPasswordSecret("placeholder"))
+	assert.NoError(t, err)
+	assert.True(t, isPlaceholder)
+
+	isPlaceholder, err = passwordIsPlaceholder(context.Background(), fakeClient, *mdbv1.DefaultDBUser("ns", "theuser", "").WithPasswordSecret("real"))
+	assert.NoError(t, err)
+	assert.False(t, isPlaceholder)
+
+	// X.509 users don't have the password
+	user := *mdbv1.DefaultDBUser("ns", "theuser", "")
+	user.Spec.PasswordSecret = nil
+	user.Spec.X509Type = "MANAGED"
+	isPlaceholder, err = passwordIsPlaceholder(context.Background(), fakeClient, user)
+	assert.NoError(t, err)
+	assert.False(t, isPlaceholder)
+
+	_, err = passwordIsPlaceholder(context.Background(), fakeClient, *mdbv1.DefaultDBUser("ns", "theuser", "").WithPasswordSecret("missing"))
+	assert.Error(t, err)
+}
+
 func TestHandleUserNameChange(t *testing.T) {
 	t.Run("Only one user after name change", func(t *testing.T) {
 		user := *mdbv1.DefaultDBUser("ns", "theuser", "project1")
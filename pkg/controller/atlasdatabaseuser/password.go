@@ -0,0 +1,117 @@
+package atlasdatabaseuser
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/password"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
+)
+
+const PasswordRotatedEvent = "PasswordRotated"
+
+// ensurePasswordSecret generates the password if the password Secret of the user doesn't exist and rotates the
+// generated password once the rotation interval passes. The new password is sent to Atlas by performUpdateInAtlas as
+// the ResourceVersion of the Secret changes. Returns the time of the next rotation, zero if the password isn't rotated.
+func (r *AtlasDatabaseUserReconciler) ensurePasswordSecret(ctx *workflow.Context, dbUser mdbv1.AtlasDatabaseUser) (time.Time, workflow.Result) {
+	passwordKey := dbUser.PasswordSecretObjectKey()
+	if passwordKey == nil {
+		return time.Time{}, workflow.OK()
+	}
+	rotation := dbUser.Spec.PasswordRotation
+
+	secret := &corev1.Secret{}
+	err := r.Client.Get(ctx.Context, *passwordKey, secret)
+	if err != nil && !apiErrors.IsNotFound(err) {
+		return time.Time{}, workflow.Terminate(workflow.Internal, err.Error())
+	}
+	exists := err == nil
+	resource := "password Secret " + passwordKey.String()
+
+	if exists {
+		rotatedAt, generated := secret.Annotations[customresource.PasswordRotatedAtAnnotation]
+		if !generated {
+			if rotation != nil {
+				return time.Time{}, workflow.Terminate(workflow.DatabaseUserInvalidSpec,
+					fmt.Sprintf("spec.passwordRotation requires the password generated by the Operator, the Secret %s doesn't have the %s annotation", passwordKey.Name, customresource.PasswordRotatedAtAnnotation))
+			}
+			return time.Time{}, workflow.OK()
+		}
+		ctx.EnsureStatusOption(status.AtlasDatabaseUserPasswordRotatedOption(rotatedAt))
+		if rotation == nil {
+			return time.Time{}, workflow.OK()
+		}
+		// The password with the malformed timestamp is rotated right away
+		lastRotation, _ := timeutil.ParseISO8601(rotatedAt)
+		if nextRotation := lastRotation.Add(rotation.Interval.Duration); time.Now().Before(nextRotation) {
+			return nextRotation, workflow.OK()
+		}
+		if ctx.PlanOnly {
+			ctx.PlanChange(workflow.PlanUpdate, resource, "the password is rotated")
+			return time.Time{}, ctx.UpdatePlanned("the password is not rotated in the plan mode")
+		}
+	} else if ctx.PlanOnly {
+		ctx.PlanChange(workflow.PlanCreate, resource, "the password is generated")
+		return time.Time{}, workflow.Planned("the password is not generated in the plan mode")
+	}
+
+	userPassword, err := password.Generate(password.DefaultLength)
+	if err != nil {
+		return time.Time{}, workflow.Terminate(workflow.DatabaseUserPasswordNotGenerated, err.Error())
+	}
+	now := time.Now().UTC()
+	rotatedAt := timeutil.FormatISO8601(now)
+
+	secret.Name = passwordKey.Name
+	secret.Namespace = passwordKey.Namespace
+	// The label is required for the Secret to be visible to the Operator (see the cache configuration)
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+	secret.Labels[connectionsecret.TypeLabelKey] = connectionsecret.CredLabelVal
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[customresource.PasswordRotatedAtAnnotation] = rotatedAt
+	secret.Data = map[string][]byte{"password": []byte(userPassword)}
+
+	if exists {
+		err = r.Client.Update(ctx.Context, secret)
+	} else {
+		// The generated password is removed together with the user
+		if err = controllerutil.SetControllerReference(&dbUser, secret, r.Scheme); err != nil {
+			return time.Time{}, workflow.Terminate(workflow.Internal, err.Error())
+		}
+		err = r.Client.Create(ctx.Context, secret)
+		if apiErrors.IsAlreadyExists(err) {
+			// The Secret without the label isn't visible to the Operator, so it's reported as not found above
+			return time.Time{}, workflow.Terminate(workflow.DatabaseUserInvalidSpec,
+				fmt.Sprintf("the password Secret %s already exists but isn't visible to the Operator, add the %s=%s label to it", passwordKey.Name, connectionsecret.TypeLabelKey, connectionsecret.CredLabelVal))
+		}
+	}
+	if err != nil {
+		return time.Time{}, workflow.Terminate(workflow.DatabaseUserPasswordNotGenerated, err.Error())
+	}
+	ctx.EnsureStatusOption(status.AtlasDatabaseUserPasswordRotatedOption(rotatedAt))
+
+	if exists {
+		ctx.Log.Infow("Rotated the database user password", "secret", passwordKey)
+		r.EventRecorder.Event(&dbUser, corev1.EventTypeNormal, PasswordRotatedEvent, fmt.Sprintf("The password of the database user %s is rotated", dbUser.Spec.Username))
+	} else {
+		ctx.Log.Infow("Generated the database user password", "secret", passwordKey)
+	}
+
+	if rotation == nil {
+		return time.Time{}, workflow.OK()
+	}
+	return now.Add(rotation.Interval.Duration), workflow.OK()
+}
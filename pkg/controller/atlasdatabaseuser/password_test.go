@@ -0,0 +1,187 @@
+package atlasdatabaseuser
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
+)
+
+func passwordReconciler(t *testing.T, objects ...client.Object) (*AtlasDatabaseUserReconciler, *record.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, mdbv1.AddToScheme(scheme))
+	recorder := record.NewFakeRecorder(10)
+	return &AtlasDatabaseUserReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		Scheme:        scheme,
+		EventRecorder: recorder,
+	}, recorder
+}
+
+// unlabelledSecretsClient hides the Secrets without the credentials label as the cache of the Operator does.
+type unlabelledSecretsClient struct {
+	client.Client
+}
+
+func (c unlabelledSecretsClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	if _, ok := obj.(*corev1.Secret); ok {
+		secret := &corev1.Secret{}
+		if err := c.Client.Get(ctx, key, secret); err == nil && secret.Labels[connectionsecret.TypeLabelKey] != connectionsecret.CredLabelVal {
+			return apiErrors.NewNotFound(corev1.Resource("secrets"), key.Name)
+		}
+	}
+	return c.Client.Get(ctx, key, obj)
+}
+
+func passwordContext() *workflow.Context {
+	ctx := workflow.NewContext(zap.S(), nil)
+	ctx.Context = context.Background()
+	return ctx
+}
+
+func generatedPasswordSecret(name, rotatedAt string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "ns",
+			Labels:      map[string]string{connectionsecret.TypeLabelKey: connectionsecret.CredLabelVal},
+			Annotations: map[string]string{customresource.PasswordRotatedAtAnnotation: rotatedAt},
+		},
+		Data: map[string][]byte{"password": []byte("old-password")},
+	}
+}
+
+func rotatedAtOf(ctx *workflow.Context) string {
+	result := status.AtlasDatabaseUserStatus{}
+	for _, o := range ctx.StatusOptions() {
+		if option, ok := o.(status.AtlasDatabaseUserStatusOption); ok {
+			option(&result)
+		}
+	}
+	return result.PasswordRotatedAt
+}
+
+func TestEnsurePasswordSecret(t *testing.T) {
+	t.Run("Password is generated if the Secret is not referenced", func(t *testing.T) {
+		user := mdbv1.DefaultDBUser("ns", "theuser", "my-project")
+		user.Spec.PasswordSecret = nil
+		user.UID = types.UID("theuser-uid")
+		reconciler, _ := passwordReconciler(t, user)
+		ctx := passwordContext()
+
+		nextRotation, result := reconciler.ensurePasswordSecret(ctx, *user)
+		require.True(t, result.IsOk())
+		assert.True(t, nextRotation.IsZero())
+
+		secret := &corev1.Secret{}
+		require.NoError(t, reconciler.Client.Get(context.Background(), kube.ObjectKey("ns", "theuser-password"), secret))
+		assert.Len(t, secret.Data["password"], 32)
+		assert.Equal(t, connectionsecret.CredLabelVal, secret.Labels[connectionsecret.TypeLabelKey])
+		assert.Equal(t, "theuser", secret.OwnerReferences[0].Name)
+		assert.Equal(t, secret.Annotations[customresource.PasswordRotatedAtAnnotation], rotatedAtOf(ctx))
+
+		password, err := user.ReadPassword(reconciler.Client)
+		require.NoError(t, err)
+		assert.Equal(t, string(secret.Data["password"]), password)
+	})
+	t.Run("Password is generated if the referenced Secret doesn't exist", func(t *testing.T) {
+		user := mdbv1.DefaultDBUser("ns", "theuser", "my-project").WithPasswordSecret("my-password")
+		reconciler, _ := passwordReconciler(t, user)
+
+		_, result := reconciler.ensurePasswordSecret(passwordContext(), *user)
+		require.True(t, result.IsOk())
+		assert.NoError(t, reconciler.Client.Get(context.Background(), kube.ObjectKey("ns", "my-password"), &corev1.Secret{}))
+	})
+	t.Run("Password is not generated in the plan mode", func(t *testing.T) {
+		user := mdbv1.DefaultDBUser("ns", "theuser", "my-project").WithPasswordSecret("my-password")
+		reconciler, _ := passwordReconciler(t, user)
+		ctx := passwordContext()
+		ctx.PlanOnly = true
+
+		_, result := reconciler.ensurePasswordSecret(ctx, *user)
+		assert.False(t, result.IsOk())
+		assert.Equal(t, workflow.PlanCreate, ctx.PlannedChanges()[0].Action)
+		assert.Error(t, reconciler.Client.Get(context.Background(), kube.ObjectKey("ns", "my-password"), &corev1.Secret{}))
+	})
+	t.Run("Password is rotated once the interval passes", func(t *testing.T) {
+		user := mdbv1.DefaultDBUser("ns", "theuser", "my-project").WithPasswordSecret("my-password").WithPasswordRotation(24 * time.Hour)
+		dayAgo := timeutil.FormatISO8601(time.Now().Add(-25 * time.Hour))
+		reconciler, recorder := passwordReconciler(t, user, generatedPasswordSecret("my-password", dayAgo))
+		ctx := passwordContext()
+
+		nextRotation, result := reconciler.ensurePasswordSecret(ctx, *user)
+		require.True(t, result.IsOk())
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), nextRotation, time.Minute)
+
+		secret := &corev1.Secret{}
+		require.NoError(t, reconciler.Client.Get(context.Background(), kube.ObjectKey("ns", "my-password"), secret))
+		assert.NotEqual(t, "old-password", string(secret.Data["password"]))
+		assert.NotEqual(t, dayAgo, rotatedAtOf(ctx))
+		assert.Contains(t, <-recorder.Events, PasswordRotatedEvent)
+	})
+	t.Run("Password is not rotated before the interval passes", func(t *testing.T) {
+		user := mdbv1.DefaultDBUser("ns", "theuser", "my-project").WithPasswordSecret("my-password").WithPasswordRotation(24 * time.Hour)
+		hourAgo := time.Now().Add(-time.Hour).UTC()
+		reconciler, _ := passwordReconciler(t, user, generatedPasswordSecret("my-password", timeutil.FormatISO8601(hourAgo)))
+		ctx := passwordContext()
+
+		nextRotation, result := reconciler.ensurePasswordSecret(ctx, *user)
+		require.True(t, result.IsOk())
+		assert.WithinDuration(t, hourAgo.Add(24*time.Hour), nextRotation, time.Second)
+
+		secret := &corev1.Secret{}
+		require.NoError(t, reconciler.Client.Get(context.Background(), kube.ObjectKey("ns", "my-password"), secret))
+		assert.Equal(t, "old-password", string(secret.Data["password"]))
+		assert.Equal(t, timeutil.FormatISO8601(hourAgo), rotatedAtOf(ctx))
+	})
+	t.Run("Password provided by the user is not rotated", func(t *testing.T) {
+		user := mdbv1.DefaultDBUser("ns", "theuser", "my-project").WithPasswordSecret("my-password").WithPasswordRotation(24 * time.Hour)
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-password", Namespace: "ns"},
+			Data:       map[string][]byte{"password": []byte("Passw0rd!")},
+		}
+		reconciler, _ := passwordReconciler(t, user, secret)
+
+		_, result := reconciler.ensurePasswordSecret(passwordContext(), *user)
+		assert.False(t, result.IsOk())
+		assert.Equal(t, workflow.RetryPermanent, result.RetryClass())
+	})
+	t.Run("Unlabelled Secret is reported to be labelled", func(t *testing.T) {
+		user := mdbv1.DefaultDBUser("ns", "theuser", "my-project").WithPasswordSecret("my-password")
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-password", Namespace: "ns"},
+			Data:       map[string][]byte{"password": []byte("Passw0rd!")},
+		}
+		reconciler, _ := passwordReconciler(t, user, secret)
+		reconciler.Client = unlabelledSecretsClient{Client: reconciler.Client}
+
+		ctx := passwordContext()
+		_, result := reconciler.ensurePasswordSecret(ctx, *user)
+		assert.False(t, result.IsOk())
+		assert.Equal(t, workflow.RetryPermanent, result.RetryClass())
+
+		ctx.SetConditionFromResult(status.DatabaseUserReadyType, result)
+		assert.Equal(t, string(workflow.DatabaseUserInvalidSpec), ctx.LastCondition().Reason)
+		assert.Contains(t, ctx.LastCondition().Message, connectionsecret.TypeLabelKey)
+	})
+}
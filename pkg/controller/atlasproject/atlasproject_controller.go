@@ -43,6 +43,7 @@ import (
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/statushandler"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/tracing"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/watch"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
@@ -54,10 +55,17 @@ type AtlasProjectReconciler struct {
 	watch.ResourceWatcher
 	Log              *zap.SugaredLogger
 	Scheme           *runtime.Scheme
-	AtlasDomain      string
+	AtlasClients     *atlas.ClientCache
 	GlobalAPISecret  client.ObjectKey
 	GlobalPredicates []predicate.Predicate
 	EventRecorder    record.EventRecorder
+	// MaxConcurrentReconciles is the number of the AtlasProjects reconciled in parallel, defaults to 1
+	MaxConcurrentReconciles int
+	// PlanMode makes all the AtlasProjects reconciled in the plan mode (see customresource.ReconciliationShouldOnlyPlan)
+	PlanMode bool
+	// WatchNodes enables the Kubernetes Nodes as the source of the IP Access List. The Nodes are cluster-scoped so they
+	// can be watched only if the Operator is not restricted to a single namespace.
+	WatchNodes bool
 }
 
 // Dev note: duplicate the permissions in both sections below to generate both Role and ClusterRoles
@@ -66,15 +74,19 @@ type AtlasProjectReconciler struct {
 // +kubebuilder:rbac:groups=atlas.mongodb.com,resources=atlasprojects/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 
 // +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasprojects,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=atlas.mongodb.com,namespace=default,resources=atlasprojects/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",namespace=default,resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",namespace=default,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",namespace=default,resources=configmaps,verbs=get;list;watch
 
 func (r *AtlasProjectReconciler) Reconcile(context context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = context
-	log := r.Log.With("atlasproject", req.NamespacedName)
+	context, span := tracing.StartReconcile(context, "AtlasProject", req.NamespacedName)
+	defer span.End()
+	log := tracing.Logger(context, r.Log.With("atlasproject", req.NamespacedName))
 
 	project := &mdbv1.AtlasProject{}
 	result := customresource.PrepareResource(r.Client, req, project, log)
@@ -93,36 +105,42 @@ func (r *AtlasProjectReconciler) Reconcile(context context.Context, req ctrl.Req
 		r.EnsureResourcesAreWatched(req.NamespacedName, "Secret", log, *project.ConnectionSecretObjectKey())
 	}
 	ctx := customresource.MarkReconciliationStarted(r.Client, project, log)
+	ctx.Context = context
+	ctx.ObserveOnly = customresource.ReconciliationShouldOnlyObserve(project)
+	ctx.PlanOnly = r.PlanMode || ctx.ObserveOnly || customresource.ReconciliationShouldOnlyPlan(project)
 
-	log.Infow("-> Starting AtlasProject reconciliation", "spec", project.Spec)
+	log.Infow("-> Starting AtlasProject reconciliation", "spec", project.Spec, "planOnly", ctx.PlanOnly, "observeOnly", ctx.ObserveOnly)
 
 	// This update will make sure the status is always updated in case of any errors or successful result
 	defer statushandler.Update(ctx, r.Client, r.EventRecorder, project)
+	defer ctx.CompletePlan()
 
 	if err := validate.Project(project); err != nil {
-		result := workflow.Terminate(workflow.Internal, err.Error())
+		result := workflow.Terminate(workflow.Internal, err.Error()).WithoutRetry()
 		ctx.SetConditionFromResult(status.ValidationSucceeded, result)
 		return result.ReconcileResult(), nil
 	}
 	ctx.SetConditionTrue(status.ValidationSucceeded)
 
-	connection, err := atlas.ReadConnection(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
+	getAtlasClient := r.AtlasClients.Get
+	if ctx.PlanOnly {
+		getAtlasClient = r.AtlasClients.GetReadOnly
+	}
+	connection, atlasClient, err := getAtlasClient(log, r.Client, r.GlobalAPISecret, project.ConnectionSecretObjectKey())
 	if err != nil {
 		if errRm := r.removeDeletionFinalizer(context, project); errRm != nil {
 			result = workflow.Terminate(workflow.Internal, errRm.Error())
 			ctx.SetConditionFromResult(status.ClusterReadyType, result)
 		}
 		result = workflow.Terminate(workflow.AtlasCredentialsNotProvided, err.Error())
+		if project.ConnectionSecretObjectKey() == nil {
+			// The global Secret is not watched so the reconciliation needs to be retried until it's fixed
+			result = result.WithRetryClass(workflow.RetryTransient)
+		}
 		ctx.SetConditionFromResult(status.ProjectReadyType, result)
 		return result.ReconcileResult(), nil
 	}
 	ctx.Connection = connection
-
-	atlasClient, err := atlas.Client(r.AtlasDomain, connection, log)
-	if err != nil {
-		ctx.SetConditionFromResult(status.ClusterReadyType, workflow.Terminate(workflow.Internal, err.Error()))
-		return result.ReconcileResult(), nil
-	}
 	ctx.Client = atlasClient
 
 	var projectID string
@@ -155,6 +173,8 @@ func (r *AtlasProjectReconciler) Reconcile(context context.Context, req ctrl.Req
 		if isDeletionFinalizerPresent(project) {
 			if customresource.ResourceShouldBeLeftInAtlas(project) {
 				log.Infof("Not removing the Atlas Project from Atlas as the '%s' annotation is set", customresource.ResourcePolicyAnnotation)
+			} else if ctx.PlanOnly {
+				log.Infow("Not removing the Atlas Project from Atlas in the plan or observe mode", "plannedChange", workflow.PlanDelete+" project "+projectID)
 			} else {
 				if result = DeleteAllPrivateEndpoints(ctx, atlasClient, projectID, project.Status.PrivateEndpoints, log); !result.IsOk() {
 					ctx.SetConditionFromResult(status.PrivateEndpointReadyType, result)
@@ -182,7 +202,14 @@ func (r *AtlasProjectReconciler) Reconcile(context context.Context, req ctrl.Req
 	ctx.SetConditionTrue(status.ProjectReadyType)
 	r.EventRecorder.Event(project, "Normal", string(status.ProjectReadyType), "")
 
-	if result = ensureIPAccessList(ctx, projectID, project); !result.IsOk() {
+	ipAccessList, configMaps, result := r.resolveIPAccessList(context, project)
+	r.EnsureResourcesAreWatched(req.NamespacedName, "ConfigMap", log, configMaps...)
+	if !result.IsOk() {
+		ctx.SetConditionFromResult(status.IPAccessListReadyType, result)
+		return result.ReconcileResult(), nil
+	}
+
+	if result = ensureIPAccessList(ctx, projectID, project, ipAccessList); !result.IsOk() {
 		ctx.SetConditionFromResult(status.IPAccessListReadyType, result)
 		return result.ReconcileResult(), nil
 	}
@@ -217,7 +244,7 @@ func (r *AtlasProjectReconciler) allIPAccessListsAreReady(context context.Contex
 		return false, workflow.Terminate(workflow.Internal, err.Error())
 	}
 	for _, ipAccessList := range atlasAccess.Results {
-		ipStatus, err := GetIPAccessListStatus(ctx.Client, ipAccessList)
+		ipStatus, err := GetIPAccessListStatus(ctx.Context, ctx.Client, ipAccessList)
 		if err != nil {
 			return false, workflow.Terminate(workflow.Internal, err.Error())
 		}
@@ -244,7 +271,11 @@ func (r *AtlasProjectReconciler) deleteAtlasProject(ctx context.Context, atlasCl
 }
 
 func (r *AtlasProjectReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	c, err := controller.New("AtlasProject", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("AtlasProject", mgr, controller.Options{
+		Reconciler:              r,
+		RateLimiter:             workflow.RateLimiter(),
+		MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+	})
 	if err != nil {
 		return err
 	}
@@ -260,6 +291,20 @@ func (r *AtlasProjectReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if err != nil {
 		return err
 	}
+
+	// Watch for the ConfigMaps used as the sources of the IP Access List
+	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, watch.NewConfigMapHandler(r.WatchedResources))
+	if err != nil {
+		return err
+	}
+
+	// Watch for the Nodes used as the sources of the IP Access List
+	if r.WatchNodes {
+		err = c.Watch(&source.Kind{Type: &corev1.Node{}}, handler.EnqueueRequestsFromMapFunc(r.projectsWithNodeSources), nodeAddressesPredicate())
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
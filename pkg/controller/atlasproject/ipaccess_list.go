@@ -2,7 +2,6 @@ package atlasproject
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -14,6 +13,7 @@ import (
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/validate"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/set"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
@@ -37,67 +37,133 @@ func (i atlasProjectIPAccessList) Identifier() interface{} {
 }
 
 // ensureIPAccessList ensures that the state of the Atlas IP Access List matches the
-// state of the IP Access list specified in the project CR. Any Access Lists which exist
-// in Atlas but are not specified in the CR are deleted.
-func ensureIPAccessList(ctx *workflow.Context, projectID string, project *mdbv1.AtlasProject) workflow.Result {
-	if err := validateIPAccessLists(project.Spec.ProjectIPAccessList); err != nil {
+// state of the IP Access list specified in the project CR (with the sources resolved, see
+// resolveIPAccessList). Any Access Lists which exist in Atlas but are not specified in the
+// CR are deleted. With the 'merge' policy only the Access Lists created by the Operator
+// before (tracked in the status) are deleted.
+func ensureIPAccessList(ctx *workflow.Context, projectID string, project *mdbv1.AtlasProject, ipAccessList []project.IPAccessList) workflow.Result {
+	defer ctx.StartSpan("ensureIPAccessList")()
+	if err := validateIPAccessLists(ipAccessList); err != nil {
 		return workflow.Terminate(workflow.ProjectIPAccessInvalid, err.Error())
 	}
-	active, expired := filterActiveIPAccessLists(project.Spec.ProjectIPAccessList)
+	active, expired := filterActiveIPAccessLists(ipAccessList)
+	deletable := deletableIPAccessLists(project)
 
-	if result := createOrDeleteInAtlas(ctx.Client, projectID, active, ctx.Log); !result.IsOk() {
+	if ctx.PlanOnly {
+		if result := planIPAccessList(ctx, projectID, active, deletable); !result.IsOk() {
+			return result
+		}
+		ctx.EnsureStatusOption(status.AtlasProjectExpiredIPAccessOption(expired))
+		return workflow.OK()
+	}
+
+	created, result := createOrDeleteInAtlas(ctx.Context, ctx.Client, projectID, active, deletable, ctx.Log)
+	if !result.IsOk() {
 		return result
 	}
 	ctx.EnsureStatusOption(status.AtlasProjectExpiredIPAccessOption(expired))
+	ctx.EnsureStatusOption(status.AtlasProjectIPAccessListEntriesOption(createdIPAccessListEntries(project.Status.IPAccessListEntries, active, created)))
 	return workflow.OK()
 }
 
-func validateIPAccessLists(ipAccessList []project.IPAccessList) error {
-	for _, list := range ipAccessList {
-		if err := validateSingleIPAccessList(list); err != nil {
-			return err
+// deletableIPAccessLists returns the filter of the Atlas IP Access List entries which may be deleted if they are not
+// specified in the project CR.
+func deletableIPAccessLists(project *mdbv1.AtlasProject) func(atlasProjectIPAccessList) bool {
+	if project.Spec.IPAccessListPolicy != mdbv1.ListPolicyMerge {
+		return func(atlasProjectIPAccessList) bool { return true }
+	}
+	createdByOperator := map[string]bool{}
+	for _, id := range project.Status.IPAccessListEntries {
+		createdByOperator[id] = true
+	}
+	return func(l atlasProjectIPAccessList) bool {
+		return createdByOperator[l.Identifier().(string)]
+	}
+}
+
+// createdIPAccessListEntries returns the identifiers of the IP Access List entries created by the Operator: the ones
+// which have just been created and the ones created before (the 'tracked' ones) which are still specified. The entries
+// which existed in Atlas before they were specified in the project CR are not tracked, so they are never deleted with the
+// 'merge' policy.
+func createdIPAccessListEntries(tracked []string, operatorIPAccessLists []project.IPAccessList, created []set.Identifiable) []string {
+	createdByOperator := map[string]bool{}
+	for _, id := range tracked {
+		createdByOperator[id] = true
+	}
+	for _, l := range created {
+		createdByOperator[l.Identifier().(string)] = true
+	}
+	result := make([]string, 0, len(operatorIPAccessLists))
+	for _, l := range operatorIPAccessLists {
+		if id := l.Identifier().(string); createdByOperator[id] {
+			result = append(result, id)
 		}
 	}
-	return nil
+	return result
 }
 
-// validateSingleIPAccessList performs validation of the IP access list. Note, that we intentionally don't validate
-// IP addresses or CIDR blocks - this will be done by Atlas. But we need to validate the timestamp as we use it to filter
-// active and expired ip access lists.
-func validateSingleIPAccessList(list project.IPAccessList) error {
-	if list.DeleteAfterDate != "" {
-		_, err := timeutil.ParseISO8601(list.DeleteAfterDate)
-		if err != nil {
-			return err
+// ipAccessListsToDelete returns the Atlas IP Access List entries which are not specified in the project CR and may be
+// deleted according to the IP Access List policy.
+func ipAccessListsToDelete(atlasAccess []mongodbatlas.ProjectIPAccessList, operatorIPAccessLists []project.IPAccessList, deletable func(atlasProjectIPAccessList) bool) []set.Identifiable {
+	// Making a new slice with synonyms as Atlas IP Access list to enable usage of 'Identifiable'
+	atlasAccessLists := make([]atlasProjectIPAccessList, 0, len(atlasAccess))
+	for _, r := range atlasAccess {
+		if deletable(atlasProjectIPAccessList(r)) {
+			atlasAccessLists = append(atlasAccessLists, atlasProjectIPAccessList(r))
 		}
 	}
-	onlyOneSpecified := onlyOneSpecified(list.AwsSecurityGroup, list.CIDRBlock, list.IPAddress)
-	allSpecified := isNotEmpty(list.AwsSecurityGroup) && isNotEmpty(list.CIDRBlock) && isNotEmpty(list.IPAddress)
-	if !onlyOneSpecified || allSpecified {
-		return errors.New("only one of the 'awsSecurityGroup', 'cidrBlock' or 'ipAddress' is required be specified")
+	return set.Difference(atlasAccessLists, operatorIPAccessLists)
+}
+
+func validateIPAccessLists(ipAccessList []project.IPAccessList) error {
+	for _, list := range ipAccessList {
+		if err := validate.IPAccessList(list); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func createOrDeleteInAtlas(client mongodbatlas.Client, projectID string, operatorIPAccessLists []project.IPAccessList, log *zap.SugaredLogger) workflow.Result {
-	atlasAccess, _, err := client.ProjectIPAccessList.List(context.Background(), projectID, &mongodbatlas.ListOptions{})
+// createOrDeleteInAtlas makes the Atlas IP Access List match the ones specified in the project CR and returns the
+// entries which didn't exist in Atlas before.
+func createOrDeleteInAtlas(ctx context.Context, client mongodbatlas.Client, projectID string, operatorIPAccessLists []project.IPAccessList, deletable func(atlasProjectIPAccessList) bool, log *zap.SugaredLogger) ([]set.Identifiable, workflow.Result) {
+	atlasAccess, _, err := client.ProjectIPAccessList.List(ctx, projectID, &mongodbatlas.ListOptions{})
 	if err != nil {
-		return workflow.Terminate(workflow.ProjectIPNotCreatedInAtlas, err.Error())
+		return nil, workflow.Terminate(workflow.ProjectIPNotCreatedInAtlas, err.Error())
 	}
-	// Making a new slice with synonyms as Atlas IP Access list to enable usage of 'Identifiable'
 	atlasAccessLists := make([]atlasProjectIPAccessList, len(atlasAccess.Results))
 	for i, r := range atlasAccess.Results {
 		atlasAccessLists[i] = atlasProjectIPAccessList(r)
 	}
 
-	accessListsToDelete := set.Difference(atlasAccessLists, operatorIPAccessLists)
+	accessListsToDelete := ipAccessListsToDelete(atlasAccess.Results, operatorIPAccessLists, deletable)
+
+	if err := deleteIPAccessFromAtlas(ctx, client, projectID, accessListsToDelete, log); err != nil {
+		return nil, workflow.Terminate(workflow.ProjectIPNotCreatedInAtlas, err.Error())
+	}
+
+	if result := createIPAccessListsInAtlas(ctx, client, projectID, operatorIPAccessLists); !result.IsOk() {
+		return nil, result
+	}
+	return set.Difference(operatorIPAccessLists, atlasAccessLists), workflow.OK()
+}
 
-	if err := deleteIPAccessFromAtlas(client, projectID, accessListsToDelete, log); err != nil {
+// planIPAccessList reports the changes createOrDeleteInAtlas would make in Atlas
+func planIPAccessList(ctx *workflow.Context, projectID string, operatorIPAccessLists []project.IPAccessList, deletable func(atlasProjectIPAccessList) bool) workflow.Result {
+	atlasAccess, _, err := ctx.Client.ProjectIPAccessList.List(ctx.Context, projectID, &mongodbatlas.ListOptions{})
+	if err != nil {
 		return workflow.Terminate(workflow.ProjectIPNotCreatedInAtlas, err.Error())
 	}
+	atlasAccessLists := make([]atlasProjectIPAccessList, len(atlasAccess.Results))
+	for i, r := range atlasAccess.Results {
+		atlasAccessLists[i] = atlasProjectIPAccessList(r)
+	}
 
-	if result := createIPAccessListsInAtlas(client, projectID, operatorIPAccessLists); !result.IsOk() {
-		return result
+	for _, l := range ipAccessListsToDelete(atlasAccess.Results, operatorIPAccessLists, deletable) {
+		ctx.PlanChange(workflow.PlanDelete, fmt.Sprintf("IP access list entry %s", l.Identifier()), "")
+	}
+	for _, l := range set.Difference(operatorIPAccessLists, atlasAccessLists) {
+		ctx.PlanChange(workflow.PlanCreate, fmt.Sprintf("IP access list entry %s", l.Identifier()), "")
 	}
 	return workflow.OK()
 }
@@ -116,21 +182,21 @@ func operatorToAtlasIPAccessList(ipAccessLists []project.IPAccessList) ([]*mongo
 	return operatorAccessLists, workflow.OK()
 }
 
-func createIPAccessListsInAtlas(client mongodbatlas.Client, projectID string, ipAccessLists []project.IPAccessList) workflow.Result {
+func createIPAccessListsInAtlas(ctx context.Context, client mongodbatlas.Client, projectID string, ipAccessLists []project.IPAccessList) workflow.Result {
 	operatorAccessLists, status := operatorToAtlasIPAccessList(ipAccessLists)
 	if !status.IsOk() {
 		return status
 	}
 
-	if _, _, err := client.ProjectIPAccessList.Create(context.Background(), projectID, operatorAccessLists); err != nil {
+	if _, _, err := client.ProjectIPAccessList.Create(ctx, projectID, operatorAccessLists); err != nil {
 		return workflow.Terminate(workflow.ProjectIPNotCreatedInAtlas, err.Error())
 	}
 	return workflow.OK()
 }
 
-func deleteIPAccessFromAtlas(client mongodbatlas.Client, projectID string, listsToRemove []set.Identifiable, log *zap.SugaredLogger) error {
+func deleteIPAccessFromAtlas(ctx context.Context, client mongodbatlas.Client, projectID string, listsToRemove []set.Identifiable, log *zap.SugaredLogger) error {
 	for _, l := range listsToRemove {
-		if _, err := client.ProjectIPAccessList.Delete(context.Background(), projectID, l.Identifier().(string)); err != nil {
+		if _, err := client.ProjectIPAccessList.Delete(ctx, projectID, l.Identifier().(string)); err != nil {
 			return err
 		}
 		log.Debugw("Removed IPAccessList from Atlas as it's not specified in current AtlasProject", "id", l.Identifier())
@@ -165,14 +231,14 @@ func getAccessListEntry(accessList mongodbatlas.ProjectIPAccessList) string {
 
 // GetIPAccessListStatus returns the status of an individual project ip access list. The documentation can be found
 // here https://docs.atlas.mongodb.com/reference/api/ip-access-list/get-one-access-list-entry-status/
-func GetIPAccessListStatus(client mongodbatlas.Client, accessList mongodbatlas.ProjectIPAccessList) (IPAccessListStatus, error) {
+func GetIPAccessListStatus(ctx context.Context, client mongodbatlas.Client, accessList mongodbatlas.ProjectIPAccessList) (IPAccessListStatus, error) {
 	urlStr := fmt.Sprintf("/api/atlas/v1.0/groups/%s/accessList/%s/status", accessList.GroupID, getAccessListEntry(accessList))
-	req, err := client.NewRequest(context.Background(), http.MethodGet, urlStr, nil)
+	req, err := client.NewRequest(ctx, http.MethodGet, urlStr, nil)
 	if err != nil {
 		return IPAccessListStatus{}, err
 	}
 	ipAccessListStatus := IPAccessListStatus{}
-	_, err = client.Do(context.Background(), req, &ipAccessListStatus)
+	_, err = client.Do(ctx, req, &ipAccessListStatus)
 	if err != nil {
 		return IPAccessListStatus{}, err
 	}
@@ -196,24 +262,3 @@ func filterActiveIPAccessLists(accessLists []project.IPAccessList) ([]project.IP
 	}
 	return active, expired
 }
-
-func isNotEmpty(s string) bool {
-	return s != ""
-}
-
-func onlyOneSpecified(values ...string) bool {
-	found := false
-	for _, v := range values {
-		if v == "" {
-			continue
-		}
-
-		if found {
-			return false
-		}
-
-		found = true
-	}
-
-	return found
-}
@@ -0,0 +1,231 @@
+package atlasproject
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlasaccessrequest"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/validate"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
+)
+
+// resolveIPAccessList returns the IP Access List of the project with the entries having the 'source' replaced by the
+// entries for each of the addresses read from the source and with the entries of the access requests added. The keys
+// of the ConfigMaps used as the sources are returned so that they can be watched.
+func (r *AtlasProjectReconciler) resolveIPAccessList(ctx context.Context, atlasProject *mdbv1.AtlasProject) ([]project.IPAccessList, []client.ObjectKey, workflow.Result) {
+	var result []project.IPAccessList
+	var configMaps []client.ObjectKey
+	for _, list := range atlasProject.Spec.ProjectIPAccessList {
+		if list.Source == nil {
+			result = append(result, list)
+			continue
+		}
+		var resolved []project.IPAccessList
+		var err error
+		switch {
+		case list.Source.NodeSelector != nil:
+			if !r.WatchNodes {
+				return nil, nil, workflow.Terminate(workflow.ProjectIPAccessInvalid, "the IP Access List with the node selector source is not supported when the Operator watches a single namespace")
+			}
+			resolved, err = r.nodesIPAccessList(ctx, list)
+		default:
+			key := client.ObjectKey{Namespace: atlasProject.Namespace, Name: list.Source.ConfigMap}
+			configMaps = append(configMaps, key)
+			resolved, err = r.configMapIPAccessList(ctx, key, list)
+		}
+		if err != nil {
+			return nil, configMaps, workflow.Terminate(workflow.ProjectIPAccessInvalid, err.Error())
+		}
+		result = append(result, resolved...)
+	}
+
+	accessRequests, err := r.accessRequestsIPAccessList(ctx, atlasProject)
+	if err != nil {
+		return nil, configMaps, workflow.Terminate(workflow.Internal, err.Error())
+	}
+	result = append(result, accessRequests...)
+	return uniqueIPAccessLists(result), configMaps, workflow.OK()
+}
+
+// accessRequestsIPAccessList returns the IP Access List entries of the AtlasAccessRequests for the project which haven't
+// expired yet. The entries are created by the AtlasAccessRequest controller, they are added to the list so that the
+// project doesn't remove them.
+func (r *AtlasProjectReconciler) accessRequestsIPAccessList(ctx context.Context, atlasProject *mdbv1.AtlasProject) ([]project.IPAccessList, error) {
+	requests := mdbv1.AtlasAccessRequestList{}
+	if err := r.Client.List(ctx, &requests); err != nil {
+		return nil, fmt.Errorf("failed to list the AtlasAccessRequests: %w", err)
+	}
+	var result []project.IPAccessList
+	for i := range requests.Items {
+		request := &requests.Items[i]
+		if request.AtlasProjectObjectKey() != kube.ObjectKeyFromObject(atlasProject) || request.Status.Expired || !request.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if validate.AccessRequest(request) != nil {
+			continue
+		}
+		result = append(result, atlasaccessrequest.IPAccessList(request))
+	}
+	return result, nil
+}
+
+// nodesIPAccessList returns the IP Access List entries for the external IP addresses of the Nodes selected by the
+// source node selector.
+func (r *AtlasProjectReconciler) nodesIPAccessList(ctx context.Context, list project.IPAccessList) ([]project.IPAccessList, error) {
+	selector, err := metav1.LabelSelectorAsSelector(list.Source.NodeSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid node selector in the IP Access List source: %w", err)
+	}
+	nodes := corev1.NodeList{}
+	if err = r.Client.List(ctx, &nodes, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list the Nodes for the IP Access List: %w", err)
+	}
+	var result []project.IPAccessList
+	for _, node := range nodes.Items {
+		for _, ip := range nodeExternalIPs(&node) {
+			result = append(result, sourceIPAccessList(list, ip, "node "+node.Name))
+		}
+	}
+	return result, nil
+}
+
+// configMapIPAccessList returns the IP Access List entries for the IP addresses and CIDR blocks stored in the values
+// of the ConfigMap.
+func (r *AtlasProjectReconciler) configMapIPAccessList(ctx context.Context, key client.ObjectKey, list project.IPAccessList) ([]project.IPAccessList, error) {
+	configMap := corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, key, &configMap); err != nil {
+		if apiErrors.IsNotFound(err) {
+			return nil, fmt.Errorf("ConfigMap %s referenced by the IP Access List doesn't exist", key)
+		}
+		return nil, fmt.Errorf("failed to read the ConfigMap %s referenced by the IP Access List: %w", key, err)
+	}
+	return ipAccessListFromConfigMap(&configMap, list)
+}
+
+func ipAccessListFromConfigMap(configMap *corev1.ConfigMap, list project.IPAccessList) ([]project.IPAccessList, error) {
+	keys := make([]string, 0, len(configMap.Data))
+	for k := range configMap.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var result []project.IPAccessList
+	for _, k := range keys {
+		addresses := strings.FieldsFunc(configMap.Data[k], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+		})
+		for _, address := range addresses {
+			if !isValidAddress(address) {
+				return nil, fmt.Errorf("ConfigMap %s contains the invalid IP address or CIDR block %q in %q", kube.ObjectKeyFromObject(configMap), address, k)
+			}
+			result = append(result, sourceIPAccessList(list, address, fmt.Sprintf("ConfigMap %s/%s", configMap.Name, k)))
+		}
+	}
+	return result, nil
+}
+
+func isValidAddress(address string) bool {
+	if strings.Contains(address, "/") {
+		_, _, err := net.ParseCIDR(address)
+		return err == nil
+	}
+	return net.ParseIP(address) != nil
+}
+
+// sourceIPAccessList creates the IP Access List entry for the address read from the source of the 'list'. The comment
+// identifies the origin of the address, the user comment (if any) is prepended to it.
+func sourceIPAccessList(list project.IPAccessList, address, origin string) project.IPAccessList {
+	result := project.NewIPAccessList().WithDeleteAfterDate(list.DeleteAfterDate)
+	if strings.Contains(address, "/") {
+		result = result.WithCIDR(address)
+	} else {
+		result = result.WithIP(address)
+	}
+	if list.Comment != "" {
+		return result.WithComment(fmt.Sprintf("%s (%s)", list.Comment, origin))
+	}
+	return result.WithComment(origin)
+}
+
+// uniqueIPAccessLists removes the duplicated entries (e.g. if the same address is read from several sources) keeping
+// the first one.
+func uniqueIPAccessLists(lists []project.IPAccessList) []project.IPAccessList {
+	seen := map[string]bool{}
+	var result []project.IPAccessList
+	for _, l := range lists {
+		id := l.Identifier().(string)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, l)
+	}
+	return result
+}
+
+func nodeExternalIPs(node *corev1.Node) []string {
+	var result []string
+	for _, address := range node.Status.Addresses {
+		if address.Type == corev1.NodeExternalIP {
+			result = append(result, address.Address)
+		}
+	}
+	return result
+}
+
+// hasNodeSource returns true if any of the IP Access List entries of the project uses the node selector source.
+func hasNodeSource(atlasProject *mdbv1.AtlasProject) bool {
+	for _, list := range atlasProject.Spec.ProjectIPAccessList {
+		if list.Source != nil && list.Source.NodeSelector != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// projectsWithNodeSources maps the Node event to the AtlasProjects using the node selector source in their IP Access
+// Lists. All such projects are reconciled as the Node may have stopped matching the selector.
+func (r *AtlasProjectReconciler) projectsWithNodeSources(client.Object) []reconcile.Request {
+	projects := mdbv1.AtlasProjectList{}
+	if err := r.Client.List(context.Background(), &projects); err != nil {
+		r.Log.Errorf("failed to list the AtlasProjects for the Node event: %v", err)
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range projects.Items {
+		if hasNodeSource(&projects.Items[i]) {
+			requests = append(requests, reconcile.Request{NamespacedName: kube.ObjectKeyFromObject(&projects.Items[i])})
+		}
+	}
+	return requests
+}
+
+// nodeAddressesPredicate filters out the Node updates which change neither the labels nor the external IP addresses
+// (e.g. the periodic status updates).
+func nodeAddressesPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, okOld := e.ObjectOld.(*corev1.Node)
+			newNode, okNew := e.ObjectNew.(*corev1.Node)
+			if !okOld || !okNew {
+				return false
+			}
+			return !reflect.DeepEqual(oldNode.Labels, newNode.Labels) || !reflect.DeepEqual(nodeExternalIPs(oldNode), nodeExternalIPs(newNode))
+		},
+	}
+}
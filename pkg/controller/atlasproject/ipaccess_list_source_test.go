@@ -0,0 +1,135 @@
+package atlasproject
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+)
+
+func testNode(name, pool string, addresses ...corev1.NodeAddress) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"pool": pool}},
+		Status:     corev1.NodeStatus{Addresses: addresses},
+	}
+}
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, mdbv1.AddToScheme(scheme))
+	return scheme
+}
+
+func TestResolveIPAccessList(t *testing.T) {
+	expiredRequest := mdbv1.NewAccessRequest("ns", "expired", "my-project", "192.0.2.20", time.Hour).WithRole("read", "test", "")
+	expiredRequest.Status.Expired = true
+	objects := []client.Object{
+		testNode("node-1", "egress",
+			corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+			corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "203.0.113.1"}),
+		testNode("node-2", "egress", corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "203.0.113.2"}),
+		testNode("node-3", "default", corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "203.0.113.3"}),
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "nat-ips", Namespace: "ns"},
+			Data:       map[string]string{"gateway-a": "198.51.100.1, 198.51.100.2", "gateway-b": "198.51.100.0/24\n203.0.113.2"},
+		},
+		accessRequest("ns", "debugging", "192.0.2.10"),
+		accessRequest("other", "other-project", "192.0.2.30"),
+		expiredRequest,
+	}
+	reconciler := &AtlasProjectReconciler{
+		Client:     fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(objects...).Build(),
+		WatchNodes: true,
+	}
+	nodeSource := &project.IPAccessListSource{NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "egress"}}}
+
+	t.Run("Nodes and ConfigMap", func(t *testing.T) {
+		atlasProject := mdbv1.NewProject("ns", "my-project", "Test Project").WithIPAccessList(project.NewIPAccessList().WithIP("192.0.2.1"))
+		atlasProject.Spec.ProjectIPAccessList = append(atlasProject.Spec.ProjectIPAccessList,
+			project.IPAccessList{Comment: "Egress", Source: nodeSource},
+			project.IPAccessList{DeleteAfterDate: "2030-01-02T15:04:05", Source: &project.IPAccessListSource{ConfigMap: "nat-ips"}},
+		)
+
+		lists, configMaps, result := reconciler.resolveIPAccessList(context.Background(), atlasProject)
+
+		require.True(t, result.IsOk())
+		assert.Equal(t, []client.ObjectKey{{Namespace: "ns", Name: "nat-ips"}}, configMaps)
+		assert.Equal(t, []project.IPAccessList{
+			project.NewIPAccessList().WithIP("192.0.2.1"),
+			project.NewIPAccessList().WithIP("203.0.113.1").WithComment("Egress (node node-1)"),
+			project.NewIPAccessList().WithIP("203.0.113.2").WithComment("Egress (node node-2)"),
+			project.NewIPAccessList().WithIP("198.51.100.1").WithComment("ConfigMap nat-ips/gateway-a").WithDeleteAfterDate("2030-01-02T15:04:05"),
+			project.NewIPAccessList().WithIP("198.51.100.2").WithComment("ConfigMap nat-ips/gateway-a").WithDeleteAfterDate("2030-01-02T15:04:05"),
+			project.NewIPAccessList().WithCIDR("198.51.100.0/24").WithComment("ConfigMap nat-ips/gateway-b").WithDeleteAfterDate("2030-01-02T15:04:05"),
+			project.NewIPAccessList().WithIP("192.0.2.10").WithComment("Access request ns/debugging").WithDeleteAfterDate("2030-01-02T16:04:05Z"),
+		}, lists)
+	})
+	t.Run("ConfigMap doesn't exist", func(t *testing.T) {
+		atlasProject := mdbv1.NewProject("other", "my-project", "Test Project").
+			WithIPAccessList(project.IPAccessList{Source: &project.IPAccessListSource{ConfigMap: "nat-ips"}})
+
+		_, configMaps, result := reconciler.resolveIPAccessList(context.Background(), atlasProject)
+
+		assert.False(t, result.IsOk())
+		// the ConfigMap is watched anyway so that the project is reconciled once it's created
+		assert.Equal(t, []client.ObjectKey{{Namespace: "other", Name: "nat-ips"}}, configMaps)
+	})
+	t.Run("Nodes are not watched", func(t *testing.T) {
+		atlasProject := mdbv1.NewProject("ns", "my-project", "Test Project").WithIPAccessList(project.IPAccessList{Source: nodeSource})
+
+		_, _, result := (&AtlasProjectReconciler{Client: reconciler.Client}).resolveIPAccessList(context.Background(), atlasProject)
+
+		assert.False(t, result.IsOk())
+	})
+}
+
+// accessRequest returns the request for 'my-project' in the "ns" namespace, expiring at 2030-01-02T16:04:05Z
+func accessRequest(namespace, name, requesterIP string) *mdbv1.AtlasAccessRequest {
+	request := mdbv1.NewAccessRequest(namespace, name, "my-project", requesterIP, time.Hour).WithRole("read", "test", "")
+	request.Spec.Project.Namespace = "ns"
+	request.CreationTimestamp = metav1.NewTime(time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC))
+	request.UID = types.UID(name)
+	if namespace == "other" {
+		request.Spec.Project.Name = "other-project"
+	}
+	return request
+}
+
+func TestIPAccessListFromConfigMap(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "nat-ips", Namespace: "ns"},
+		Data:       map[string]string{"gateway": "198.51.100.1 not-an-ip"},
+	}
+	_, err := ipAccessListFromConfigMap(configMap, project.IPAccessList{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not-an-ip")
+}
+
+func TestNodeAddressesPredicate(t *testing.T) {
+	node := testNode("node-1", "egress", corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "203.0.113.1"})
+
+	heartbeat := node.DeepCopy()
+	heartbeat.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}
+	assert.False(t, nodeAddressesPredicate().Update(event.UpdateEvent{ObjectOld: node, ObjectNew: heartbeat}))
+
+	relabeled := node.DeepCopy()
+	relabeled.Labels["pool"] = "default"
+	assert.True(t, nodeAddressesPredicate().Update(event.UpdateEvent{ObjectOld: node, ObjectNew: relabeled}))
+
+	readdressed := node.DeepCopy()
+	readdressed.Status.Addresses[0].Address = "203.0.113.9"
+	assert.True(t, nodeAddressesPredicate().Update(event.UpdateEvent{ObjectOld: node, ObjectNew: readdressed}))
+}
@@ -5,44 +5,13 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas/mongodbatlas"
 
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/set"
 )
 
-func TestValidateSingleIPAccessList(t *testing.T) {
-	testCases := []struct {
-		in                 project.IPAccessList
-		errorExpectedRegex string
-	}{
-		// Date
-		{in: project.IPAccessList{DeleteAfterDate: "incorrect", IPAddress: "192.158.0.0"}, errorExpectedRegex: "cannot parse"},
-		{in: project.IPAccessList{DeleteAfterDate: "2020/01/02T15:04:05-0700", IPAddress: "192.158.0.0"}, errorExpectedRegex: "cannot parse"},
-		{in: project.IPAccessList{DeleteAfterDate: "2020-01-02T15:04:05-07000", IPAddress: "192.158.0.0"}, errorExpectedRegex: "cannot parse"},
-		{in: project.IPAccessList{DeleteAfterDate: "2020-11-02T20:04:05-0700", IPAddress: "192.158.0.0"}},
-		{in: project.IPAccessList{DeleteAfterDate: "2020-11-02T20:04:05+03", IPAddress: "192.158.0.0"}},
-		{in: project.IPAccessList{DeleteAfterDate: "2011-01-02T15:04:05", IPAddress: "192.158.0.0"}},
-
-		// Only one other field is allowed (and required)
-		{in: project.IPAccessList{DeleteAfterDate: "2011-01-02T15:04:05", IPAddress: "192.158.0.0", CIDRBlock: "203.0.113.0/24"}, errorExpectedRegex: "only one of the "},
-		{in: project.IPAccessList{IPAddress: "192.158.0.0", AwsSecurityGroup: "sg-0026348ec11780bd1"}, errorExpectedRegex: "only one of the "},
-		{in: project.IPAccessList{CIDRBlock: "203.0.113.0/24", AwsSecurityGroup: "sg-0026348ec11780bd1"}, errorExpectedRegex: "only one of the "},
-		{in: project.IPAccessList{CIDRBlock: "203.0.113.0/24", AwsSecurityGroup: "sg-0026348ec11780bd1", IPAddress: "192.158.0.0"}, errorExpectedRegex: "only one of the "},
-		{in: project.IPAccessList{}, errorExpectedRegex: "only one of the "},
-	}
-
-	for _, testCase := range testCases {
-		t.Run("", func(t *testing.T) {
-			err := validateSingleIPAccessList(testCase.in)
-			if testCase.errorExpectedRegex != "" {
-				assert.Error(t, err)
-				assert.Regexp(t, testCase.errorExpectedRegex, err.Error())
-			} else {
-				assert.NoError(t, err)
-			}
-		})
-	}
-}
-
 func TestFilterActiveIPAccessLists(t *testing.T) {
 	t.Run("One expired, one active", func(t *testing.T) {
 		dateBefore := time.Now().UTC().Add(time.Hour * -1).Format("2006-01-02T15:04:05.999Z")
@@ -63,3 +32,39 @@ func TestFilterActiveIPAccessLists(t *testing.T) {
 		assert.Empty(t, expired)
 	})
 }
+
+func TestIPAccessListsToDelete(t *testing.T) {
+	atlasAccessLists := []mongodbatlas.ProjectIPAccessList{
+		{IPAddress: "10.0.0.1", CIDRBlock: "10.0.0.1/32"},
+		{CIDRBlock: "192.168.0.0/24"},
+		{AwsSecurityGroup: "sg-0123456789"},
+	}
+	spec := []project.IPAccessList{{IPAddress: "10.0.0.1"}}
+
+	t.Run("Exclusive policy", func(t *testing.T) {
+		p := mdbv1.DefaultProject("ns", "secret").WithIPAccessList(spec[0])
+
+		toDelete := ipAccessListsToDelete(atlasAccessLists, spec, deletableIPAccessLists(p))
+		assert.Len(t, toDelete, 2)
+		assert.Equal(t, "192.168.0.0/24", toDelete[0].Identifier())
+		assert.Equal(t, "sg-0123456789", toDelete[1].Identifier())
+	})
+	t.Run("Merge policy", func(t *testing.T) {
+		p := mdbv1.DefaultProject("ns", "secret").WithIPAccessList(spec[0])
+		p.Spec.IPAccessListPolicy = mdbv1.ListPolicyMerge
+		p.Status.IPAccessListEntries = []string{"10.0.0.1", "sg-0123456789"}
+
+		toDelete := ipAccessListsToDelete(atlasAccessLists, spec, deletableIPAccessLists(p))
+		assert.Len(t, toDelete, 1)
+		assert.Equal(t, "sg-0123456789", toDelete[0].Identifier())
+	})
+}
+
+func TestCreatedIPAccessListEntries(t *testing.T) {
+	spec := []project.IPAccessList{{IPAddress: "10.0.0.1"}, {CIDRBlock: "192.168.0.0/24"}, {AwsSecurityGroup: "sg-0123456789"}}
+	created := []set.Identifiable{spec[2]}
+
+	// "192.168.0.0/24" existed in Atlas before, "10.0.0.2" is not specified anymore
+	assert.Equal(t, []string{"10.0.0.1", "sg-0123456789"}, createdIPAccessListEntries([]string{"10.0.0.1", "10.0.0.2"}, spec, created))
+	assert.Empty(t, createdIPAccessListEntries(nil, spec, nil))
+}
@@ -2,6 +2,7 @@ package atlasproject
 
 import (
 	"context"
+	"fmt"
 
 	"go.mongodb.org/atlas/mongodbatlas"
 	"go.uber.org/zap"
@@ -15,10 +16,11 @@ import (
 )
 
 func (r *AtlasProjectReconciler) ensurePrivateEndpoint(ctx *workflow.Context, projectID string, project *mdbv1.AtlasProject) workflow.Result {
+	defer ctx.StartSpan("ensurePrivateEndpoint")()
 	specPEs := project.Spec.DeepCopy().PrivateEndpoints
 	statusPEs := project.Status.DeepCopy().PrivateEndpoints
 
-	result := createOrDeletePEInAtlas(ctx, projectID, specPEs, statusPEs)
+	result := createOrDeletePEInAtlas(ctx, projectID, specPEs, statusPEs, project.Spec.PrivateEndpointPolicy)
 	if !result.IsOk() {
 		return result
 	}
@@ -26,7 +28,10 @@ func (r *AtlasProjectReconciler) ensurePrivateEndpoint(ctx *workflow.Context, pr
 	return workflow.OK()
 }
 
-func createOrDeletePEInAtlas(ctx *workflow.Context, projectID string, specPEs []project.PrivateEndpoint, statusPEs []status.ProjectPrivateEndpoint) (result workflow.Result) {
+// createOrDeletePEInAtlas creates the Private Endpoints specified in the project CR and deletes the ones created by the
+// Operator (tracked in the status) but removed from the spec. The Private Endpoint Services not created by the Operator
+// are deleted too unless the 'merge' policy is used.
+func createOrDeletePEInAtlas(ctx *workflow.Context, projectID string, specPEs []project.PrivateEndpoint, statusPEs []status.ProjectPrivateEndpoint, policy mdbv1.ListPolicy) (result workflow.Result) {
 	log := ctx.Log
 
 	atlasPeConnections, err := syncPEConnections(ctx, projectID)
@@ -36,8 +41,14 @@ func createOrDeletePEInAtlas(ctx *workflow.Context, projectID string, specPEs []
 
 	log.Debugw("Updated PE Connections", "atlasPeConnections", atlasPeConnections, "statusPEs", statusPEs)
 
-	if result := clearOutNotLinkedPEs(ctx.Client, projectID, atlasPeConnections, statusPEs, log); !result.IsOk() {
-		return result
+	if ctx.PlanOnly {
+		return planPrivateEndpoints(ctx, atlasPeConnections, specPEs, statusPEs, policy)
+	}
+
+	if policy != mdbv1.ListPolicyMerge {
+		if result := clearOutNotLinkedPEs(ctx.Context, ctx.Client, projectID, atlasPeConnections, statusPEs, log); !result.IsOk() {
+			return result
+		}
 	}
 
 	endpointsToCreate := set.Difference(specPEs, statusPEs)
@@ -48,25 +59,63 @@ func createOrDeletePEInAtlas(ctx *workflow.Context, projectID string, specPEs []
 	log.Debugw("Items to update", "difference", endpointsToUpdate)
 	log.Debugw("Items to delete", "difference", endpointsToDelete)
 
-	if result := deletePrivateEndpointsFromAtlas(ctx.Client, projectID, endpointsToDelete, log); !result.IsOk() {
+	if result := deletePrivateEndpointsFromAtlas(ctx.Context, ctx.Client, projectID, endpointsToDelete, log); !result.IsOk() {
 		return result
 	}
 
-	newConnections, err := createPeServiceInAtlas(ctx.Client, projectID, endpointsToCreate)
+	newConnections, err := createPeServiceInAtlas(ctx.Context, ctx.Client, projectID, endpointsToCreate)
 	if err != nil {
 		log.Debugw("Failed to create PE Service in Atlas", "error", err)
 	}
 	ctx.EnsureStatusOption(status.AtlasProjectAddPrivateEnpointsOption(convertAllToStatus(newConnections)))
 
-	if err = createPrivateEndpointInAtlas(ctx.Client, projectID, endpointsToUpdate, log); err != nil {
+	if err = createPrivateEndpointInAtlas(ctx.Context, ctx.Client, projectID, endpointsToUpdate, log); err != nil {
 		log.Debugw("Failed to create PE Interface in Atlas", "error", err)
 	}
 
 	return getStatusForInterfaceConnections(ctx, projectID)
 }
 
+// planPrivateEndpoints reports the changes createOrDeletePEInAtlas would make in Atlas
+func planPrivateEndpoints(ctx *workflow.Context, atlasConns []mongodbatlas.PrivateEndpointConnection, specPEs []project.PrivateEndpoint, statusPEs []status.ProjectPrivateEndpoint, policy mdbv1.ListPolicy) workflow.Result {
+	if policy != mdbv1.ListPolicyMerge {
+		statusIDs := map[string]bool{}
+		for _, statusPE := range statusPEs {
+			statusIDs[statusPE.ID] = true
+		}
+		for _, atlasConn := range atlasConns {
+			if !statusIDs[atlasConn.ID] && !isDeleting(atlasConn.Status) {
+				ctx.PlanChange(workflow.PlanDelete, privateEndpointResource(convertOneToStatus(atlasConn)), "")
+			}
+		}
+	}
+
+	for _, item := range set.Difference(statusPEs, specPEs) {
+		ctx.PlanChange(workflow.PlanDelete, privateEndpointResource(item.(status.ProjectPrivateEndpoint)), "")
+	}
+
+	for _, item := range set.Difference(specPEs, statusPEs) {
+		pe := item.(project.PrivateEndpoint)
+		ctx.PlanChange(workflow.PlanCreate, fmt.Sprintf("private endpoint service %s %s", pe.Provider, pe.Region), "")
+	}
+
+	for _, pair := range set.Intersection(specPEs, statusPEs) {
+		operatorPeService := pair[0].(project.PrivateEndpoint)
+		statusPeService := pair[1].(status.ProjectPrivateEndpoint)
+		if operatorPeService.ID != "" && statusPeService.InterfaceEndpointID == "" {
+			ctx.PlanChange(workflow.PlanCreate, fmt.Sprintf("interface endpoint %s of %s", operatorPeService.ID, privateEndpointResource(statusPeService)), "")
+		}
+	}
+
+	return workflow.OK()
+}
+
+func privateEndpointResource(pe status.ProjectPrivateEndpoint) string {
+	return fmt.Sprintf("private endpoint service %s %s (%s)", pe.Provider, pe.Region, pe.ID)
+}
+
 func getStatusForInterfaceConnections(ctx *workflow.Context, projectID string) workflow.Result {
-	atlasPeConnections, err := getAllPrivateEndpoints(ctx.Client, projectID)
+	atlasPeConnections, err := getAllPrivateEndpoints(ctx.Context, ctx.Client, projectID)
 	if err != nil {
 		return workflow.Terminate(workflow.Internal, err.Error())
 	}
@@ -86,7 +135,7 @@ func getStatusForInterfaceConnections(ctx *workflow.Context, projectID string) w
 			continue
 		}
 
-		interfaceEndpoint, _, err := ctx.Client.PrivateEndpoints.GetOnePrivateEndpoint(context.Background(), projectID, string(statusPeService.Provider), statusPeService.ID, statusPeService.InterfaceEndpointID)
+		interfaceEndpoint, _, err := ctx.Client.PrivateEndpoints.GetOnePrivateEndpoint(ctx.Context, projectID, string(statusPeService.Provider), statusPeService.ID, statusPeService.InterfaceEndpointID)
 		if err != nil {
 			return workflow.Terminate(workflow.Internal, err.Error())
 		}
@@ -115,7 +164,7 @@ func allEnpointsAreAvailable(atlasPeConnections []mongodbatlas.PrivateEndpointCo
 }
 
 func syncPEConnections(ctx *workflow.Context, projectID string) ([]mongodbatlas.PrivateEndpointConnection, error) {
-	atlasPeConnections, err := getAllPrivateEndpoints(ctx.Client, projectID)
+	atlasPeConnections, err := getAllPrivateEndpoints(ctx.Context, ctx.Client, projectID)
 	if err != nil {
 		return nil, err
 	}
@@ -125,10 +174,10 @@ func syncPEConnections(ctx *workflow.Context, projectID string) ([]mongodbatlas.
 	return atlasPeConnections, nil
 }
 
-func getAllPrivateEndpoints(client mongodbatlas.Client, projectID string) (result []mongodbatlas.PrivateEndpointConnection, err error) {
+func getAllPrivateEndpoints(ctx context.Context, client mongodbatlas.Client, projectID string) (result []mongodbatlas.PrivateEndpointConnection, err error) {
 	providers := []string{"AWS", "AZURE"}
 	for _, provider := range providers {
-		atlasPeConnections, _, err := client.PrivateEndpoints.List(context.Background(), projectID, provider, &mongodbatlas.ListOptions{})
+		atlasPeConnections, _, err := client.PrivateEndpoints.List(ctx, projectID, provider, &mongodbatlas.ListOptions{})
 		if err != nil {
 			return nil, err
 		}
@@ -143,12 +192,12 @@ func getAllPrivateEndpoints(client mongodbatlas.Client, projectID string) (resul
 	return
 }
 
-func createPeServiceInAtlas(client mongodbatlas.Client, projectID string, endpointsToCreate []set.Identifiable) ([]mongodbatlas.PrivateEndpointConnection, error) {
+func createPeServiceInAtlas(ctx context.Context, client mongodbatlas.Client, projectID string, endpointsToCreate []set.Identifiable) ([]mongodbatlas.PrivateEndpointConnection, error) {
 	newConnections := []mongodbatlas.PrivateEndpointConnection{}
 	for _, item := range endpointsToCreate {
 		pe := item.(project.PrivateEndpoint)
 
-		conn, _, err := client.PrivateEndpoints.Create(context.Background(), projectID, &mongodbatlas.PrivateEndpointConnection{
+		conn, _, err := client.PrivateEndpoints.Create(ctx, projectID, &mongodbatlas.PrivateEndpointConnection{
 			ProviderName: string(pe.Provider),
 			Region:       pe.Region,
 		})
@@ -164,13 +213,13 @@ func createPeServiceInAtlas(client mongodbatlas.Client, projectID string, endpoi
 	return newConnections, nil
 }
 
-func createPrivateEndpointInAtlas(client mongodbatlas.Client, projectID string, endpointsToUpdate [][]set.Identifiable, log *zap.SugaredLogger) error {
+func createPrivateEndpointInAtlas(ctx context.Context, client mongodbatlas.Client, projectID string, endpointsToUpdate [][]set.Identifiable, log *zap.SugaredLogger) error {
 	for _, pair := range endpointsToUpdate {
 		operatorPeService := pair[0].(project.PrivateEndpoint)
 		statusPeService := pair[1].(status.ProjectPrivateEndpoint)
 
 		if operatorPeService.ID != "" && statusPeService.InterfaceEndpointID == "" {
-			interfaceConn, _, err := client.PrivateEndpoints.AddOnePrivateEndpoint(context.Background(), projectID, string(operatorPeService.Provider), statusPeService.ID, &mongodbatlas.InterfaceEndpointConnection{
+			interfaceConn, _, err := client.PrivateEndpoints.AddOnePrivateEndpoint(ctx, projectID, string(operatorPeService.Provider), statusPeService.ID, &mongodbatlas.InterfaceEndpointConnection{
 				ID:                       operatorPeService.ID,
 				PrivateEndpointIPAddress: operatorPeService.IP,
 			})
@@ -185,17 +234,17 @@ func createPrivateEndpointInAtlas(client mongodbatlas.Client, projectID string,
 }
 
 func DeleteAllPrivateEndpoints(ctx *workflow.Context, client mongodbatlas.Client, projectID string, statusPE []status.ProjectPrivateEndpoint, log *zap.SugaredLogger) workflow.Result {
-	atlasPeConnections, err := getAllPrivateEndpoints(ctx.Client, projectID)
+	atlasPeConnections, err := getAllPrivateEndpoints(ctx.Context, ctx.Client, projectID)
 	if err != nil {
 		return workflow.Terminate(workflow.Internal, err.Error())
 	}
 
 	endpointsToDelete := set.Difference(convertAllToStatus(atlasPeConnections), []status.ProjectPrivateEndpoint{})
 	log.Debugw("List of endpoints to delete", "endpointsToDelete", endpointsToDelete)
-	return deletePrivateEndpointsFromAtlas(client, projectID, endpointsToDelete, log)
+	return deletePrivateEndpointsFromAtlas(ctx.Context, client, projectID, endpointsToDelete, log)
 }
 
-func clearOutNotLinkedPEs(client mongodbatlas.Client, projectID string, atlasConns []mongodbatlas.PrivateEndpointConnection, statusPEs []status.ProjectPrivateEndpoint, log *zap.SugaredLogger) workflow.Result {
+func clearOutNotLinkedPEs(ctx context.Context, client mongodbatlas.Client, projectID string, atlasConns []mongodbatlas.PrivateEndpointConnection, statusPEs []status.ProjectPrivateEndpoint, log *zap.SugaredLogger) workflow.Result {
 	endpointsWithoutPair := []status.ProjectPrivateEndpoint{}
 	endpointsAreDeleting := false
 	for _, atlasConn := range atlasConns {
@@ -218,7 +267,7 @@ func clearOutNotLinkedPEs(client mongodbatlas.Client, projectID string, atlasCon
 
 	endpointsToDelete := set.Difference(endpointsWithoutPair, []status.ProjectPrivateEndpoint{})
 	log.Debugw("Outdated endpoints to delete", "endpointsToDelete", endpointsToDelete)
-	result := deletePrivateEndpointsFromAtlas(client, projectID, endpointsToDelete, log)
+	result := deletePrivateEndpointsFromAtlas(ctx, client, projectID, endpointsToDelete, log)
 
 	if endpointsAreDeleting {
 		return workflow.InProgress(workflow.ProjectPEServiceIsNotReadyInAtlas, "Endpoints are being deleted")
@@ -227,20 +276,20 @@ func clearOutNotLinkedPEs(client mongodbatlas.Client, projectID string, atlasCon
 	return result
 }
 
-func deletePrivateEndpointsFromAtlas(client mongodbatlas.Client, projectID string, listsToRemove []set.Identifiable, log *zap.SugaredLogger) workflow.Result {
+func deletePrivateEndpointsFromAtlas(ctx context.Context, client mongodbatlas.Client, projectID string, listsToRemove []set.Identifiable, log *zap.SugaredLogger) workflow.Result {
 	result := workflow.OK()
 	for _, item := range listsToRemove {
 		peService := item.(status.ProjectPrivateEndpoint)
 		provider := string(peService.Provider)
 		if peService.InterfaceEndpointID != "" {
-			if _, err := client.PrivateEndpoints.DeleteOnePrivateEndpoint(context.Background(), projectID, provider, peService.ID, peService.InterfaceEndpointID); err != nil {
+			if _, err := client.PrivateEndpoints.DeleteOnePrivateEndpoint(ctx, projectID, provider, peService.ID, peService.InterfaceEndpointID); err != nil {
 				return workflow.Terminate(workflow.ProjectPrivateEndpointIsNotReadyInAtlas, "failed to delete Private Endpoint")
 			}
 
 			return workflow.InProgress(workflow.ProjectPEServiceIsNotReadyInAtlas, "Private Endpoint is deleting")
 		}
 
-		if _, err := client.PrivateEndpoints.Delete(context.Background(), projectID, provider, peService.ID); err != nil {
+		if _, err := client.PrivateEndpoints.Delete(ctx, projectID, provider, peService.ID); err != nil {
 			return workflow.Terminate(workflow.ProjectPEServiceIsNotReadyInAtlas, "failed to delete Private Endpoint Service")
 		}
 		log.Debugw("Removed Private Endpoint Service from Atlas as it's not specified in current AtlasProject", "id", item.Identifier())
@@ -3,29 +3,46 @@ package atlasproject
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
 
 	"go.mongodb.org/atlas/mongodbatlas"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 )
 
+const projectPath = "api/atlas/v1.0/groups/%s"
+
 // ensureProjectExists creates the project if it doesn't exist yet. Returns the project ID
 func (r *AtlasProjectReconciler) ensureProjectExists(ctx *workflow.Context, project *mdbv1.AtlasProject) (string, workflow.Result) {
+	defer ctx.StartSpan("ensureProjectExists")()
+	if project.Spec.ProjectID != "" {
+		return ensureProjectBoundByID(ctx, project)
+	}
+
 	// Try to find the project
-	p, _, err := ctx.Client.Projects.GetOneProjectByName(context.Background(), project.Spec.Name)
+	p, _, err := ctx.Client.Projects.GetOneProjectByName(ctx.Context, project.Spec.Name)
 	if err != nil {
 		ctx.Log.Infow("Error", "err", err.Error())
-		var apiError *mongodbatlas.ErrorResponse
-		if errors.As(err, &apiError) && (apiError.ErrorCode == atlas.NotInGroup || apiError.ErrorCode == atlas.ResourceNotFound) {
+		if isProjectNotFound(err) {
+			if customresource.ResourceMustExistInAtlas(project) {
+				return "", workflow.Terminate(workflow.ProjectNotFoundInAtlas,
+					fmt.Sprintf("project %q doesn't exist in Atlas and the adoption policy doesn't allow to create it", project.Spec.Name))
+			}
 			// Project doesn't exist? Try to create it
 			p = &mongodbatlas.Project{
 				OrgID:                     ctx.Connection.OrgID,
 				Name:                      project.Spec.Name,
 				WithDefaultAlertsSettings: &project.Spec.WithDefaultAlertsSettings,
 			}
-			if p, _, err = ctx.Client.Projects.Create(context.Background(), p, &mongodbatlas.CreateProjectOptions{}); err != nil {
+			if ctx.PlanOnly {
+				ctx.PlanChange(workflow.PlanCreate, "project "+project.Spec.Name, "")
+				return "", workflow.Planned("the project is not created in the plan mode")
+			}
+			if p, _, err = ctx.Client.Projects.Create(ctx.Context, p, &mongodbatlas.CreateProjectOptions{}); err != nil {
 				return "", workflow.Terminate(workflow.ProjectNotCreatedInAtlas, err.Error())
 			}
 			ctx.Log.Infow("Created Atlas Project", "name", project.Spec.Name, "id", p.ID)
@@ -40,3 +57,42 @@ func (r *AtlasProjectReconciler) ensureProjectExists(ctx *workflow.Context, proj
 	}
 	return p.ID, workflow.OK()
 }
+
+// ensureProjectBoundByID finds the existing project by 'spec.projectId' and renames it if the name in Atlas differs
+// from 'spec.name'. The project is never created.
+func ensureProjectBoundByID(ctx *workflow.Context, project *mdbv1.AtlasProject) (string, workflow.Result) {
+	p, _, err := ctx.Client.Projects.GetOneProject(ctx.Context, project.Spec.ProjectID)
+	if err != nil {
+		if isProjectNotFound(err) {
+			return "", workflow.Terminate(workflow.ProjectNotFoundInAtlas, fmt.Sprintf("project with ID %s doesn't exist in Atlas", project.Spec.ProjectID))
+		}
+		return "", workflow.Terminate(workflow.ProjectNotFoundInAtlas, err.Error())
+	}
+
+	if p.Name != project.Spec.Name {
+		if ctx.PlanOnly {
+			ctx.PlanChange(workflow.PlanUpdate, "project "+p.ID, fmt.Sprintf("name: %q -> %q", p.Name, project.Spec.Name))
+			return p.ID, workflow.OK()
+		}
+		if _, err = renameProject(ctx.Context, ctx.Client, p.ID, project.Spec.Name); err != nil {
+			return "", workflow.Terminate(workflow.ProjectNotRenamedInAtlas, err.Error())
+		}
+		ctx.Log.Infow("Renamed Atlas Project", "id", p.ID, "oldName", p.Name, "name", project.Spec.Name)
+	}
+	return p.ID, workflow.OK()
+}
+
+// renameProject changes the name of the Atlas project. The request is made directly as the Atlas client doesn't
+// allow to update the project.
+func renameProject(ctx context.Context, client mongodbatlas.Client, projectID, name string) (*mongodbatlas.Response, error) {
+	req, err := client.NewRequest(ctx, http.MethodPatch, fmt.Sprintf(projectPath, projectID), map[string]string{"name": name})
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(ctx, req, &mongodbatlas.Project{})
+}
+
+func isProjectNotFound(err error) bool {
+	var apiError *mongodbatlas.ErrorResponse
+	return errors.As(err, &apiError) && (apiError.ErrorCode == atlas.NotInGroup || apiError.ErrorCode == atlas.ResourceNotFound)
+}
@@ -0,0 +1,117 @@
+package atlasproject
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+const testProjectID = "5f5f5f5f5f5f5f5f5f5f5f5f"
+
+// atlasProjectsServer serves the single Atlas project 'Test Project' and records the name it's renamed to
+func atlasProjectsServer(t *testing.T, renamedTo *string) *httptest.Server {
+	notFound := func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errorCode": "RESOURCE_NOT_FOUND", "error": 404}`))
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/atlas/v1.0/groups/"+testProjectID,
+			r.Method == http.MethodGet && r.URL.Path == "/api/atlas/v1.0/groups/byName/Test Project":
+			_, _ = w.Write([]byte(`{"id": "` + testProjectID + `", "name": "Test Project"}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/atlas/v1.0/groups/"+testProjectID:
+			body := map[string]string{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			*renamedTo = body["name"]
+			_, _ = w.Write([]byte(`{"id": "` + testProjectID + `", "name": "` + body["name"] + `"}`))
+		case r.Method == http.MethodGet:
+			notFound(w)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+}
+
+func testContext(t *testing.T, server *httptest.Server) *workflow.Context {
+	client, err := mongodbatlas.New(server.Client(), mongodbatlas.SetBaseURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	ctx := workflow.NewContext(zap.S(), nil)
+	ctx.Client = *client
+	return ctx
+}
+
+func TestEnsureProjectExists(t *testing.T) {
+	reconciler := &AtlasProjectReconciler{}
+
+	t.Run("Project bound by ID is renamed", func(t *testing.T) {
+		renamedTo := ""
+		server := atlasProjectsServer(t, &renamedTo)
+		defer server.Close()
+
+		project := mdbv1.NewProject("default", "my-project", "Renamed Project").WithProjectID(testProjectID)
+		projectID, result := reconciler.ensureProjectExists(testContext(t, server), project)
+
+		assert.True(t, result.IsOk())
+		assert.Equal(t, testProjectID, projectID)
+		assert.Equal(t, "Renamed Project", renamedTo)
+	})
+	t.Run("Rename is only planned in the plan mode", func(t *testing.T) {
+		renamedTo := ""
+		server := atlasProjectsServer(t, &renamedTo)
+		defer server.Close()
+
+		ctx := testContext(t, server)
+		ctx.PlanOnly = true
+		project := mdbv1.NewProject("default", "my-project", "Renamed Project").WithProjectID(testProjectID)
+		projectID, result := reconciler.ensureProjectExists(ctx, project)
+
+		assert.True(t, result.IsOk())
+		assert.Equal(t, testProjectID, projectID)
+		assert.Empty(t, renamedTo)
+		require.Len(t, ctx.PlannedChanges(), 1)
+		assert.Equal(t, workflow.PlanUpdate, ctx.PlannedChanges()[0].Action)
+	})
+	t.Run("Project bound by ID doesn't exist", func(t *testing.T) {
+		server := atlasProjectsServer(t, nil)
+		defer server.Close()
+
+		project := mdbv1.NewProject("default", "my-project", "Test Project").WithProjectID("6a6a6a6a6a6a6a6a6a6a6a6a")
+		_, result := reconciler.ensureProjectExists(testContext(t, server), project)
+
+		assert.False(t, result.IsOk())
+	})
+	t.Run("Project found by name", func(t *testing.T) {
+		server := atlasProjectsServer(t, nil)
+		defer server.Close()
+
+		project := mdbv1.NewProject("default", "my-project", "Test Project")
+		project.SetAnnotations(map[string]string{customresource.AdoptionPolicyAnnotation: customresource.AdoptionPolicyExistingOnly})
+		projectID, result := reconciler.ensureProjectExists(testContext(t, server), project)
+
+		assert.True(t, result.IsOk())
+		assert.Equal(t, testProjectID, projectID)
+	})
+	t.Run("Adoption policy doesn't allow to create the project", func(t *testing.T) {
+		server := atlasProjectsServer(t, nil)
+		defer server.Close()
+
+		project := mdbv1.NewProject("default", "my-project", "Tset Project")
+		project.SetAnnotations(map[string]string{customresource.AdoptionPolicyAnnotation: customresource.AdoptionPolicyExistingOnly})
+		_, result := reconciler.ensureProjectExists(testContext(t, server), project)
+
+		assert.False(t, result.IsOk())
+	})
+}
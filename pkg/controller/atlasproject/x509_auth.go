@@ -18,6 +18,7 @@ import (
 )
 
 func (r *AtlasProjectReconciler) ensureX509(ctx *workflow.Context, projectID string, project *mdbv1.AtlasProject) (authmode.AuthModes, workflow.Result) {
+	defer ctx.StartSpan("ensureX509")()
 	log := ctx.Log
 
 	var specCert string
@@ -31,8 +32,12 @@ func (r *AtlasProjectReconciler) ensureX509(ctx *workflow.Context, projectID str
 	}
 
 	if authModes.CheckAuthMode(authmode.X509) && specCert == "" {
+		if ctx.PlanOnly {
+			ctx.PlanChange(workflow.PlanDelete, "x509 configuration of the project "+projectID, "")
+			return authModes, workflow.OK()
+		}
 		log.Infow("Disable x509 auth", "projectID", projectID)
-		_, err := ctx.Client.X509AuthDBUsers.DisableCustomerX509(context.Background(), projectID)
+		_, err := ctx.Client.X509AuthDBUsers.DisableCustomerX509(ctx.Context, projectID)
 		if err != nil {
 			return authModes, workflow.Terminate(workflow.Internal, err.Error())
 		}
@@ -40,7 +45,7 @@ func (r *AtlasProjectReconciler) ensureX509(ctx *workflow.Context, projectID str
 		return authModes, workflow.OK()
 	}
 
-	customer, _, err := ctx.Client.X509AuthDBUsers.GetCurrentX509Conf(context.Background(), projectID)
+	customer, _, err := ctx.Client.X509AuthDBUsers.GetCurrentX509Conf(ctx.Context, projectID)
 	if err != nil {
 		return authModes, workflow.Terminate(workflow.Internal, err.Error())
 	}
@@ -49,10 +54,14 @@ func (r *AtlasProjectReconciler) ensureX509(ctx *workflow.Context, projectID str
 		conf := mongodbatlas.CustomerX509{
 			Cas: specCert,
 		}
+		if ctx.PlanOnly {
+			ctx.PlanChange(workflow.PlanUpdate, "x509 configuration of the project "+projectID, "the CA certificate is changed")
+			return authModes, workflow.OK()
+		}
 		log.Infow("Saving new x509 cert", "projectID", projectID)
 		log.Debugw("New customer", "conf", conf)
 
-		_, _, err := ctx.Client.X509AuthDBUsers.SaveConfiguration(context.Background(), projectID, &conf)
+		_, _, err := ctx.Client.X509AuthDBUsers.SaveConfiguration(ctx.Context, projectID, &conf)
 		if err != nil {
 			return authModes, workflow.Terminate(workflow.Internal, err.Error())
 		}
@@ -29,6 +29,8 @@ const (
 
 type ConnectionData struct {
 	DBUserName, ConnURL, SrvConnURL, PvtConnURL, PvtSrvConnURL, Password string
+	// PrivateEndpointConnURLs are the connection strings specific to each private endpoint of the cluster
+	PrivateEndpointConnURLs []PrivateEndpointConnURLs
 }
 
 // Ensure creates or updates the connection Secret for the specific cluster and db user. Returns the name of the Secret
@@ -87,6 +89,19 @@ func fillSecret(secret *corev1.Secret, projectID string, clusterName string, dat
 		userNameKey:               []byte(data.DBUserName),
 		passwordKey:               []byte(data.Password),
 	}
+
+	for _, pe := range data.PrivateEndpointConnURLs {
+		if pe.ConnURL != "" {
+			if secret.Data[pe.secretKey(connectionSecretPvtKey)], err = addCredentialsToConnectionURLBytes(pe.ConnURL, data.DBUserName, data.Password); err != nil {
+				return err
+			}
+		}
+		if pe.SrvConnURL != "" {
+			if secret.Data[pe.secretKey(connectionSecretPvtSrvKey)], err = addCredentialsToConnectionURLBytes(pe.SrvConnURL, data.DBUserName, data.Password); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -106,3 +121,11 @@ func AddCredentialsToConnectionURL(connURL, userName, password string) (string,
 	cs.User = url.UserPassword(userName, password)
 	return cs.String(), nil
 }
+
+func addCredentialsToConnectionURLBytes(connURL, userName, password string) ([]byte, error) {
+	result, err := AddCredentialsToConnectionURL(connURL, userName, password)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(result), nil
+}
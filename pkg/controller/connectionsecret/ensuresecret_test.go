@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas/mongodbatlas"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -77,6 +78,62 @@ func TestEnsure(t *testing.T) {
 	})
 }
 
+func TestEnsurePrivateEndpointConnURLs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	data := dataForSecret()
+	data.PrivateEndpointConnURLs = []PrivateEndpointConnURLs{
+		{
+			EndpointID: "vpce-0a1b2c3d",
+			Region:     "US_EAST_1",
+			ConnURL:    "mongodb://pl-0-us-east-1.example.com:1024/?authSource=admin",
+			SrvConnURL: "mongodb+srv://cluster0-pl-0.example.com/?authSource=admin",
+		},
+		{
+			EndpointID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/privateEndpoints/pe1",
+			SrvConnURL: "mongodb+srv://cluster0-pl-1.example.com/?authSource=admin",
+		},
+	}
+
+	name, err := Ensure(fakeClient, "testNs", "project1", "603e7bf38a94956835659ae5", "cluster1", data)
+	assert.NoError(t, err)
+
+	secret := corev1.Secret{}
+	assert.NoError(t, fakeClient.Get(context.Background(), kube.ObjectKey("testNs", name), &secret))
+	assert.Equal(t, buildConnectionURL(data.PrivateEndpointConnURLs[0].ConnURL, data.DBUserName, data.Password), string(secret.Data["connectionStringPrivate.US_EAST_1.vpce-0a1b2c3d"]))
+	assert.Equal(t, buildConnectionURL(data.PrivateEndpointConnURLs[0].SrvConnURL, data.DBUserName, data.Password), string(secret.Data["connectionStringPrivateSrv.US_EAST_1.vpce-0a1b2c3d"]))
+	assert.Equal(t, buildConnectionURL(data.PrivateEndpointConnURLs[1].SrvConnURL, data.DBUserName, data.Password), string(secret.Data["connectionStringPrivateSrv.subscriptions-sub-resourceGroups-rg-providers-Microsoft.Network-privateEndpoints-pe1"]))
+	assert.Len(t, secret.Data, 9)
+}
+
+func TestPrivateEndpointConnURLsFromAtlas(t *testing.T) {
+	assert.Nil(t, PrivateEndpointConnURLsFromAtlas(nil))
+
+	connectionStrings := &mongodbatlas.ConnectionStrings{
+		PrivateEndpoint: []mongodbatlas.PrivateEndpoint{
+			{
+				ConnectionString:    "mongodb://pl-0.example.com:1024",
+				SRVConnectionString: "mongodb+srv://cluster0-pl-0.example.com",
+				Endpoints: []mongodbatlas.Endpoint{
+					{EndpointID: "vpce-1", ProviderName: "AWS", Region: "US_EAST_1"},
+					{EndpointID: "vpce-2", ProviderName: "AWS", Region: "US_WEST_2"},
+				},
+			},
+		},
+		AwsPrivateLink:    map[string]string{"vpce-1": "mongodb://pl-0.example.com:1024", "vpce-3": "mongodb://pl-3.example.com:1024"},
+		AwsPrivateLinkSrv: map[string]string{"vpce-1": "mongodb+srv://cluster0-pl-0.example.com", "vpce-3": "mongodb+srv://cluster0-pl-3.example.com"},
+	}
+
+	expected := []PrivateEndpointConnURLs{
+		{EndpointID: "vpce-1", Region: "US_EAST_1", ConnURL: "mongodb://pl-0.example.com:1024", SrvConnURL: "mongodb+srv://cluster0-pl-0.example.com"},
+		{EndpointID: "vpce-2", Region: "US_WEST_2", ConnURL: "mongodb://pl-0.example.com:1024", SrvConnURL: "mongodb+srv://cluster0-pl-0.example.com"},
+		{EndpointID: "vpce-3", ConnURL: "mongodb://pl-3.example.com:1024", SrvConnURL: "mongodb+srv://cluster0-pl-3.example.com"},
+	}
+	assert.Equal(t, expected, PrivateEndpointConnURLsFromAtlas(connectionStrings))
+}
+
 func validateSecret(t *testing.T, fakeClient client.Client, namespace, projectName, projectID, clusterName string, data ConnectionData) corev1.Secret {
 	secret := corev1.Secret{}
 	secretName := fmt.Sprintf("%s-%s-%s", projectName, clusterName, kube.NormalizeIdentifier(data.DBUserName))
@@ -0,0 +1,87 @@
+package connectionsecret
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+)
+
+// secretKeyInvalidChars matches the characters that are not allowed in the Secret data keys
+var secretKeyInvalidChars = regexp.MustCompile(`[^-._a-zA-Z0-9]+`)
+
+// PrivateEndpointConnURLs are the connection strings to use for connecting to the cluster through the specific
+// private endpoint.
+type PrivateEndpointConnURLs struct {
+	EndpointID, Region, ConnURL, SrvConnURL string
+}
+
+// secretKey returns the key of the Secret data entry for the private endpoint connection string.
+// The key has the format '<prefix>.<region>.<endpointID>', for example 'connectionStringPrivateSrv.US_EAST_1.vpce-0a1b2c3d'.
+// The region is omitted if it's unknown.
+func (pe PrivateEndpointConnURLs) secretKey(prefix string) string {
+	parts := []string{prefix}
+	if pe.Region != "" {
+		parts = append(parts, normalizeSecretKeyPart(pe.Region))
+	}
+	parts = append(parts, normalizeSecretKeyPart(pe.EndpointID))
+	return strings.Join(parts, ".")
+}
+
+func normalizeSecretKeyPart(value string) string {
+	return strings.Trim(secretKeyInvalidChars.ReplaceAllString(value, "-"), "-.")
+}
+
+// PrivateEndpointConnURLsFromAtlas returns the connection strings for every private endpoint the cluster
+// can be reached through.
+// The deprecated 'awsPrivateLink' and 'awsPrivateLinkSrv' maps are used only for the endpoints missing
+// in the 'privateEndpoint' list.
+func PrivateEndpointConnURLsFromAtlas(connectionStrings *mongodbatlas.ConnectionStrings) []PrivateEndpointConnURLs {
+	if connectionStrings == nil {
+		return nil
+	}
+
+	var result []PrivateEndpointConnURLs
+	known := map[string]bool{}
+	for _, pe := range connectionStrings.PrivateEndpoint {
+		for _, endpoint := range pe.Endpoints {
+			if endpoint.EndpointID == "" || known[endpoint.EndpointID] {
+				continue
+			}
+			known[endpoint.EndpointID] = true
+			result = append(result, PrivateEndpointConnURLs{
+				EndpointID: endpoint.EndpointID,
+				Region:     endpoint.Region,
+				ConnURL:    pe.ConnectionString,
+				SrvConnURL: pe.SRVConnectionString,
+			})
+		}
+	}
+
+	legacy := map[string]*PrivateEndpointConnURLs{}
+	var legacyIDs []string
+	addLegacy := func(endpointID string) *PrivateEndpointConnURLs {
+		if _, ok := legacy[endpointID]; !ok {
+			legacy[endpointID] = &PrivateEndpointConnURLs{EndpointID: endpointID}
+			legacyIDs = append(legacyIDs, endpointID)
+		}
+		return legacy[endpointID]
+	}
+	for endpointID, connURL := range connectionStrings.AwsPrivateLink {
+		if !known[endpointID] {
+			addLegacy(endpointID).ConnURL = connURL
+		}
+	}
+	for endpointID, srvConnURL := range connectionStrings.AwsPrivateLinkSrv {
+		if !known[endpointID] {
+			addLegacy(endpointID).SrvConnURL = srvConnURL
+		}
+	}
+	sort.Strings(legacyIDs)
+	for _, endpointID := range legacyIDs {
+		result = append(result, *legacy[endpointID])
+	}
+
+	return result
+}
@@ -17,9 +17,33 @@ import (
 const (
 	ResourcePolicyAnnotation       = "mongodb.com/atlas-resource-policy"
 	ReconciliationPolicyAnnotation = "mongodb.com/atlas-reconciliation-policy"
+	DeletionConfirmedAnnotation    = "mongodb.com/atlas-deletion-confirmed"
+	MigrateToAdvancedAnnotation    = "mongodb.com/atlas-migrate-to-advanced"
+	ChangeApprovalPolicyAnnotation = "mongodb.com/atlas-change-approval-policy"
+	ChangesApprovedAnnotation      = "mongodb.com/atlas-changes-approved"
+	PasswordPlaceholderAnnotation  = "mongodb.com/atlas-password-placeholder"
+	AdoptionPolicyAnnotation       = "mongodb.com/atlas-adoption-policy"
+	AdoptAnnotation                = "mongodb.com/atlas-adopt"
+	// PasswordRotatedAtAnnotation marks the password Secrets generated by the Operator and keeps the time of the last
+	// password rotation
+	PasswordRotatedAtAnnotation = "mongodb.com/atlas-password-rotated-at"
 
-	ResourcePolicyKeep       = "keep"
-	ReconciliationPolicySkip = "skip"
+	ResourcePolicyKeep          = "keep"
+	ReconciliationPolicySkip    = "skip"
+	ReconciliationPolicyPlan    = "plan"
+	ReconciliationPolicyObserve = "observe"
+	DeletionConfirmedTrue       = "true"
+	MigrateToAdvancedTrue       = "true"
+	PasswordPlaceholderTrue     = "true"
+	AdoptTrue                   = "true"
+
+	ChangeApprovalPolicyRequired = "required"
+	AdoptionPolicyExistingOnly   = "existing-only"
+
+	// DeletionProtectionFinalizer holds the removal of the resource until the deletion is confirmed by the user
+	DeletionProtectionFinalizer = "mongodb.com/atlas-deletion-protection"
+	// AccessRevocationFinalizer holds the removal of the AtlasAccessRequest until the access is revoked in Atlas
+	AccessRevocationFinalizer = "mongodb.com/atlas-access-revocation"
 )
 
 // PrepareResource queries the Custom Resource 'request.NamespacedName' and populates the 'resource' pointer.
@@ -67,3 +91,73 @@ func ReconciliationShouldBeSkipped(resource mdbv1.AtlasCustomResource) bool {
 	}
 	return false
 }
+
+// ReconciliationShouldOnlyPlan returns 'true' if the reconciliation must only plan the changes in Atlas for this
+// resource without applying them.
+func ReconciliationShouldOnlyPlan(resource mdbv1.AtlasCustomResource) bool {
+	if v, ok := resource.GetAnnotations()[ReconciliationPolicyAnnotation]; ok {
+		return v == ReconciliationPolicyPlan
+	}
+	return false
+}
+
+// ReconciliationShouldOnlyObserve returns 'true' if the reconciliation must only report the state of the resource in
+// Atlas and its drift from the spec without changing Atlas.
+func ReconciliationShouldOnlyObserve(resource mdbv1.AtlasCustomResource) bool {
+	if v, ok := resource.GetAnnotations()[ReconciliationPolicyAnnotation]; ok {
+		return v == ReconciliationPolicyObserve
+	}
+	return false
+}
+
+// ResourceMustExistInAtlas returns 'true' if the resource may only be bound to the existing Atlas resource and must
+// never be created by the Operator.
+func ResourceMustExistInAtlas(resource mdbv1.AtlasCustomResource) bool {
+	if v, ok := resource.GetAnnotations()[AdoptionPolicyAnnotation]; ok {
+		return v == AdoptionPolicyExistingOnly
+	}
+	return false
+}
+
+// AdoptionIsConfirmed returns 'true' if the user has explicitly allowed the Operator to take over the existing Atlas
+// resource which isn't managed by any Operator yet.
+func AdoptionIsConfirmed(resource mdbv1.AtlasCustomResource) bool {
+	if v, ok := resource.GetAnnotations()[AdoptAnnotation]; ok {
+		return v == AdoptTrue
+	}
+	return false
+}
+
+// DeletionIsConfirmed returns 'true' if the user has explicitly confirmed the removal of the protected resource.
+func DeletionIsConfirmed(resource mdbv1.AtlasCustomResource) bool {
+	if v, ok := resource.GetAnnotations()[DeletionConfirmedAnnotation]; ok {
+		return v == DeletionConfirmedTrue
+	}
+	return false
+}
+
+// MigrationToAdvancedRequested returns 'true' if the user has requested to migrate the AtlasCluster from
+// 'spec.clusterSpec' to 'spec.advancedClusterSpec'.
+func MigrationToAdvancedRequested(resource mdbv1.AtlasCustomResource) bool {
+	if v, ok := resource.GetAnnotations()[MigrateToAdvancedAnnotation]; ok {
+		return v == MigrateToAdvancedTrue
+	}
+	return false
+}
+
+// ChangeApprovalRequired returns 'true' if the risky changes of the resource (like the cluster instance size downgrade)
+// must be approved by the user before they are applied to Atlas.
+func ChangeApprovalRequired(resource mdbv1.AtlasCustomResource) bool {
+	if v, ok := resource.GetAnnotations()[ChangeApprovalPolicyAnnotation]; ok {
+		return v == ChangeApprovalPolicyRequired
+	}
+	return false
+}
+
+// ChangesApproved returns 'true' if the user has approved the changes with the 'hash'.
+func ChangesApproved(resource mdbv1.AtlasCustomResource, hash string) bool {
+	if v, ok := resource.GetAnnotations()[ChangesApprovedAnnotation]; ok {
+		return v == hash
+	}
+	return false
+}
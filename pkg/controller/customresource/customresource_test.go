@@ -76,3 +76,86 @@ func TestReconciliationShouldBeSkipped(t *testing.T) {
 		}
 	})
 }
+
+func TestReconciliationShouldOnlyPlan(t *testing.T) {
+	for _, resource := range []v1.AtlasCustomResource{&v1.AtlasCluster{}, &v1.AtlasDatabaseUser{}, &v1.AtlasProject{}} {
+		assert.False(t, ReconciliationShouldOnlyPlan(resource))
+
+		resource.SetAnnotations(map[string]string{ReconciliationPolicyAnnotation: ReconciliationPolicySkip})
+		assert.False(t, ReconciliationShouldOnlyPlan(resource))
+
+		resource.SetAnnotations(map[string]string{ReconciliationPolicyAnnotation: ReconciliationPolicyPlan})
+		assert.True(t, ReconciliationShouldOnlyPlan(resource))
+	}
+}
+
+func TestReconciliationShouldOnlyObserve(t *testing.T) {
+	for _, resource := range []v1.AtlasCustomResource{&v1.AtlasCluster{}, &v1.AtlasDatabaseUser{}, &v1.AtlasProject{}} {
+		assert.False(t, ReconciliationShouldOnlyObserve(resource))
+
+		resource.SetAnnotations(map[string]string{ReconciliationPolicyAnnotation: ReconciliationPolicyPlan})
+		assert.False(t, ReconciliationShouldOnlyObserve(resource))
+
+		resource.SetAnnotations(map[string]string{ReconciliationPolicyAnnotation: ReconciliationPolicyObserve})
+		assert.True(t, ReconciliationShouldOnlyObserve(resource))
+	}
+}
+
+func TestChangeApproval(t *testing.T) {
+	cluster := &v1.AtlasCluster{}
+	assert.False(t, ChangeApprovalRequired(cluster))
+	assert.False(t, ChangesApproved(cluster, "0123456789abcdef"))
+
+	cluster.SetAnnotations(map[string]string{
+		ChangeApprovalPolicyAnnotation: ChangeApprovalPolicyRequired,
+		ChangesApprovedAnnotation:      "0123456789abcdef",
+	})
+	assert.True(t, ChangeApprovalRequired(cluster))
+	assert.True(t, ChangesApproved(cluster, "0123456789abcdef"))
+	assert.False(t, ChangesApproved(cluster, "fedcba9876543210"))
+}
+
+func TestResourceMustExistInAtlas(t *testing.T) {
+	project := &v1.AtlasProject{}
+	assert.False(t, ResourceMustExistInAtlas(project))
+
+	project.SetAnnotations(map[string]string{AdoptionPolicyAnnotation: "any"})
+	assert.False(t, ResourceMustExistInAtlas(project))
+
+	project.SetAnnotations(map[string]string{AdoptionPolicyAnnotation: AdoptionPolicyExistingOnly})
+	assert.True(t, ResourceMustExistInAtlas(project))
+}
+
+func TestAdoptionIsConfirmed(t *testing.T) {
+	for _, resource := range []v1.AtlasCustomResource{&v1.AtlasCluster{}, &v1.AtlasDatabaseUser{}} {
+		assert.False(t, AdoptionIsConfirmed(resource))
+
+		resource.SetAnnotations(map[string]string{AdoptAnnotation: "yes"})
+		assert.False(t, AdoptionIsConfirmed(resource))
+
+		resource.SetAnnotations(map[string]string{AdoptAnnotation: AdoptTrue})
+		assert.True(t, AdoptionIsConfirmed(resource))
+	}
+}
+
+func TestDeletionIsConfirmed(t *testing.T) {
+	t.Run("Empty annotations", func(t *testing.T) {
+		assert.False(t, DeletionIsConfirmed(&v1.AtlasCluster{}))
+	})
+
+	t.Run("Annotation present, deletion is not confirmed", func(t *testing.T) {
+		assert.False(t, DeletionIsConfirmed(&v1.AtlasCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{DeletionConfirmedAnnotation: "yes"},
+			},
+		}))
+	})
+
+	t.Run("Annotation present, deletion is confirmed", func(t *testing.T) {
+		assert.True(t, DeletionIsConfirmed(&v1.AtlasCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{DeletionConfirmedAnnotation: DeletionConfirmedTrue},
+			},
+		}))
+	})
+}
@@ -0,0 +1,125 @@
+// Package defaults contains the canonical defaults for the Atlas Custom Resources. The defaults match the values
+// Atlas uses itself, so writing them into the resources makes the Operator compare the same representations with the
+// Atlas responses and lets users see the effective configuration.
+package defaults
+
+import (
+	"fmt"
+	"strings"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
+)
+
+const (
+	// highestRegionPriority is the election priority Atlas assigns to the highest priority region
+	highestRegionPriority = 7
+)
+
+// Cluster sets the defaults for the regular or the advanced cluster spec. The serverless spec has no defaults.
+func Cluster(cluster *mdbv1.AtlasCluster) {
+	if cluster.Spec.ClusterSpec != nil {
+		clusterSpec(cluster.Spec.ClusterSpec)
+	}
+	if cluster.Spec.AdvancedClusterSpec != nil {
+		advancedClusterSpec(cluster.Spec.AdvancedClusterSpec)
+	}
+}
+
+func clusterSpec(spec *mdbv1.ClusterSpec) {
+	if spec.ClusterType == "" {
+		spec.ClusterType = mdbv1.TypeReplicaSet
+		if spec.NumShards != nil && *spec.NumShards > 1 {
+			spec.ClusterType = mdbv1.TypeSharded
+		}
+	}
+
+	for i := range spec.ReplicationSpecs {
+		if spec.ReplicationSpecs[i].NumShards == nil {
+			numShards := int64(1)
+			spec.ReplicationSpecs[i].NumShards = &numShards
+		}
+	}
+}
+
+func advancedClusterSpec(spec *mdbv1.AdvancedClusterSpec) {
+	if spec.ClusterType == "" {
+		spec.ClusterType = string(mdbv1.TypeReplicaSet)
+		for _, replicationSpec := range spec.ReplicationSpecs {
+			if replicationSpec != nil && replicationSpec.NumShards > 1 {
+				spec.ClusterType = string(mdbv1.TypeSharded)
+			}
+		}
+	}
+
+	for i, replicationSpec := range spec.ReplicationSpecs {
+		if replicationSpec == nil {
+			continue
+		}
+		if replicationSpec.NumShards == 0 {
+			replicationSpec.NumShards = 1
+		}
+		if replicationSpec.ZoneName == "" {
+			replicationSpec.ZoneName = fmt.Sprintf("Zone %d", i+1)
+		}
+		regionPriorities(replicationSpec.RegionConfigs)
+	}
+}
+
+// regionPriorities sets the election priorities for the regions which don't specify them. Atlas requires the
+// regions with electable nodes to have the descending priorities starting from 7 (in the order they are specified)
+// and the regions without electable nodes to have the priority 0.
+func regionPriorities(regionConfigs []*mdbv1.AdvancedRegionConfig) {
+	priority := highestRegionPriority
+	for _, regionConfig := range regionConfigs {
+		if regionConfig == nil {
+			continue
+		}
+		hasElectableNodes := regionConfig.ElectableSpecs != nil &&
+			(regionConfig.ElectableSpecs.NodeCount == nil || *regionConfig.ElectableSpecs.NodeCount > 0)
+
+		if regionConfig.Priority == nil {
+			p := 0
+			if hasElectableNodes {
+				p = priority
+			}
+			regionConfig.Priority = &p
+		}
+		if hasElectableNodes && priority > 0 {
+			priority--
+		}
+	}
+}
+
+// BackupPolicy lowercases the frequency types and the retention units as Atlas returns them in lower case.
+func BackupPolicy(policy *mdbv1.AtlasBackupPolicy) {
+	for i := range policy.Spec.Items {
+		policy.Spec.Items[i].FrequencyType = strings.ToLower(policy.Spec.Items[i].FrequencyType)
+		policy.Spec.Items[i].RetentionUnit = strings.ToLower(policy.Spec.Items[i].RetentionUnit)
+	}
+}
+
+// DatabaseUser converts the 'deleteAfterDate' to the format returned by Atlas.
+func DatabaseUser(user *mdbv1.AtlasDatabaseUser) {
+	user.Spec.DeleteAfterDate = iso8601Date(user.Spec.DeleteAfterDate)
+}
+
+// Project converts the 'deleteAfterDate' of the IP access lists to the format returned by Atlas.
+func Project(project *mdbv1.AtlasProject) {
+	for i := range project.Spec.ProjectIPAccessList {
+		project.Spec.ProjectIPAccessList[i].DeleteAfterDate = iso8601Date(project.Spec.ProjectIPAccessList[i].DeleteAfterDate)
+	}
+}
+
+// iso8601Date returns the date in the UTC ISO 8601 format used by Atlas. Invalid dates are returned as is - they are
+// reported by the validation.
+func iso8601Date(date string) string {
+	if date == "" {
+		return date
+	}
+	parsed, err := timeutil.ParseISO8601(date)
+	if err != nil {
+		return date
+	}
+	return timeutil.FormatISO8601(parsed.UTC())
+}
@@ -0,0 +1,104 @@
+package defaults
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+func TestCluster(t *testing.T) {
+	t.Run("Regular cluster", func(t *testing.T) {
+		cluster := mdbv1.DefaultAWSCluster("default", "my-project")
+		cluster.Spec.ClusterSpec.ReplicationSpecs = []mdbv1.ReplicationSpec{{ZoneName: "Zone 1"}}
+		Cluster(cluster)
+
+		assert.Equal(t, mdbv1.TypeReplicaSet, cluster.Spec.ClusterSpec.ClusterType)
+		assert.Equal(t, int64(1), *cluster.Spec.ClusterSpec.ReplicationSpecs[0].NumShards)
+	})
+
+	t.Run("Regular sharded cluster", func(t *testing.T) {
+		cluster := mdbv1.DefaultAWSCluster("default", "my-project")
+		cluster.Spec.ClusterSpec.NumShards = toptr.Intptr(2)
+		Cluster(cluster)
+
+		assert.Equal(t, mdbv1.TypeSharded, cluster.Spec.ClusterSpec.ClusterType)
+	})
+
+	t.Run("Cluster type is not overridden", func(t *testing.T) {
+		cluster := mdbv1.DefaultAWSCluster("default", "my-project")
+		cluster.Spec.ClusterSpec.ClusterType = mdbv1.TypeGeoSharded
+		Cluster(cluster)
+
+		assert.Equal(t, mdbv1.TypeGeoSharded, cluster.Spec.ClusterSpec.ClusterType)
+	})
+
+	t.Run("Advanced cluster", func(t *testing.T) {
+		cluster := mdbv1.DefaultAwsAdvancedCluster("default", "my-project")
+		cluster.Spec.AdvancedClusterSpec.ClusterType = ""
+		regionConfigs := cluster.Spec.AdvancedClusterSpec.ReplicationSpecs[0].RegionConfigs
+		regionConfigs[0].Priority = nil
+		regionConfigs = append(regionConfigs,
+			&mdbv1.AdvancedRegionConfig{RegionName: "US_WEST_2", ElectableSpecs: &mdbv1.Specs{NodeCount: toptr.Intptr(2)}},
+			&mdbv1.AdvancedRegionConfig{RegionName: "EU_WEST_1", ReadOnlySpecs: &mdbv1.Specs{NodeCount: toptr.Intptr(1)}},
+		)
+		cluster.Spec.AdvancedClusterSpec.ReplicationSpecs[0].RegionConfigs = regionConfigs
+		Cluster(cluster)
+
+		assert.Equal(t, string(mdbv1.TypeReplicaSet), cluster.Spec.AdvancedClusterSpec.ClusterType)
+		replicationSpec := cluster.Spec.AdvancedClusterSpec.ReplicationSpecs[0]
+		assert.Equal(t, 1, replicationSpec.NumShards)
+		assert.Equal(t, "Zone 1", replicationSpec.ZoneName)
+		assert.Equal(t, 7, *replicationSpec.RegionConfigs[0].Priority)
+		assert.Equal(t, 6, *replicationSpec.RegionConfigs[1].Priority)
+		assert.Equal(t, 0, *replicationSpec.RegionConfigs[2].Priority)
+	})
+
+	t.Run("Defaults are idempotent", func(t *testing.T) {
+		cluster := mdbv1.DefaultAwsAdvancedCluster("default", "my-project")
+		Cluster(cluster)
+		defaulted := cluster.DeepCopy()
+		Cluster(cluster)
+
+		assert.Equal(t, defaulted, cluster)
+	})
+}
+
+func TestBackupPolicy(t *testing.T) {
+	policy := &mdbv1.AtlasBackupPolicy{Spec: mdbv1.AtlasBackupPolicySpec{Items: []mdbv1.AtlasBackupPolicyItem{
+		{FrequencyType: "Daily", RetentionUnit: "DAYS"},
+	}}}
+	BackupPolicy(policy)
+
+	assert.Equal(t, "daily", policy.Spec.Items[0].FrequencyType)
+	assert.Equal(t, "days", policy.Spec.Items[0].RetentionUnit)
+}
+
+func TestDeleteAfterDate(t *testing.T) {
+	testCases := []struct {
+		in       string
+		expected string
+	}{
+		{in: "", expected: ""},
+		{in: "2021-11-02", expected: "2021-11-02T00:00:00Z"},
+		{in: "2021-11-02T20:04:05+03", expected: "2021-11-02T17:04:05Z"},
+		{in: "2021-11-02T20:04:05.123Z", expected: "2021-11-02T20:04:05.123Z"},
+		{in: "incorrect", expected: "incorrect"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.in, func(t *testing.T) {
+			user := mdbv1.DefaultDBUser("default", "theuser", "my-project").WithDeleteAfterDate(testCase.in)
+			DatabaseUser(user)
+			assert.Equal(t, testCase.expected, user.Spec.DeleteAfterDate)
+
+			p := mdbv1.NewProject("default", "my-project", "Test Project").
+				WithIPAccessList(project.NewIPAccessList().WithIP("192.158.0.0").WithDeleteAfterDate(testCase.in))
+			Project(p)
+			assert.Equal(t, testCase.expected, p.Spec.ProjectIPAccessList[0].DeleteAfterDate)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Package metrics contains the Prometheus metrics of the Operator. The metrics are registered in the controller-runtime
+// registry so they are exposed on the same endpoint as the controller-runtime ones ('--metrics-bind-address').
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "atlas_operator"
+
+var (
+	// AtlasRequestDuration is the duration of the requests sent to Atlas. Each request sent over the network is
+	// observed, including the retries and the digest authentication challenges.
+	AtlasRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "atlas_request_duration_seconds",
+		Help:      "Duration of the requests to the Atlas API by method, path template and status code",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"method", "path", "code"})
+
+	// ConditionReasons counts the reasons the resource conditions were set to false with.
+	ConditionReasons = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "condition_reasons_total",
+		Help:      "Number of times the resource conditions were set to false by condition type and reason",
+	}, []string{"condition", "reason"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(AtlasRequestDuration, ConditionReasons)
+}
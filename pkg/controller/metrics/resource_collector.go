@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+)
+
+var resourcesDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "resources"),
+	"Number of the Atlas Custom Resources by kind, namespace and readiness",
+	[]string{"kind", "namespace", "ready"}, nil,
+)
+
+var driftedResourcesDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "drifted_resources"),
+	"Number of the observed Atlas Custom Resources which differ from Atlas by kind and namespace",
+	[]string{"kind", "namespace"}, nil,
+)
+
+// ResourceCollector reports the number of Ready and not Ready Atlas Custom Resources and the number of the resources
+// with the drift detected (see the 'observe' reconciliation policy). The resources are read
+// from the manager cache on each scrape, so the numbers never drift from the cluster state (i.e. after the deletion).
+type ResourceCollector struct {
+	reader client.Reader
+	log    *zap.SugaredLogger
+}
+
+var _ prometheus.Collector = &ResourceCollector{}
+
+func NewResourceCollector(reader client.Reader, log *zap.SugaredLogger) *ResourceCollector {
+	return &ResourceCollector{reader: reader, log: log}
+}
+
+func (c *ResourceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- resourcesDesc
+	ch <- driftedResourcesDesc
+}
+
+func (c *ResourceCollector) Collect(ch chan<- prometheus.Metric) {
+	lists := map[string]client.ObjectList{
+		"AtlasProject":       &mdbv1.AtlasProjectList{},
+		"AtlasCluster":       &mdbv1.AtlasClusterList{},
+		"AtlasDatabaseUser":  &mdbv1.AtlasDatabaseUserList{},
+		"AtlasAccessRequest": &mdbv1.AtlasAccessRequestList{},
+	}
+	for kind, list := range lists {
+		if err := c.reader.List(context.Background(), list); err != nil {
+			c.log.Debugf("Failed to list %s resources for metrics: %v", kind, err)
+			continue
+		}
+		for key, count := range countByReadiness(resources(list)) {
+			ch <- prometheus.MustNewConstMetric(resourcesDesc, prometheus.GaugeValue, float64(count), kind, key.namespace, key.ready)
+		}
+		for namespace, count := range countDrifted(resources(list)) {
+			ch <- prometheus.MustNewConstMetric(driftedResourcesDesc, prometheus.GaugeValue, float64(count), kind, namespace)
+		}
+	}
+}
+
+type readinessKey struct {
+	namespace string
+	ready     string
+}
+
+func countByReadiness(resources []mdbv1.AtlasCustomResource) map[readinessKey]int {
+	result := map[readinessKey]int{}
+	for _, resource := range resources {
+		ready := "false"
+		for _, condition := range resource.GetStatus().GetConditions() {
+			if condition.Type == status.ReadyType && condition.Status == corev1.ConditionTrue {
+				ready = "true"
+			}
+		}
+		result[readinessKey{namespace: resource.GetNamespace(), ready: ready}]++
+	}
+	return result
+}
+
+// countDrifted returns the number of the resources with the drift detected by namespace. Only the namespaces with
+// such resources are reported.
+func countDrifted(resources []mdbv1.AtlasCustomResource) map[string]int {
+	result := map[string]int{}
+	for _, resource := range resources {
+		for _, condition := range resource.GetStatus().GetConditions() {
+			if condition.Type == status.DriftDetectedType && condition.Status == corev1.ConditionTrue {
+				result[resource.GetNamespace()]++
+			}
+		}
+	}
+	return result
+}
+
+func resources(list client.ObjectList) []mdbv1.AtlasCustomResource {
+	var result []mdbv1.AtlasCustomResource
+	switch l := list.(type) {
+	case *mdbv1.AtlasProjectList:
+		for i := range l.Items {
+			result = append(result, &l.Items[i])
+		}
+	case *mdbv1.AtlasClusterList:
+		for i := range l.Items {
+			result = append(result, &l.Items[i])
+		}
+	case *mdbv1.AtlasDatabaseUserList:
+		for i := range l.Items {
+			result = append(result, &l.Items[i])
+		}
+	case *mdbv1.AtlasAccessRequestList:
+		for i := range l.Items {
+			result = append(result, &l.Items[i])
+		}
+	}
+	return result
+}
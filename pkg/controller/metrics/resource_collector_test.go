@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+)
+
+func TestResourceCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, mdbv1.AddToScheme(scheme))
+
+	readyProject := mdbv1.DefaultProject("ns1", "secret").WithName("ready")
+	readyProject.Status.Conditions = []status.Condition{
+		{Type: status.ReadyType, Status: corev1.ConditionTrue},
+		{Type: status.DriftDetectedType, Status: corev1.ConditionTrue},
+	}
+	notReadyProject := mdbv1.DefaultProject("ns1", "secret").WithName("not-ready")
+	notReadyProject.Status.Conditions = []status.Condition{{Type: status.ReadyType, Status: corev1.ConditionFalse}}
+	otherNamespaceProject := mdbv1.DefaultProject("ns2", "secret").WithName("other")
+	cluster := mdbv1.DefaultAWSCluster("ns1", "ready")
+
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(readyProject, notReadyProject, otherNamespaceProject, cluster).Build()
+
+	expected := `
+# HELP atlas_operator_drifted_resources Number of the observed Atlas Custom Resources which differ from Atlas by kind and namespace
+# TYPE atlas_operator_drifted_resources gauge
+atlas_operator_drifted_resources{kind="AtlasProject",namespace="ns1"} 1
+# HELP atlas_operator_resources Number of the Atlas Custom Resources by kind, namespace and readiness
+# TYPE atlas_operator_resources gauge
+atlas_operator_resources{kind="AtlasCluster",namespace="ns1",ready="false"} 1
+atlas_operator_resources{kind="AtlasProject",namespace="ns1",ready="false"} 1
+atlas_operator_resources{kind="AtlasProject",namespace="ns1",ready="true"} 1
+atlas_operator_resources{kind="AtlasProject",namespace="ns2",ready="false"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(NewResourceCollector(reader, zap.S()), strings.NewReader(expected)))
+}
@@ -0,0 +1,117 @@
+package ownership
+
+import (
+	"fmt"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+// The Atlas labels marking the clusters and the database users managed by the Operator. The UID identifies the
+// Custom Resource managing the Atlas resource, so the resources of another Operator instance or another Kubernetes
+// cluster are never taken over. The namespace helps to find the owner.
+const (
+	UIDLabelKey       = "mongodb.com/atlas-operator-uid"
+	NamespaceLabelKey = "mongodb.com/atlas-operator-namespace"
+)
+
+// Labels returns the labels marking the Atlas resource as managed by the Custom Resource.
+func Labels(resource mdbv1.AtlasCustomResource) []mongodbatlas.Label {
+	return []mongodbatlas.Label{
+		{Key: UIDLabelKey, Value: string(resource.GetUID())},
+		{Key: NamespaceLabelKey, Value: resource.GetNamespace()},
+	}
+}
+
+// WithLabels returns the labels with the ownership labels of the Custom Resource added to the end (replacing the
+// existing ones).
+func WithLabels(labels []mongodbatlas.Label, resource mdbv1.AtlasCustomResource) []mongodbatlas.Label {
+	return append(WithoutLabels(labels), Labels(resource)...)
+}
+
+// WithoutLabels returns the labels with the ownership labels removed.
+func WithoutLabels(labels []mongodbatlas.Label) []mongodbatlas.Label {
+	var result []mongodbatlas.Label
+	for _, label := range labels {
+		if label.Key != UIDLabelKey && label.Key != NamespaceLabelKey {
+			result = append(result, label)
+		}
+	}
+	return result
+}
+
+// Check verifies that the Atlas resource with the 'labels' may be managed by the Custom Resource. The resource not
+// marked by any Operator is managed only if it has been managed by the Custom Resource before the ownership labels
+// were introduced ('managed') or if the user has confirmed its adoption with the annotation.
+// The 'description' names the Atlas resource in the messages, for example "cluster test-cluster".
+// The plan and observe modes only read the resource: the resource not marked by any Operator is reported with the
+// 'notAdopted' status (so the status they fill isn't taken as managed before), the plan mode also records its
+// adoption as the planned change.
+func Check(ctx *workflow.Context, resource mdbv1.AtlasCustomResource, description string, labels []mongodbatlas.Label, managed bool) workflow.Result {
+	owner := labelValue(labels, UIDLabelKey)
+	switch {
+	case owner != "" && owner == string(resource.GetUID()):
+		return adopted(ctx)
+	case owner != "" && ctx.ObserveOnly:
+		return workflow.OK()
+	case owner != "":
+		return workflow.Terminate(workflow.AtlasResourceOwnedByOther,
+			fmt.Sprintf("%s is managed by another resource (uid %s, namespace %q) of another Operator instance or Kubernetes cluster",
+				description, owner, labelValue(labels, NamespaceLabelKey)))
+	case managedBefore(resource, managed):
+		return workflow.OK()
+	case ctx.PlanOnly:
+		ctx.EnsureStatusOption(status.NotAdoptedOption(true))
+		if !ctx.ObserveOnly {
+			diff := fmt.Sprintf("adopt the %s which isn't managed by the Operator", description)
+			if !customresource.AdoptionIsConfirmed(resource) {
+				diff += fmt.Sprintf(", requires the %s=%s annotation", customresource.AdoptAnnotation, customresource.AdoptTrue)
+			}
+			ctx.PlanChange(workflow.PlanUpdate, description, diff)
+		}
+		return workflow.OK()
+	case customresource.AdoptionIsConfirmed(resource):
+		return adopted(ctx)
+	default:
+		return workflow.Terminate(workflow.AtlasResourceNotAdopted,
+			fmt.Sprintf("%s already exists in Atlas and isn't managed by the Operator, set the %s=%s annotation to adopt it",
+				description, customresource.AdoptAnnotation, customresource.AdoptTrue))
+	}
+}
+
+// adopted clears the 'notAdopted' status once the Operator manages the Atlas resource.
+func adopted(ctx *workflow.Context) workflow.Result {
+	if !ctx.PlanOnly {
+		ctx.EnsureStatusOption(status.NotAdoptedOption(false))
+	}
+	return workflow.OK()
+}
+
+// managedBefore returns true if the Atlas resource not marked by any Operator has been managed by the Custom Resource
+// ('managed'). The status reported in the plan or observe mode for the resource not adopted doesn't count.
+func managedBefore(resource mdbv1.AtlasCustomResource, managed bool) bool {
+	return managed && !resource.GetStatus().IsNotAdopted()
+}
+
+// IsOwned returns 'true' if the Atlas resource is marked as managed by the Custom Resource or if it's not marked at all
+// but has been managed by the Custom Resource before ('managed').
+func IsOwned(resource mdbv1.AtlasCustomResource, labels []mongodbatlas.Label, managed bool) bool {
+	owner := labelValue(labels, UIDLabelKey)
+	if owner == "" {
+		return managedBefore(resource, managed)
+	}
+	return owner == string(resource.GetUID())
+}
+
+func labelValue(labels []mongodbatlas.Label, key string) string {
+	for _, label := range labels {
+		if label.Key == key {
+			return label.Value
+		}
+	}
+	return ""
+}
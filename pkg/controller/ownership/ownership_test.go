@@ -0,0 +1,124 @@
+package ownership
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
+)
+
+func testCluster(uid string) *mdbv1.AtlasCluster {
+	return &mdbv1.AtlasCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "ns", UID: types.UID("uid-" + uid)}}
+}
+
+func TestWithLabels(t *testing.T) {
+	cluster := testCluster("1")
+	labels := []mongodbatlas.Label{
+		{Key: UIDLabelKey, Value: "uid-2"},
+		{Key: "team", Value: "data"},
+		{Key: NamespaceLabelKey, Value: "other"},
+	}
+
+	assert.Equal(t, []mongodbatlas.Label{
+		{Key: "team", Value: "data"},
+		{Key: UIDLabelKey, Value: "uid-1"},
+		{Key: NamespaceLabelKey, Value: "ns"},
+	}, WithLabels(labels, cluster))
+	assert.Equal(t, Labels(cluster), WithLabels(nil, cluster))
+	assert.Equal(t, []mongodbatlas.Label{{Key: "team", Value: "data"}}, WithoutLabels(labels))
+}
+
+func TestCheck(t *testing.T) {
+	cluster := testCluster("1")
+	ctx := workflow.NewContext(zap.S(), nil)
+
+	t.Run("Marked by the resource", func(t *testing.T) {
+		assert.True(t, Check(ctx, cluster, "cluster test", Labels(cluster), false).IsOk())
+		assert.True(t, IsOwned(cluster, Labels(cluster), false))
+	})
+	t.Run("Marked by another resource", func(t *testing.T) {
+		other := Labels(testCluster("2"))
+		cluster := testCluster("1")
+		cluster.SetAnnotations(map[string]string{customresource.AdoptAnnotation: customresource.AdoptTrue})
+
+		result := Check(ctx, cluster, "cluster test", other, true)
+		assert.False(t, result.IsOk())
+		assert.Equal(t, workflow.RetryPermanent, result.RetryClass())
+		assert.False(t, IsOwned(cluster, other, true))
+	})
+	t.Run("Not marked and not managed", func(t *testing.T) {
+		result := Check(ctx, cluster, "cluster test", []mongodbatlas.Label{{Key: "team", Value: "data"}}, false)
+		assert.False(t, result.IsOk())
+		assert.Equal(t, workflow.RetryPermanent, result.RetryClass())
+		assert.False(t, IsOwned(cluster, nil, false))
+	})
+	t.Run("Not marked but managed before", func(t *testing.T) {
+		assert.True(t, Check(ctx, cluster, "cluster test", nil, true).IsOk())
+		assert.True(t, IsOwned(cluster, nil, true))
+	})
+	t.Run("Not marked but adoption is confirmed", func(t *testing.T) {
+		cluster := testCluster("1")
+		cluster.SetAnnotations(map[string]string{customresource.AdoptAnnotation: customresource.AdoptTrue})
+
+		assert.True(t, Check(ctx, cluster, "cluster test", nil, false).IsOk())
+		// the adoption doesn't allow to remove the resource from Atlas until it's marked by the resource
+		assert.False(t, IsOwned(cluster, nil, false))
+	})
+	t.Run("Not marked and not managed in the plan mode", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), nil)
+		ctx.PlanOnly = true
+
+		assert.True(t, Check(ctx, cluster, "cluster test", nil, false).IsOk())
+		require.Len(t, ctx.PlannedChanges(), 1)
+		assert.Equal(t, workflow.PlanUpdate, ctx.PlannedChanges()[0].Action)
+		assert.Equal(t, "cluster test", ctx.PlannedChanges()[0].Resource)
+	})
+	t.Run("Marked by another resource in the plan mode", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), nil)
+		ctx.PlanOnly = true
+
+		assert.False(t, Check(ctx, cluster, "cluster test", Labels(testCluster("2")), false).IsOk())
+	})
+	t.Run("Not enforced in the observe mode", func(t *testing.T) {
+		ctx := workflow.NewContext(zap.S(), nil)
+		ctx.PlanOnly = true
+		ctx.ObserveOnly = true
+
+		assert.True(t, Check(ctx, cluster, "cluster test", nil, false).IsOk())
+		assert.True(t, Check(ctx, cluster, "cluster test", Labels(testCluster("2")), false).IsOk())
+		assert.Empty(t, ctx.PlannedChanges())
+	})
+	t.Run("Observed resource is not managed once the observe mode is off", func(t *testing.T) {
+		cluster := testCluster("1")
+		cluster.SetAnnotations(map[string]string{customresource.ReconciliationPolicyAnnotation: customresource.ReconciliationPolicyObserve})
+		ctx := workflow.NewContext(zap.S(), nil)
+		ctx.PlanOnly = true
+		ctx.ObserveOnly = true
+
+		require.True(t, Check(ctx, cluster, "cluster test", nil, cluster.Status.StateName != "").IsOk())
+		// the observe mode reports the state of the cluster
+		ctx.EnsureStatusOption(status.AtlasClusterStateNameOption("IDLE"))
+		cluster.UpdateStatus(ctx.Conditions(), ctx.StatusOptions()...)
+		assert.True(t, cluster.Status.NotAdopted)
+
+		cluster.SetAnnotations(nil)
+		ctx = workflow.NewContext(zap.S(), nil)
+		result := Check(ctx, cluster, "cluster test", nil, cluster.Status.StateName != "")
+		assert.False(t, result.IsOk())
+		assert.False(t, IsOwned(cluster, nil, cluster.Status.StateName != ""))
+
+		cluster.SetAnnotations(map[string]string{customresource.AdoptAnnotation: customresource.AdoptTrue})
+		require.True(t, Check(ctx, cluster, "cluster test", nil, cluster.Status.StateName != "").IsOk())
+		cluster.UpdateStatus(ctx.Conditions(), ctx.StatusOptions()...)
+		assert.False(t, cluster.Status.NotAdopted)
+	})
+}
@@ -0,0 +1,89 @@
+// Package tracing configures the OpenTelemetry tracing of the Operator. The reconciliations, their steps and the
+// requests to Atlas are traced, the spans are exported with the OTLP exporter if the endpoint is configured.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	tracerName  = "github.com/mongodb/mongodb-atlas-kubernetes"
+	serviceName = "mongodb-atlas-kubernetes-operator"
+)
+
+// Config is the configuration of the traces exporter.
+type Config struct {
+	// Endpoint is the 'host:port' of the OTLP HTTP receiver. The tracing is disabled if it's empty.
+	Endpoint string
+	// Insecure disables the TLS for the connection to the receiver.
+	Insecure bool
+	// SampleRatio is the share of the reconciliations traced, from 0 to 1.
+	SampleRatio float64
+	// Version is the version of the Operator reported in the traces.
+	Version string
+}
+
+// Setup registers the global tracer provider exporting the spans to the OTLP receiver. The returned function flushes
+// the spans left and must be called before the Operator exits. Nothing is registered if the endpoint is not specified,
+// in this case the spans are not recorded at all.
+func Setup(ctx context.Context, config Config) (func(context.Context) error, error) {
+	if config.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.Endpoint)}
+	if config.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OTLP exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(serviceName),
+			semconv.ServiceVersionKey.String(config.Version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start starts the span being the child of the one found in the context (if any).
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// StartReconcile starts the root span of the reconciliation of the resource.
+func StartReconcile(ctx context.Context, kind string, resource client.ObjectKey) (context.Context, trace.Span) {
+	return Start(ctx, kind+".Reconcile",
+		attribute.String("k8s.resource.kind", kind),
+		attribute.String("k8s.namespace.name", resource.Namespace),
+		attribute.String("k8s.resource.name", resource.Name),
+	)
+}
+
+// Logger adds the ids of the trace and the span found in the context to the logger, so that the logs can be
+// correlated with the traces. The logger is returned as is if the span is not recorded.
+func Logger(ctx context.Context, log *zap.SugaredLogger) *zap.SugaredLogger {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return log
+	}
+	return log.With("traceID", spanContext.TraceID().String(), "spanID", spanContext.SpanID().String())
+}
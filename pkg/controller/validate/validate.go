@@ -2,13 +2,36 @@ package validate
 
 import (
 	"errors"
+	"fmt"
+	"net"
 	"reflect"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/timeutil"
 )
 
+// adminOnlyRoles are the built-in Atlas roles which can be granted only on the 'admin' database
+var adminOnlyRoles = map[string]bool{
+	"atlasAdmin":           true,
+	"backup":               true,
+	"clusterMonitor":       true,
+	"dbAdminAnyDatabase":   true,
+	"enableSharding":       true,
+	"readAnyDatabase":      true,
+	"readWriteAnyDatabase": true,
+}
+
+var x509Types = map[string]bool{
+	"NONE":     true,
+	"MANAGED":  true,
+	"CUSTOMER": true,
+}
+
 func ClusterSpec(clusterSpec mdbv1.AtlasClusterSpec) error {
 	var err error
 
@@ -29,14 +52,334 @@ func ClusterSpec(clusterSpec mdbv1.AtlasClusterSpec) error {
 		}
 	}
 
+	if clusterSpec.ClusterSpec != nil && clusterSpec.ClusterSpec.ProviderSettings != nil {
+		settings := clusterSpec.ClusterSpec.ProviderSettings
+		if e := instanceSizeForProvider("spec.clusterSpec.providerSettings.instanceSizeName", settings.InstanceSizeName, string(settings.ProviderName)); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+
+	if clusterSpec.AdvancedClusterSpec != nil {
+		if e := advancedInstanceSizes(clusterSpec.AdvancedClusterSpec); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+
+	if clusterSpec.ServerlessSpec != nil {
+		if e := serverlessPrivateEndpoints(clusterSpec.ServerlessSpec); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+
 	return err
 }
 
-func Project(_ *mdbv1.AtlasProject) error {
+// sharedInstanceSizes are the instance sizes of the shared clusters, Atlas provisions them only with the TENANT provider
+var sharedInstanceSizes = map[string]bool{
+	"M0": true,
+	"M2": true,
+	"M5": true,
+}
+
+// instanceSizeForProvider checks that the shared instance sizes are used only with the TENANT provider and vice versa.
+func instanceSizeForProvider(field, instanceSize, providerName string) error {
+	if instanceSize == "" || providerName == "" || providerName == "SERVERLESS" {
+		return nil
+	}
+	if sharedInstanceSizes[instanceSize] && providerName != "TENANT" {
+		return fmt.Errorf("%s %s is a shared instance size and requires the TENANT provider, got %q", field, instanceSize, providerName)
+	}
+	if !sharedInstanceSizes[instanceSize] && providerName == "TENANT" {
+		return fmt.Errorf("%s %s is not a shared instance size and can't be used with the TENANT provider", field, instanceSize)
+	}
 	return nil
 }
 
-func DatabaseUser(_ *mdbv1.AtlasDatabaseUser) error {
+// advancedInstanceSizes checks the instance sizes of all the nodes of the advanced cluster against the providers of
+// their regions.
+func advancedInstanceSizes(advancedSpec *mdbv1.AdvancedClusterSpec) error {
+	var err error
+	for i, replicationSpec := range advancedSpec.ReplicationSpecs {
+		if replicationSpec == nil {
+			continue
+		}
+		for j, regionConfig := range replicationSpec.RegionConfigs {
+			if regionConfig == nil {
+				continue
+			}
+			nodeSpecs := []struct {
+				name  string
+				specs *mdbv1.Specs
+			}{
+				{"electableSpecs", regionConfig.ElectableSpecs},
+				{"readOnlySpecs", regionConfig.ReadOnlySpecs},
+				{"analyticsSpecs", regionConfig.AnalyticsSpecs},
+			}
+			for _, nodeSpec := range nodeSpecs {
+				if nodeSpec.specs == nil {
+					continue
+				}
+				field := fmt.Sprintf("spec.advancedClusterSpec.replicationSpecs[%d].regionConfigs[%d].%s.instanceSize", i, j, nodeSpec.name)
+				if e := instanceSizeForProvider(field, nodeSpec.specs.InstanceSize, regionConfig.ProviderName); e != nil {
+					err = multierror.Append(err, e)
+				}
+			}
+		}
+	}
+	return err
+}
+
+// ClusterUpdate validates the changes made to the existing AtlasCluster. Some of the fields can't be changed
+// as the Operator would create a new cluster in Atlas leaving the old one orphaned.
+func ClusterUpdate(oldCluster, newCluster *mdbv1.AtlasCluster) error {
+	var err error
+
+	if oldCluster.IsServerless() != newCluster.IsServerless() {
+		err = multierror.Append(err, errors.New("a serverless instance can't be converted to a dedicated cluster and vice versa"))
+		return err
+	}
+
+	if oldCluster.GetClusterName() != newCluster.GetClusterName() {
+		err = multierror.Append(err, fmt.Errorf("the cluster name can't be changed from %q to %q", oldCluster.GetClusterName(), newCluster.GetClusterName()))
+	}
+
+	if oldCluster.AtlasProjectObjectKey() != newCluster.AtlasProjectObjectKey() {
+		err = multierror.Append(err, errors.New("spec.projectRef can't be changed"))
+	}
+
+	if newCluster.IsServerless() {
+		oldSettings := oldCluster.Spec.ServerlessSpec.ProviderSettings
+		newSettings := newCluster.Spec.ServerlessSpec.ProviderSettings
+		if oldSettings != nil && newSettings != nil {
+			checkField := func(field, oldValue, newValue string) {
+				if oldValue != newValue {
+					err = multierror.Append(err, fmt.Errorf("spec.serverlessSpec.%s can't be changed from %q to %q", field, oldValue, newValue))
+				}
+			}
+			checkField("providerSettings.providerName", string(oldSettings.ProviderName), string(newSettings.ProviderName))
+			checkField("providerSettings.backingProviderName", oldSettings.BackingProviderName, newSettings.BackingProviderName)
+			checkField("providerSettings.regionName", oldSettings.RegionName, newSettings.RegionName)
+		}
+	}
+
+	return err
+}
+
+func serverlessPrivateEndpoints(serverlessSpec *mdbv1.ServerlessSpec) error {
+	if len(serverlessSpec.PrivateEndpoints) == 0 {
+		return nil
+	}
+
+	var err error
+	backingProvider := ""
+	if serverlessSpec.ProviderSettings != nil {
+		backingProvider = serverlessSpec.ProviderSettings.BackingProviderName
+	}
+	if backingProvider != "AWS" && backingProvider != "AZURE" {
+		err = multierror.Append(err, fmt.Errorf("serverless private endpoints are supported only for AWS and AZURE backing providers, got %q", backingProvider))
+	}
+
+	names := map[string]bool{}
+	for _, pe := range serverlessSpec.PrivateEndpoints {
+		if names[pe.Name] {
+			err = multierror.Append(err, fmt.Errorf("serverless private endpoint name %q is not unique", pe.Name))
+		}
+		names[pe.Name] = true
+	}
+
+	return err
+}
+
+func Project(project *mdbv1.AtlasProject) error {
+	var err error
+
+	if project.Spec.Name == "" {
+		err = multierror.Append(err, errors.New("spec.name must not be empty"))
+	}
+
+	for _, list := range project.Spec.ProjectIPAccessList {
+		if e := IPAccessList(list); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+
+	privateEndpoints := map[interface{}]bool{}
+	for _, pe := range project.Spec.PrivateEndpoints {
+		if privateEndpoints[pe.Identifier()] {
+			err = multierror.Append(err, fmt.Errorf("private endpoint for the provider %s and the region %s is specified more than once", pe.Provider, pe.Region))
+		}
+		privateEndpoints[pe.Identifier()] = true
+	}
+
+	return err
+}
+
+// ProjectUpdate validates the changes made to the existing AtlasProject.
+// The name can be changed only if the project is bound by ID, otherwise it's used to find the project in Atlas.
+func ProjectUpdate(oldProject, newProject *mdbv1.AtlasProject) error {
+	if oldProject.Spec.Name != newProject.Spec.Name && newProject.Spec.ProjectID == "" {
+		return fmt.Errorf("spec.name can't be changed from %q to %q unless the project is bound by spec.projectId", oldProject.Spec.Name, newProject.Spec.Name)
+	}
+	if newProject.Spec.ProjectID != "" && oldProject.Status.ID != "" && newProject.Spec.ProjectID != oldProject.Status.ID {
+		return fmt.Errorf("spec.projectId %q doesn't match the ID %q of the project the resource is bound to", newProject.Spec.ProjectID, oldProject.Status.ID)
+	}
+	return nil
+}
+
+// IPAccessList performs validation of the IP access list. Note, that we intentionally don't validate
+// IP addresses or CIDR blocks - this will be done by Atlas. But we need to validate the timestamp as we use it to filter
+// active and expired ip access lists.
+func IPAccessList(list project.IPAccessList) error {
+	if list.DeleteAfterDate != "" {
+		_, err := timeutil.ParseISO8601(list.DeleteAfterDate)
+		if err != nil {
+			return err
+		}
+	}
+	if list.Source != nil {
+		return ipAccessListSource(list)
+	}
+	onlyOneSpecified := onlyOneSpecified(list.AwsSecurityGroup, list.CIDRBlock, list.IPAddress)
+	allSpecified := isNotEmpty(list.AwsSecurityGroup) && isNotEmpty(list.CIDRBlock) && isNotEmpty(list.IPAddress)
+	if !onlyOneSpecified || allSpecified {
+		return errors.New("only one of the 'awsSecurityGroup', 'cidrBlock', 'ipAddress' or 'source' is required be specified")
+	}
+	return nil
+}
+
+func ipAccessListSource(list project.IPAccessList) error {
+	if isNotEmpty(list.AwsSecurityGroup) || isNotEmpty(list.CIDRBlock) || isNotEmpty(list.IPAddress) {
+		return errors.New("only one of the 'awsSecurityGroup', 'cidrBlock', 'ipAddress' or 'source' is required be specified")
+	}
+	if (list.Source.NodeSelector == nil) == (list.Source.ConfigMap == "") {
+		return errors.New("only one of the 'source.nodeSelector' or 'source.configMap' is required be specified")
+	}
+	if list.Source.NodeSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(list.Source.NodeSelector); err != nil {
+			return fmt.Errorf("invalid 'source.nodeSelector': %w", err)
+		}
+	}
+	return nil
+}
+
+// minPasswordRotationInterval protects the Atlas users from being updated (and the clusters from applying the changes)
+// too often
+const minPasswordRotationInterval = time.Hour
+
+func DatabaseUser(user *mdbv1.AtlasDatabaseUser) error {
+	var err error
+
+	if user.Spec.Username == "" {
+		err = multierror.Append(err, errors.New("spec.username must not be empty"))
+	}
+
+	if user.Spec.DeleteAfterDate != "" {
+		if _, e := timeutil.ParseISO8601(user.Spec.DeleteAfterDate); e != nil {
+			err = multierror.Append(err, fmt.Errorf("spec.deleteAfterDate is not a valid ISO 8601 date: %w", e))
+		}
+	}
+
+	if user.Spec.X509Type != "" && !x509Types[user.Spec.X509Type] {
+		err = multierror.Append(err, fmt.Errorf("spec.x509Type must be one of NONE, MANAGED or CUSTOMER, got %q", user.Spec.X509Type))
+	}
+
+	for _, role := range user.Spec.Roles {
+		if e := databaseUserRole(role); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+
+	if user.Spec.PasswordRotation != nil {
+		if !user.UsesPassword() {
+			err = multierror.Append(err, errors.New("spec.passwordRotation can't be used for the X.509 users"))
+		}
+		if user.Spec.PasswordRotation.Interval.Duration < minPasswordRotationInterval {
+			err = multierror.Append(err, fmt.Errorf("spec.passwordRotation.interval must be at least %s, got %s", minPasswordRotationInterval, user.Spec.PasswordRotation.Interval.Duration))
+		}
+	}
+
+	for _, scope := range user.Spec.Scopes {
+		if scope.Name == "" {
+			err = multierror.Append(err, errors.New("spec.scopes[].name must not be empty"))
+		}
+	}
+
+	return err
+}
+
+func databaseUserRole(role mdbv1.RoleSpec) error {
+	if role.RoleName == "" {
+		return errors.New("spec.roles[].roleName must not be empty")
+	}
+	if role.DatabaseName == "" {
+		return fmt.Errorf("spec.roles[].databaseName must not be empty for the role %q", role.RoleName)
+	}
+	if adminOnlyRoles[role.RoleName] && role.DatabaseName != "admin" {
+		return fmt.Errorf("the role %q can be granted only on the 'admin' database, got %q", role.RoleName, role.DatabaseName)
+	}
+	return nil
+}
+
+// maxAccessDuration is the longest access the AtlasAccessRequest can grant as Atlas deletes the temporary database users
+// and the IP Access List entries within one week.
+const maxAccessDuration = 7 * 24 * time.Hour
+
+func AccessRequest(request *mdbv1.AtlasAccessRequest) error {
+	var err error
+
+	if request.Spec.Project.Name == "" {
+		err = multierror.Append(err, errors.New("spec.projectRef.name must not be empty"))
+	}
+
+	if !isIPOrCIDR(request.Spec.RequesterIP) {
+		err = multierror.Append(err, fmt.Errorf("spec.requesterIp must be an IP address or a CIDR block, got %q", request.Spec.RequesterIP))
+	}
+
+	if len(request.Spec.Roles) == 0 {
+		err = multierror.Append(err, errors.New("spec.roles must not be empty"))
+	}
+	for _, role := range request.Spec.Roles {
+		if e := databaseUserRole(role); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+
+	if request.Spec.Duration.Duration <= 0 || request.Spec.Duration.Duration > maxAccessDuration {
+		err = multierror.Append(err, fmt.Errorf("spec.duration must be positive and must not exceed %s, got %s", maxAccessDuration, request.Spec.Duration.Duration))
+	}
+
+	return err
+}
+
+func isIPOrCIDR(address string) bool {
+	if _, _, err := net.ParseCIDR(address); err == nil {
+		return true
+	}
+	return net.ParseIP(address) != nil
+}
+
+func BackupPolicy(policy *mdbv1.AtlasBackupPolicy) error {
+	var err error
+
+	frequencies := map[string]bool{}
+	for _, item := range policy.Spec.Items {
+		if (item.FrequencyType == "hourly" || item.FrequencyType == "daily") && frequencies[item.FrequencyType] {
+			err = multierror.Append(err, fmt.Errorf("only one %s backup policy item can be specified", item.FrequencyType))
+		}
+		frequencies[item.FrequencyType] = true
+
+		if item.RetentionValue <= 0 {
+			err = multierror.Append(err, fmt.Errorf("retentionValue must be positive for the %s backup policy item", item.FrequencyType))
+		}
+	}
+
+	return err
+}
+
+func BackupSchedule(schedule *mdbv1.AtlasBackupSchedule) error {
+	if schedule.Spec.PolicyRef.Name == "" {
+		return errors.New("spec.policy.name must not be empty")
+	}
 	return nil
 }
 
@@ -59,3 +402,24 @@ func allAreNil(values ...interface{}) bool {
 func moreThanOneIsNonNil(values ...interface{}) bool {
 	return getNonNilCount(values...) > 1
 }
+
+func onlyOneSpecified(values ...string) bool {
+	found := false
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+
+		if found {
+			return false
+		}
+
+		found = true
+	}
+
+	return found
+}
+
+func isNotEmpty(s string) bool {
+	return s != ""
+}
@@ -2,10 +2,13 @@ package validate
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
 )
 
 func TestClusterValidation(t *testing.T) {
@@ -36,6 +39,55 @@ func TestClusterValidation(t *testing.T) {
 			}}
 			assert.Error(t, ClusterSpec(spec))
 		})
+		t.Run("Shared instance size with a dedicated provider", func(t *testing.T) {
+			spec := mdbv1.AtlasClusterSpec{ClusterSpec: &mdbv1.ClusterSpec{
+				ProviderSettings: &mdbv1.ProviderSettingsSpec{
+					InstanceSizeName: "M0",
+					ProviderName:     "AWS",
+				},
+			}}
+			assert.Error(t, ClusterSpec(spec))
+		})
+		t.Run("Advanced cluster instance sizes not matching the provider", func(t *testing.T) {
+			testCases := []struct {
+				name         string
+				regionConfig mdbv1.AdvancedRegionConfig
+			}{
+				{"Shared electable instance size with AWS", mdbv1.AdvancedRegionConfig{ProviderName: "AWS", ElectableSpecs: &mdbv1.Specs{InstanceSize: "M0"}}},
+				{"Dedicated electable instance size with TENANT", mdbv1.AdvancedRegionConfig{ProviderName: "TENANT", BackingProviderName: "AWS", ElectableSpecs: &mdbv1.Specs{InstanceSize: "M10"}}},
+				{"Shared read-only instance size with GCP", mdbv1.AdvancedRegionConfig{ProviderName: "GCP", ElectableSpecs: &mdbv1.Specs{InstanceSize: "M10"}, ReadOnlySpecs: &mdbv1.Specs{InstanceSize: "M2"}}},
+				{"Shared analytics instance size with AZURE", mdbv1.AdvancedRegionConfig{ProviderName: "AZURE", ElectableSpecs: &mdbv1.Specs{InstanceSize: "M10"}, AnalyticsSpecs: &mdbv1.Specs{InstanceSize: "M5"}}},
+			}
+			for _, tc := range testCases {
+				t.Run(tc.name, func(t *testing.T) {
+					regionConfig := tc.regionConfig
+					spec := mdbv1.AtlasClusterSpec{AdvancedClusterSpec: &mdbv1.AdvancedClusterSpec{
+						ReplicationSpecs: []*mdbv1.AdvancedReplicationSpec{{RegionConfigs: []*mdbv1.AdvancedRegionConfig{&regionConfig}}},
+					}}
+					assert.Error(t, ClusterSpec(spec))
+				})
+			}
+		})
+		t.Run("Serverless private endpoints with unsupported backing provider", func(t *testing.T) {
+			spec := mdbv1.AtlasClusterSpec{ServerlessSpec: &mdbv1.ServerlessSpec{
+				ProviderSettings: &mdbv1.ProviderSettingsSpec{
+					ProviderName:        "SERVERLESS",
+					BackingProviderName: "GCP",
+				},
+				PrivateEndpoints: []mdbv1.ServerlessPrivateEndpoint{{Name: "pe1"}},
+			}}
+			assert.Error(t, ClusterSpec(spec))
+		})
+		t.Run("Serverless private endpoints with duplicate names", func(t *testing.T) {
+			spec := mdbv1.AtlasClusterSpec{ServerlessSpec: &mdbv1.ServerlessSpec{
+				ProviderSettings: &mdbv1.ProviderSettingsSpec{
+					ProviderName:        "SERVERLESS",
+					BackingProviderName: "AWS",
+				},
+				PrivateEndpoints: []mdbv1.ServerlessPrivateEndpoint{{Name: "pe1"}, {Name: "pe1"}},
+			}}
+			assert.Error(t, ClusterSpec(spec))
+		})
 	})
 	t.Run("Valid cluster specs", func(t *testing.T) {
 		t.Run("Advanced cluster spec specified", func(t *testing.T) {
@@ -43,6 +95,15 @@ func TestClusterValidation(t *testing.T) {
 			assert.NoError(t, ClusterSpec(spec))
 			assert.Nil(t, ClusterSpec(spec))
 		})
+		t.Run("Advanced cluster instance sizes matching the provider", func(t *testing.T) {
+			spec := mdbv1.AtlasClusterSpec{AdvancedClusterSpec: &mdbv1.AdvancedClusterSpec{
+				ReplicationSpecs: []*mdbv1.AdvancedReplicationSpec{{RegionConfigs: []*mdbv1.AdvancedRegionConfig{
+					{ProviderName: "TENANT", BackingProviderName: "AWS", ElectableSpecs: &mdbv1.Specs{InstanceSize: "M0"}},
+					{ProviderName: "AWS", ElectableSpecs: &mdbv1.Specs{InstanceSize: "M10"}, AnalyticsSpecs: &mdbv1.Specs{InstanceSize: "M30"}},
+				}}},
+			}}
+			assert.NoError(t, ClusterSpec(spec))
+		})
 		t.Run("Regular cluster specs specified", func(t *testing.T) {
 			spec := mdbv1.AtlasClusterSpec{AdvancedClusterSpec: nil, ClusterSpec: &mdbv1.ClusterSpec{}}
 			assert.NoError(t, ClusterSpec(spec))
@@ -58,5 +119,233 @@ func TestClusterValidation(t *testing.T) {
 			assert.NoError(t, ClusterSpec(spec))
 			assert.Nil(t, ClusterSpec(spec))
 		})
+
+		t.Run("Serverless instance with private endpoints", func(t *testing.T) {
+			spec := mdbv1.AtlasClusterSpec{ServerlessSpec: &mdbv1.ServerlessSpec{
+				ProviderSettings: &mdbv1.ProviderSettingsSpec{
+					ProviderName:        "SERVERLESS",
+					BackingProviderName: "AZURE",
+				},
+				PrivateEndpoints: []mdbv1.ServerlessPrivateEndpoint{{Name: "pe1"}, {Name: "pe2"}},
+			}}
+			assert.NoError(t, ClusterSpec(spec))
+		})
+	})
+}
+
+func TestIPAccessList(t *testing.T) {
+	testCases := []struct {
+		in                 project.IPAccessList
+		errorExpectedRegex string
+	}{
+		// Date
+		{in: project.IPAccessList{DeleteAfterDate: "incorrect", IPAddress: "192.158.0.0"}, errorExpectedRegex: "cannot parse"},
+		{in: project.IPAccessList{DeleteAfterDate: "2020/01/02T15:04:05-0700", IPAddress: "192.158.0.0"}, errorExpectedRegex: "cannot parse"},
+		{in: project.IPAccessList{DeleteAfterDate: "2020-01-02T15:04:05-07000", IPAddress: "192.158.0.0"}, errorExpectedRegex: "cannot parse"},
+		{in: project.IPAccessList{DeleteAfterDate: "2020-11-02T20:04:05-0700", IPAddress: "192.158.0.0"}},
+		{in: project.IPAccessList{DeleteAfterDate: "2020-11-02T20:04:05+03", IPAddress: "192.158.0.0"}},
+		{in: project.IPAccessList{DeleteAfterDate: "2011-01-02T15:04:05", IPAddress: "192.158.0.0"}},
+
+		// Only one other field is allowed (and required)
+		{in: project.IPAccessList{DeleteAfterDate: "2011-01-02T15:04:05", IPAddress: "192.158.0.0", CIDRBlock: "203.0.113.0/24"}, errorExpectedRegex: "only one of the "},
+		{in: project.IPAccessList{IPAddress: "192.158.0.0", AwsSecurityGroup: "sg-0026348ec11780bd1"}, errorExpectedRegex: "only one of the "},
+		{in: project.IPAccessList{CIDRBlock: "203.0.113.0/24", AwsSecurityGroup: "sg-0026348ec11780bd1"}, errorExpectedRegex: "only one of the "},
+		{in: project.IPAccessList{CIDRBlock: "203.0.113.0/24", AwsSecurityGroup: "sg-0026348ec11780bd1", IPAddress: "192.158.0.0"}, errorExpectedRegex: "only one of the "},
+		{in: project.IPAccessList{}, errorExpectedRegex: "only one of the "},
+
+		// Source
+		{in: project.IPAccessList{Source: &project.IPAccessListSource{ConfigMap: "egress-ips"}}},
+		{in: project.IPAccessList{Source: &project.IPAccessListSource{NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "egress"}}}}},
+		{in: project.IPAccessList{IPAddress: "192.158.0.0", Source: &project.IPAccessListSource{ConfigMap: "egress-ips"}}, errorExpectedRegex: "only one of the "},
+		{in: project.IPAccessList{Source: &project.IPAccessListSource{}}, errorExpectedRegex: "only one of the 'source.nodeSelector'"},
+		{in: project.IPAccessList{Source: &project.IPAccessListSource{ConfigMap: "egress-ips", NodeSelector: &metav1.LabelSelector{}}}, errorExpectedRegex: "only one of the 'source.nodeSelector'"},
+		{in: project.IPAccessList{Source: &project.IPAccessListSource{NodeSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "pool", Operator: "Unknown"}}}}}, errorExpectedRegex: "invalid 'source.nodeSelector'"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run("", func(t *testing.T) {
+			err := IPAccessList(testCase.in)
+			if testCase.errorExpectedRegex != "" {
+				assert.Error(t, err)
+				assert.Regexp(t, testCase.errorExpectedRegex, err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestClusterUpdate(t *testing.T) {
+	t.Run("Changing the cluster name", func(t *testing.T) {
+		oldCluster := mdbv1.DefaultAWSCluster("default", "my-project")
+		newCluster := mdbv1.DefaultAWSCluster("default", "my-project").WithAtlasName("other-name")
+		assert.Error(t, ClusterUpdate(oldCluster, newCluster))
+	})
+	t.Run("Changing the project reference", func(t *testing.T) {
+		oldCluster := mdbv1.DefaultAWSCluster("default", "my-project")
+		newCluster := mdbv1.DefaultAWSCluster("default", "other-project")
+		assert.Error(t, ClusterUpdate(oldCluster, newCluster))
+	})
+	t.Run("Converting a regular cluster to the serverless instance", func(t *testing.T) {
+		oldCluster := mdbv1.DefaultAWSCluster("default", "my-project")
+		newCluster := mdbv1.DefaultAWSCluster("default", "my-project")
+		newCluster.Spec.ClusterSpec = nil
+		newCluster.Spec.ServerlessSpec = &mdbv1.ServerlessSpec{Name: oldCluster.GetClusterName()}
+		assert.Error(t, ClusterUpdate(oldCluster, newCluster))
+	})
+	t.Run("Changing the serverless region", func(t *testing.T) {
+		newServerless := func(region string) *mdbv1.AtlasCluster {
+			cluster := mdbv1.DefaultAWSCluster("default", "my-project")
+			cluster.Spec.ClusterSpec = nil
+			cluster.Spec.ServerlessSpec = &mdbv1.ServerlessSpec{
+				Name: "serverless",
+				ProviderSettings: &mdbv1.ProviderSettingsSpec{
+					ProviderName:        "SERVERLESS",
+					BackingProviderName: "AWS",
+					RegionName:          region,
+				},
+			}
+			return cluster
+		}
+		assert.Error(t, ClusterUpdate(newServerless("US_EAST_1"), newServerless("US_WEST_2")))
+		assert.NoError(t, ClusterUpdate(newServerless("US_EAST_1"), newServerless("US_EAST_1")))
+	})
+	t.Run("Changing the instance size", func(t *testing.T) {
+		oldCluster := mdbv1.DefaultAWSCluster("default", "my-project")
+		newCluster := mdbv1.DefaultAWSCluster("default", "my-project").WithInstanceSize("M30")
+		assert.NoError(t, ClusterUpdate(oldCluster, newCluster))
+	})
+}
+
+func TestProject(t *testing.T) {
+	t.Run("Valid project", func(t *testing.T) {
+		p := mdbv1.NewProject("default", "my-project", "Test Project").
+			WithIPAccessList(project.IPAccessList{CIDRBlock: "203.0.113.0/24"})
+		assert.NoError(t, Project(p))
+	})
+	t.Run("Empty name", func(t *testing.T) {
+		assert.Error(t, Project(mdbv1.NewProject("default", "my-project", "")))
+	})
+	t.Run("Invalid IP access list", func(t *testing.T) {
+		p := mdbv1.NewProject("default", "my-project", "Test Project").
+			WithIPAccessList(project.IPAccessList{CIDRBlock: "203.0.113.0/24", IPAddress: "192.158.0.0"})
+		assert.Error(t, Project(p))
+	})
+	t.Run("Duplicate private endpoints", func(t *testing.T) {
+		p := mdbv1.NewProject("default", "my-project", "Test Project")
+		p.Spec.PrivateEndpoints = []project.PrivateEndpoint{
+			{Provider: "AWS", Region: "us-east-1"},
+			{Provider: "AWS", Region: "us-east-1"},
+		}
+		assert.Error(t, Project(p))
+	})
+	t.Run("Changing the name", func(t *testing.T) {
+		oldProject := mdbv1.NewProject("default", "my-project", "Test Project")
+		newProject := mdbv1.NewProject("default", "my-project", "Other Project")
+		assert.Error(t, ProjectUpdate(oldProject, newProject))
+		assert.NoError(t, ProjectUpdate(oldProject, oldProject))
+	})
+	t.Run("Changing the name of the project bound by ID", func(t *testing.T) {
+		oldProject := mdbv1.NewProject("default", "my-project", "Test Project").WithProjectID("5f5f5f5f5f5f5f5f5f5f5f5f")
+		newProject := mdbv1.NewProject("default", "my-project", "Other Project").WithProjectID("5f5f5f5f5f5f5f5f5f5f5f5f")
+		assert.NoError(t, ProjectUpdate(oldProject, newProject))
+	})
+	t.Run("Changing the project ID", func(t *testing.T) {
+		oldProject := mdbv1.NewProject("default", "my-project", "Test Project")
+		oldProject.Status.ID = "5f5f5f5f5f5f5f5f5f5f5f5f"
+		assert.NoError(t, ProjectUpdate(oldProject, oldProject.DeepCopy().WithProjectID("5f5f5f5f5f5f5f5f5f5f5f5f")))
+		assert.Error(t, ProjectUpdate(oldProject, oldProject.DeepCopy().WithProjectID("6a6a6a6a6a6a6a6a6a6a6a6a")))
+	})
+}
+
+func TestDatabaseUser(t *testing.T) {
+	testCases := []struct {
+		name               string
+		user               *mdbv1.AtlasDatabaseUser
+		errorExpectedRegex string
+	}{
+		{name: "Valid user", user: mdbv1.DefaultDBUser("default", "theuser", "my-project")},
+		{name: "Empty username", user: mdbv1.DefaultDBUser("default", "", "my-project"), errorExpectedRegex: "spec.username"},
+		{name: "Invalid deleteAfterDate", user: mdbv1.DefaultDBUser("default", "theuser", "my-project").WithDeleteAfterDate("tomorrow"), errorExpectedRegex: "spec.deleteAfterDate"},
+		{name: "Admin only role", user: mdbv1.DefaultDBUser("default", "theuser", "my-project").WithRole("readWriteAnyDatabase", "test", ""), errorExpectedRegex: "'admin' database"},
+		{name: "Empty role database", user: mdbv1.DefaultDBUser("default", "theuser", "my-project").WithRole("read", "", ""), errorExpectedRegex: "databaseName must not be empty"},
+		{name: "Empty scope name", user: mdbv1.DefaultDBUser("default", "theuser", "my-project").WithScope(mdbv1.ClusterScopeType, ""), errorExpectedRegex: "spec.scopes"},
+		{name: "Password rotation", user: mdbv1.DefaultDBUser("default", "theuser", "my-project").WithPasswordRotation(30 * 24 * time.Hour)},
+		{name: "Too frequent password rotation", user: mdbv1.DefaultDBUser("default", "theuser", "my-project").WithPasswordRotation(time.Minute), errorExpectedRegex: "spec.passwordRotation.interval"},
+		{name: "Password rotation for X.509 user", user: x509User().WithPasswordRotation(24 * time.Hour), errorExpectedRegex: "X.509"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := DatabaseUser(testCase.user)
+			if testCase.errorExpectedRegex != "" {
+				assert.Error(t, err)
+				assert.Regexp(t, testCase.errorExpectedRegex, err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func x509User() *mdbv1.AtlasDatabaseUser {
+	user := mdbv1.DefaultDBUser("default", "theuser", "my-project")
+	user.Spec.X509Type = "MANAGED"
+	return user
+}
+
+func TestAccessRequest(t *testing.T) {
+	request := func(requesterIP string, duration time.Duration) *mdbv1.AtlasAccessRequest {
+		return mdbv1.NewAccessRequest("default", "debugging", "my-project", requesterIP, duration)
+	}
+	testCases := []struct {
+		name               string
+		request            *mdbv1.AtlasAccessRequest
+		errorExpectedRegex string
+	}{
+		{name: "Valid request", request: request("192.0.2.10", 2*time.Hour).WithRole("read", "test", "")},
+		{name: "CIDR block", request: request("192.0.2.0/24", 2*time.Hour).WithRole("read", "test", "")},
+		{name: "Invalid requester IP", request: request("my-laptop", 2*time.Hour).WithRole("read", "test", ""), errorExpectedRegex: "spec.requesterIp"},
+		{name: "No roles", request: request("192.0.2.10", 2*time.Hour), errorExpectedRegex: "spec.roles"},
+		{name: "Admin only role", request: request("192.0.2.10", 2*time.Hour).WithRole("readAnyDatabase", "test", ""), errorExpectedRegex: "'admin' database"},
+		{name: "Zero duration", request: request("192.0.2.10", 0).WithRole("read", "test", ""), errorExpectedRegex: "spec.duration"},
+		{name: "Duration longer than a week", request: request("192.0.2.10", 8*24*time.Hour).WithRole("read", "test", ""), errorExpectedRegex: "spec.duration"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := AccessRequest(testCase.request)
+			if testCase.errorExpectedRegex != "" {
+				assert.Error(t, err)
+				assert.Regexp(t, testCase.errorExpectedRegex, err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBackupPolicy(t *testing.T) {
+	t.Run("Valid policy", func(t *testing.T) {
+		policy := &mdbv1.AtlasBackupPolicy{Spec: mdbv1.AtlasBackupPolicySpec{Items: []mdbv1.AtlasBackupPolicyItem{
+			{FrequencyType: "hourly", RetentionValue: 2},
+			{FrequencyType: "daily", RetentionValue: 7},
+			{FrequencyType: "weekly", RetentionValue: 4},
+			{FrequencyType: "weekly", RetentionValue: 4},
+		}}}
+		assert.NoError(t, BackupPolicy(policy))
+	})
+	t.Run("Duplicate daily items", func(t *testing.T) {
+		policy := &mdbv1.AtlasBackupPolicy{Spec: mdbv1.AtlasBackupPolicySpec{Items: []mdbv1.AtlasBackupPolicyItem{
+			{FrequencyType: "daily", RetentionValue: 2},
+			{FrequencyType: "daily", RetentionValue: 7},
+		}}}
+		assert.Error(t, BackupPolicy(policy))
+	})
+	t.Run("Non positive retention", func(t *testing.T) {
+		policy := &mdbv1.AtlasBackupPolicy{Spec: mdbv1.AtlasBackupPolicySpec{Items: []mdbv1.AtlasBackupPolicyItem{
+			{FrequencyType: "monthly", RetentionValue: 0},
+		}}}
+		assert.Error(t, BackupPolicy(policy))
 	})
 }
@@ -6,13 +6,36 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
 )
 
+// triggeringAnnotations are the annotations changing the way the resource is reconciled: the migration to the advanced
+// cluster, the approval of the cluster changes, the reconciliation policy (e.g. the resource observed is taken under
+// management), the adoption of the existing Atlas resource and the adoption policy
+var triggeringAnnotations = []string{
+	customresource.MigrateToAdvancedAnnotation,
+	customresource.ChangesApprovedAnnotation,
+	customresource.ReconciliationPolicyAnnotation,
+	customresource.AdoptAnnotation,
+	customresource.AdoptionPolicyAnnotation,
+}
+
 // CommonPredicates returns the predicate which filter out the changes done to any field except for spec (e.g. status)
 // Also we should reconcile if finalizers have changed (see https://blog.openshift.com/kubernetes-operators-best-practices/)
+// and if annotations have changed for the resource being deleted (the deletion may be waiting for the confirmation annotation)
+// or any of the annotations changing the way the resource is reconciled have changed (see triggeringAnnotations)
 func CommonPredicates() predicate.Funcs {
 	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
+			if !e.ObjectNew.GetDeletionTimestamp().IsZero() && !reflect.DeepEqual(e.ObjectNew.GetAnnotations(), e.ObjectOld.GetAnnotations()) {
+				return true
+			}
+			for _, annotation := range triggeringAnnotations {
+				if e.ObjectNew.GetAnnotations()[annotation] != e.ObjectOld.GetAnnotations()[annotation] {
+					return true
+				}
+			}
 			if e.ObjectOld.GetGeneration() == e.ObjectNew.GetGeneration() && reflect.DeepEqual(e.ObjectNew.GetFinalizers(), e.ObjectOld.GetFinalizers()) {
 				return false
 			}
@@ -0,0 +1,31 @@
+package watch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+)
+
+func TestCommonPredicates(t *testing.T) {
+	update := func(oldAnnotations, newAnnotations map[string]string) event.UpdateEvent {
+		oldCluster := &mdbv1.AtlasCluster{ObjectMeta: metav1.ObjectMeta{Generation: 1, Annotations: oldAnnotations}}
+		newCluster := &mdbv1.AtlasCluster{ObjectMeta: metav1.ObjectMeta{Generation: 1, Annotations: newAnnotations}}
+		return event.UpdateEvent{ObjectOld: oldCluster, ObjectNew: newCluster}
+	}
+	predicates := CommonPredicates()
+
+	t.Run("Adoption is confirmed", func(t *testing.T) {
+		assert.True(t, predicates.Update(update(nil, map[string]string{customresource.AdoptAnnotation: customresource.AdoptTrue})))
+	})
+	t.Run("Adoption policy is removed", func(t *testing.T) {
+		assert.True(t, predicates.Update(update(map[string]string{customresource.AdoptionPolicyAnnotation: customresource.AdoptionPolicyExistingOnly}, nil)))
+	})
+	t.Run("Other annotation is changed", func(t *testing.T) {
+		assert.False(t, predicates.Update(update(nil, map[string]string{"team": "data"})))
+	})
+}
@@ -1,6 +1,8 @@
 package watch
 
 import (
+	"sync"
+
 	"go.uber.org/zap"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -16,9 +18,16 @@ type ResourceWatcher struct {
 	WatchedResources map[WatchedObject]map[client.ObjectKey]bool
 }
 
+// watchedResourcesMu guards the WatchedResources maps as they are updated by the concurrent reconciliations and read
+// by the event handlers (see ResourcesHandler).
+var watchedResourcesMu sync.RWMutex
+
 // EnsureResourcesAreWatched registers a dependant for the watched objects.
 // This will let the controller to react on the events for the watched objects and trigger reconciliation for dependants.
 func (r ResourceWatcher) EnsureResourcesAreWatched(dependant client.ObjectKey, resourceKind string, log *zap.SugaredLogger, watchedObjectsKeys ...client.ObjectKey) {
+	watchedResourcesMu.Lock()
+	defer watchedResourcesMu.Unlock()
+
 	for _, watchedObjectKey := range watchedObjectsKeys {
 		r.addWatchedResourceIfNotAdded(watchedObjectKey, resourceKind, dependant, log)
 	}
@@ -28,6 +37,9 @@ func (r ResourceWatcher) EnsureResourcesAreWatched(dependant client.ObjectKey, r
 }
 
 func (r ResourceWatcher) EnsureMultiplesResourcesAreWatched(dependant client.ObjectKey, log *zap.SugaredLogger, resources ...WatchedObject) {
+	watchedResourcesMu.Lock()
+	defer watchedResourcesMu.Unlock()
+
 	for _, res := range resources {
 		r.addWatchedResourceIfNotAdded(res.Resource, res.ResourceKind, dependant, log)
 		log.Debugf("resource watcher: watching %v to trigger reconciliation for %v", res.Resource, dependant)
@@ -47,19 +59,31 @@ func (r *ResourceWatcher) addWatchedResourceIfNotAdded(watchedObjectKey client.O
 	r.WatchedResources[key][dependentResourceNsName] = true
 }
 
+// cleanNonWatchedResources removes the dependant from the watched resources of the same kind which are not referenced
+// any more. The resources of other kinds are left intact as the dependant may watch them as well (e.g. the AtlasCluster
+// watches both the connection Secret and the backup resources).
 func (r ResourceWatcher) cleanNonWatchedResources(dependant client.ObjectKey, resourceKind string, watchedKeys []client.ObjectKey) {
 	for k, v := range r.WatchedResources {
-		if !contains(watchedKeys, k.Resource) || k.ResourceKind != resourceKind {
+		if k.ResourceKind == resourceKind && !contains(watchedKeys, k.Resource) {
 			delete(v, dependant)
 		}
 	}
 }
 
+// cleanNonWatchedResourcesExceptMultiple removes the dependant from the watched resources of the kinds passed which
+// are not referenced any more.
 func (r ResourceWatcher) cleanNonWatchedResourcesExceptMultiple(dependant client.ObjectKey, resources ...WatchedObject) {
+	kinds := map[string]bool{}
+	for _, res := range resources {
+		kinds[res.ResourceKind] = true
+	}
 	for k, v := range r.WatchedResources {
+		if !kinds[k.ResourceKind] {
+			continue
+		}
 		toRemove := true
 		for _, res := range resources {
-			if res.Resource == k.Resource {
+			if res == k {
 				toRemove = false
 			}
 		}
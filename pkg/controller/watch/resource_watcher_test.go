@@ -62,5 +62,28 @@ func TestEnsureResourcesAreWatched(t *testing.T) {
 		}
 		assert.Equal(t, expectedWatched, watcher.WatchedResources)
 	})
-	// TODO: add test for different kind of resources
+	t.Run("Resource watches resources of different kinds", func(t *testing.T) {
+		watcher := NewResourceWatcher()
+		cluster := kube.ObjectKey("test", "cluster")
+		connectionSecret := kube.ObjectKey("test", "connectionSecret")
+		connectionSecret2 := kube.ObjectKey("test", "connectionSecret2")
+		schedule := kube.ObjectKey("test", "schedule")
+		policy := kube.ObjectKey("test", "policy")
+
+		watcher.EnsureResourcesAreWatched(cluster, "Secret", zap.S(), connectionSecret)
+		watcher.EnsureMultiplesResourcesAreWatched(cluster, zap.S(),
+			WatchedObject{ResourceKind: "AtlasBackupSchedule", Resource: schedule},
+			WatchedObject{ResourceKind: "AtlasBackupPolicy", Resource: policy},
+		)
+		// Changing the Secret doesn't affect the backup resources
+		watcher.EnsureResourcesAreWatched(cluster, "Secret", zap.S(), connectionSecret2)
+
+		expectedWatched := map[WatchedObject]map[client.ObjectKey]bool{
+			{ResourceKind: "Secret", Resource: connectionSecret}:      {},
+			{ResourceKind: "Secret", Resource: connectionSecret2}:     {cluster: true},
+			{ResourceKind: "AtlasBackupSchedule", Resource: schedule}: {cluster: true},
+			{ResourceKind: "AtlasBackupPolicy", Resource: policy}:     {cluster: true},
+		}
+		assert.Equal(t, expectedWatched, watcher.WatchedResources)
+	})
 }
@@ -37,6 +37,10 @@ func NewSecretHandler(tracked map[WatchedObject]map[client.ObjectKey]bool) *Reso
 	return &ResourcesHandler{ResourceKind: "Secret", TrackedResources: tracked}
 }
 
+func NewConfigMapHandler(tracked map[WatchedObject]map[client.ObjectKey]bool) *ResourcesHandler {
+	return &ResourcesHandler{ResourceKind: "ConfigMap", TrackedResources: tracked}
+}
+
 func NewBackupScheduleHandler(tracked map[WatchedObject]map[client.ObjectKey]bool) *ResourcesHandler {
 	return &ResourcesHandler{ResourceKind: "AtlasBackupSchedule", TrackedResources: tracked}
 }
@@ -47,9 +51,10 @@ func NewBackupPolicyHandler(tracked map[WatchedObject]map[client.ObjectKey]bool)
 
 // Create handles the Create event for the resource.
 // Note that we implement Create in addition to Update to be able to handle cases when config map or secret is deleted
-// and then created again.
+// and then created again (or created after the dependant resource, like the connection Secret).
+// The kind of the typed objects received from the informers is empty so the handler resourceKind is used.
 func (c *ResourcesHandler) Create(e event.CreateEvent, q workqueue.RateLimitingInterface) {
-	c.doHandle(e.Object.GetNamespace(), e.Object.GetName(), e.Object.GetObjectKind().GroupVersionKind().Kind, q)
+	c.doHandle(e.Object.GetNamespace(), e.Object.GetName(), c.ResourceKind, q)
 }
 
 func (c *ResourcesHandler) Update(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
@@ -79,6 +84,8 @@ func (c *ResourcesHandler) doHandle(namespace, name, kind string, q workqueue.Ra
 		ResourceKind: kind,
 		Resource:     types.NamespacedName{Name: name, Namespace: namespace},
 	}
+	watchedResourcesMu.RLock()
+	defer watchedResourcesMu.RUnlock()
 	for k := range c.TrackedResources[watchedResource] {
 		zap.S().Infof("%s has been modified -> triggering reconciliation for the %s", watchedResource, k)
 		q.Add(reconcile.Request{NamespacedName: k})
@@ -1,12 +1,15 @@
 package workflow
 
 import (
+	"context"
+
 	"go.mongodb.org/atlas/mongodbatlas"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/metrics"
 )
 
 // Context is a container for some information that is needed on all levels of function calls during reconciliation.
@@ -15,15 +18,29 @@ import (
 type Context struct {
 	// Log is the root logger used in the reconciliation. Used just for convenience to avoid passing log to each
 	// method.
-	// Is not supposed to be mutated!
+	// Is not supposed to be mutated! (except for the StartSpan adding the tracing fields for the step)
 	Log *zap.SugaredLogger
 
+	// Context is the Go context of the reconciliation carrying the tracing span of the current step. It should be
+	// passed to the Atlas client calls so that the requests are traced as the part of the step.
+	Context context.Context
+
 	// Client is a mongodb atlas client used to make v1.0 API calls
 	Client mongodbatlas.Client
 
 	// Connection is an object encapsulating information about connecting to Atlas using API
 	Connection atlas.Connection
 
+	// PlanOnly indicates that the reconciliation must not make any changes in Atlas. Instead, the changes are recorded
+	// with PlanChange and reported in the status of the resource (see CompletePlan).
+	PlanOnly bool
+
+	// ObserveOnly indicates that the reconciliation must only report the state of the resource in Atlas and the
+	// drift from the spec. Implies PlanOnly, the changes recorded are reported as the drift (see CompletePlan).
+	ObserveOnly bool
+
+	plannedChanges []status.PlannedChange
+
 	status Status
 
 	// This is the condition happened the last (most of all it contains the most important information that needs
@@ -37,8 +54,9 @@ type Context struct {
 
 func NewContext(log *zap.SugaredLogger, conditions []status.Condition) *Context {
 	return &Context{
-		status: NewStatus(conditions),
-		Log:    log,
+		status:  NewStatus(conditions),
+		Log:     log,
+		Context: context.Background(),
 	}
 }
 
@@ -77,6 +95,10 @@ func (c *Context) SetConditionFromResult(conditionType status.ConditionType, res
 		Message: result.message,
 	})
 	c.lastConditionWarn = result.warning
+	if result.reason != "" {
+		metrics.ConditionReasons.WithLabelValues(string(conditionType), string(result.reason)).Inc()
+	}
+	c.recordResult(conditionType, result)
 	return c
 }
 
@@ -0,0 +1,100 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+)
+
+// The actions of the planned changes
+const (
+	PlanCreate = "Create"
+	PlanUpdate = "Update"
+	PlanDelete = "Delete"
+)
+
+// Planned indicates that the reconciliation in the plan mode cannot proceed further as the next steps depend on the
+// changes which were planned but not applied to Atlas (for example the cluster to be created).
+func Planned(message string) Result {
+	return Result{
+		terminated: true,
+		retry:      RetryPermanent,
+		reason:     ChangesPlanned,
+		message:    message,
+		warning:    false,
+	}
+}
+
+// UpdatePlanned is the result of the step which planned the update of the existing Atlas resource. The reconciliation
+// in the observe mode proceeds further to report the state of the resource while the plan mode stops as Planned does.
+func (c Context) UpdatePlanned(message string) Result {
+	if c.ObserveOnly {
+		return OK()
+	}
+	return Planned(message)
+}
+
+// PlanChange records the change the reconciliation would make in Atlas if it wasn't running in the plan mode.
+func (c *Context) PlanChange(action, resource, diff string) {
+	c.Log.Infow("Planned change", "action", action, "resource", resource, "diff", diff)
+	c.plannedChanges = append(c.plannedChanges, status.PlannedChange{Action: action, Resource: resource, Diff: diff})
+}
+
+func (c Context) PlannedChanges() []status.PlannedChange {
+	return c.plannedChanges
+}
+
+// CompletePlan publishes the changes planned by the reconciliation in the plan mode to the status of the resource.
+// The resource is reported as not ready if there are any changes planned, the event for the condition lists them.
+// In the observe mode the changes are reported as the drift of Atlas from the spec instead (see reportDrift).
+// Must be called after all the steps of the reconciliation, usually deferred right before the status update.
+func (c *Context) CompletePlan() {
+	if c.ObserveOnly {
+		c.reportDrift()
+		return
+	}
+	if !c.PlanOnly {
+		return
+	}
+	changes := c.plannedChanges
+	if changes == nil {
+		changes = []status.PlannedChange{}
+	}
+	c.EnsureStatusOption(status.PlannedChangesOption(changes))
+	if len(changes) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("The reconciliation is running in the plan mode, %d change(s) are not applied to Atlas: %s",
+		len(changes), changesSummary(changes))
+	c.SetConditionFromResult(status.ReadyType, Planned(message))
+}
+
+// reportDrift sets the DriftDetected condition listing the changes which would make Atlas match the spec.
+// The readiness of the resource is not affected: the resource is ready once its state is read from Atlas.
+func (c *Context) reportDrift() {
+	if len(c.plannedChanges) == 0 {
+		// The status is updated directly to keep the last condition reported in the event
+		c.status.EnsureCondition(status.Condition{Type: status.DriftDetectedType, Status: corev1.ConditionFalse})
+		return
+	}
+	message := fmt.Sprintf("Atlas differs from the spec, %d change(s) are required: %s", len(c.plannedChanges), changesSummary(c.plannedChanges))
+	c.EnsureCondition(status.Condition{
+		Type:    status.DriftDetectedType,
+		Status:  corev1.ConditionTrue,
+		Reason:  string(AtlasDiffersFromSpec),
+		Message: message,
+	})
+	c.lastConditionWarn = true
+}
+
+func changesSummary(changes []status.PlannedChange) string {
+	summary := make([]string, len(changes))
+	for i, change := range changes {
+		summary[i] = fmt.Sprintf("%s %s", change.Action, change.Resource)
+	}
+	return strings.Join(summary, "; ")
+}
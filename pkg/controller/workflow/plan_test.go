@@ -0,0 +1,100 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+)
+
+func TestPlanned(t *testing.T) {
+	result := Planned("the cluster is not created")
+	assert.False(t, result.IsOk())
+	assert.Equal(t, RetryPermanent, result.RetryClass())
+	assert.Equal(t, reconcile.Result{}, result.ReconcileResult())
+	assert.False(t, Context{PlanOnly: true}.UpdatePlanned("the cluster is not updated").IsOk())
+}
+
+func TestCompletePlan(t *testing.T) {
+	t.Run("Nothing is reported if not in the plan mode", func(t *testing.T) {
+		ctx := NewContext(zap.S(), []status.Condition{})
+		ctx.SetConditionTrue(status.ReadyType)
+		ctx.CompletePlan()
+
+		assert.Empty(t, ctx.StatusOptions())
+		assert.Equal(t, corev1.ConditionTrue, ctx.Conditions()[0].Status)
+	})
+	t.Run("No changes planned", func(t *testing.T) {
+		ctx := NewContext(zap.S(), []status.Condition{})
+		ctx.PlanOnly = true
+		ctx.SetConditionTrue(status.ReadyType)
+		ctx.CompletePlan()
+
+		assert.Equal(t, []status.PlannedChange{}, plannedChangesFromOptions(ctx))
+		assert.Equal(t, corev1.ConditionTrue, ctx.Conditions()[0].Status)
+	})
+	t.Run("Changes planned", func(t *testing.T) {
+		ctx := NewContext(zap.S(), []status.Condition{})
+		ctx.PlanOnly = true
+		ctx.PlanChange(PlanCreate, "cluster test", "+ name: test")
+		ctx.PlanChange(PlanDelete, "IP access list entry 10.0.0.1", "")
+		ctx.SetConditionTrue(status.ReadyType)
+		ctx.CompletePlan()
+
+		expected := []status.PlannedChange{
+			{Action: PlanCreate, Resource: "cluster test", Diff: "+ name: test"},
+			{Action: PlanDelete, Resource: "IP access list entry 10.0.0.1"},
+		}
+		assert.Equal(t, expected, ctx.PlannedChanges())
+		assert.Equal(t, expected, plannedChangesFromOptions(ctx))
+
+		ready := ctx.Conditions()[0]
+		assert.Equal(t, status.ReadyType, ready.Type)
+		assert.Equal(t, corev1.ConditionFalse, ready.Status)
+		assert.Equal(t, string(ChangesPlanned), ready.Reason)
+		assert.Equal(t, "The reconciliation is running in the plan mode, 2 change(s) are not applied to Atlas: Create cluster test; Delete IP access list entry 10.0.0.1", ready.Message)
+		assert.False(t, ctx.LastConditionWarn())
+	})
+}
+
+func TestReportDrift(t *testing.T) {
+	t.Run("No drift", func(t *testing.T) {
+		ctx := NewContext(zap.S(), []status.Condition{})
+		ctx.PlanOnly, ctx.ObserveOnly = true, true
+		ctx.SetConditionTrue(status.ReadyType)
+		assert.True(t, ctx.UpdatePlanned("not updated").IsOk())
+		ctx.CompletePlan()
+
+		assert.Empty(t, ctx.StatusOptions())
+		assert.Equal(t, status.ReadyType, ctx.LastCondition().Type)
+		assert.Equal(t, status.DriftDetectedType, ctx.Conditions()[1].Type)
+		assert.Equal(t, corev1.ConditionFalse, ctx.Conditions()[1].Status)
+	})
+	t.Run("Drift detected", func(t *testing.T) {
+		ctx := NewContext(zap.S(), []status.Condition{})
+		ctx.PlanOnly, ctx.ObserveOnly = true, true
+		ctx.PlanChange(PlanUpdate, "cluster test", "- M10\n+ M20")
+		ctx.SetConditionTrue(status.ReadyType)
+		ctx.CompletePlan()
+
+		assert.Empty(t, ctx.StatusOptions())
+		assert.Equal(t, corev1.ConditionTrue, ctx.Conditions()[0].Status)
+		drift := ctx.Conditions()[1]
+		assert.Equal(t, status.DriftDetectedType, drift.Type)
+		assert.Equal(t, corev1.ConditionTrue, drift.Status)
+		assert.Equal(t, string(AtlasDiffersFromSpec), drift.Reason)
+		assert.Equal(t, "Atlas differs from the spec, 1 change(s) are required: Update cluster test", drift.Message)
+	})
+}
+
+func plannedChangesFromOptions(ctx *Context) []status.PlannedChange {
+	common := &status.Common{}
+	for _, option := range ctx.StatusOptions() {
+		option.(status.CommonStatusOption)(common)
+	}
+	return common.PlannedChanges
+}
@@ -8,11 +8,17 @@ type ConditionReason string
 const (
 	AtlasCredentialsNotProvided ConditionReason = "AtlasCredentialsNotProvided"
 	Internal                    ConditionReason = "InternalError"
+	ChangesPlanned              ConditionReason = "ChangesPlanned"
+	AtlasDiffersFromSpec        ConditionReason = "AtlasDiffersFromSpec"
+	AtlasResourceNotAdopted     ConditionReason = "AtlasResourceNotAdopted"
+	AtlasResourceOwnedByOther   ConditionReason = "AtlasResourceOwnedByOther"
 )
 
 // Atlas Project reasons
 const (
 	ProjectNotCreatedInAtlas                ConditionReason = "ProjectNotCreatedInAtlas"
+	ProjectNotFoundInAtlas                  ConditionReason = "ProjectNotFoundInAtlas"
+	ProjectNotRenamedInAtlas                ConditionReason = "ProjectNotRenamedInAtlas"
 	ProjectIPAccessInvalid                  ConditionReason = "ProjectIPAccessListInvalid"
 	ProjectIPNotCreatedInAtlas              ConditionReason = "ProjectIPAccessListNotCreatedInAtlas"
 	ProjectPEServiceIsNotReadyInAtlas       ConditionReason = "ProjectPrivateEndpointServiceIsNotReadyInAtlas"
@@ -28,6 +34,12 @@ const (
 	ClusterUpdating                    ConditionReason = "ClusterUpdating"
 	ClusterConnectionSecretsNotCreated ConditionReason = "ClusterConnectionSecretsNotCreated"
 	ClusterAdvancedOptionsAreNotReady  ConditionReason = "ClusterAdvancedOptionsAreNotReady"
+	ClusterImmutableFieldsChanged      ConditionReason = "ClusterImmutableFieldsChanged"
+	ClusterDeletionNotConfirmed        ConditionReason = "ClusterDeletionNotConfirmed"
+	ClusterMigrationFailed             ConditionReason = "ClusterMigrationFailed"
+	ClusterChangesNotApproved          ConditionReason = "ClusterChangesNotApproved"
+	ServerlessPrivateEndpointNotReady  ConditionReason = "ServerlessPrivateEndpointNotReady"
+	ServerlessPrivateEndpointFailed    ConditionReason = "ServerlessPrivateEndpointFailed"
 )
 
 // Atlas Database User reasons
@@ -39,4 +51,15 @@ const (
 	DatabaseUserClustersAppliedChanges      ConditionReason = "ClustersAppliedDatabaseUsersChanges"
 	DatabaseUserInvalidSpec                 ConditionReason = "DatabaseUserInvalidSpec"
 	DatabaseUserExpired                     ConditionReason = "DatabaseUserExpired"
+	DatabaseUserPasswordNotGenerated        ConditionReason = "DatabaseUserPasswordNotGenerated"
+)
+
+// Atlas Access Request reasons
+const (
+	AccessRequestInvalidSpec         ConditionReason = "AccessRequestInvalidSpec"
+	AccessRequestProjectNotReady     ConditionReason = "AccessRequestProjectNotReady"
+	AccessRequestNotGrantedInAtlas   ConditionReason = "AccessRequestNotGrantedInAtlas"
+	AccessRequestNotRevokedInAtlas   ConditionReason = "AccessRequestNotRevokedInAtlas"
+	AccessRequestCredentialsNotSaved ConditionReason = "AccessRequestCredentialsNotSaved"
+	AccessRequestExpired             ConditionReason = "AccessRequestExpired"
 )
@@ -11,8 +11,40 @@ const (
 	DefaultTimeout = time.Minute * 20
 )
 
+// RetryClass defines when the reconciliation is repeated after it's finished with the Result.
+type RetryClass int
+
+const (
+	// RetryTransient is used for the errors which are expected to go away (Atlas or network failures). The
+	// reconciliation is requeued with the exponential backoff (see RateLimiter) which is reset on the first success.
+	RetryTransient RetryClass = iota
+	// RetryPolling is used for the long-running Atlas operations. The reconciliation is requeued in
+	// RetryPolicy.PollInterval to check if the operation is finished.
+	RetryPolling
+	// RetryPermanent is used for the errors which can't be fixed by the retry, for example the invalid spec.
+	// The reconciliation is not requeued and waits for the resource (or the Secret it watches) to change.
+	RetryPermanent
+	// retryFixed is used for the results with the delay specified explicitly.
+	retryFixed
+)
+
+// permanentReasons are the reasons the Terminate results are not retried for. The user has to change the resource
+// or the Secret to fix them.
+var permanentReasons = map[ConditionReason]bool{
+	AtlasCredentialsNotProvided:   true,
+	AtlasResourceNotAdopted:       true,
+	AtlasResourceOwnedByOther:     true,
+	ProjectIPAccessInvalid:        true,
+	ClusterImmutableFieldsChanged: true,
+	ClusterMigrationFailed:        true,
+	ClusterChangesNotApproved:     true,
+	DatabaseUserInvalidSpec:       true,
+	AccessRequestInvalidSpec:      true,
+}
+
 type Result struct {
 	terminated   bool
+	retry        RetryClass
 	requeueAfter time.Duration
 	message      string
 	reason       ConditionReason
@@ -24,8 +56,8 @@ type Result struct {
 // OK indicates that the reconciliation logic can proceed further
 func OK() Result {
 	return Result{
-		terminated:   false,
-		requeueAfter: -1,
+		terminated: false,
+		retry:      RetryPermanent,
 	}
 }
 
@@ -33,36 +65,44 @@ func OK() Result {
 // This is not an expected termination of the reconciliation process so 'warning' flag is set to 'true'.
 // 'reason' and 'message' indicate the error state and are supposed to be reflected in the `conditions` for the
 // reconciled Custom Resource.
+// The reconciliation is retried with the exponential backoff unless the reason is a permanent one (like the missing
+// credentials).
 func Terminate(reason ConditionReason, message string) Result {
+	retry := RetryTransient
+	if permanentReasons[reason] {
+		retry = RetryPermanent
+	}
 	return Result{
-		terminated:   true,
-		requeueAfter: DefaultRetry,
-		reason:       reason,
-		message:      message,
-		warning:      true,
+		terminated: true,
+		retry:      retry,
+		reason:     reason,
+		message:    message,
+		warning:    true,
 	}
 }
 
 // InProgress indicates that the reconciliation logic cannot proceed and needs to be finished (and possibly requeued).
 // This is an expected termination of the reconciliation process so 'warning' flag is set to 'false'.
 // 'reason' and 'message' indicate the in-progress state and are supposed to be reflected in the 'conditions' for the reconciled Custom Resource.
+// The reconciliation is requeued in the poll interval.
 func InProgress(reason ConditionReason, message string) Result {
 	return Result{
-		terminated:   true,
-		requeueAfter: DefaultRetry,
-		reason:       reason,
-		message:      message,
-		warning:      false,
+		terminated: true,
+		retry:      RetryPolling,
+		reason:     reason,
+		message:    message,
+		warning:    false,
 	}
 }
 
 // TerminateSilently indicates that the reconciliation logic cannot proceed and needs to be finished (and possibly requeued)
 // The status of the reconciled Custom Resource is not supposed to be updated.
 func TerminateSilently() Result {
-	return Result{terminated: true, requeueAfter: DefaultRetry}
+	return Result{terminated: true, retry: RetryTransient}
 }
 
 func (r Result) WithRetry(retry time.Duration) Result {
+	r.retry = retryFixed
 	r.requeueAfter = retry
 	return r
 }
@@ -70,17 +110,35 @@ func (r Result) WithRetry(retry time.Duration) Result {
 // WithoutRetry indicates that no retry must happen after the reconciliation is over. This should usually be used
 // in cases when retry won't fix the situation like when the spec is incorrect and requires the user to update it.
 func (r Result) WithoutRetry() Result {
-	r.requeueAfter = -1
+	r.retry = RetryPermanent
+	return r
+}
+
+// WithRetryClass overrides the retry class of the result, for example if the failure is known to be transient or
+// permanent in the specific case.
+func (r Result) WithRetryClass(retry RetryClass) Result {
+	r.retry = retry
 	return r
 }
 
+func (r Result) RetryClass() RetryClass {
+	return r.retry
+}
+
 func (r Result) IsOk() bool {
 	return !r.terminated
 }
 
 func (r Result) ReconcileResult() reconcile.Result {
-	if r.requeueAfter < 0 {
+	switch r.retry {
+	case RetryTransient:
+		// The controller requeues the request with the rate limiter (see RateLimiter)
+		return reconcile.Result{Requeue: true}
+	case RetryPolling:
+		return reconcile.Result{RequeueAfter: retryPolicy.PollInterval}
+	case retryFixed:
+		return reconcile.Result{RequeueAfter: r.requeueAfter}
+	default:
 		return reconcile.Result{}
 	}
-	return reconcile.Result{RequeueAfter: r.requeueAfter}
 }
@@ -0,0 +1,56 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcileResult(t *testing.T) {
+	defer SetRetryPolicy(DefaultRetryPolicy())
+	SetRetryPolicy(RetryPolicy{PollInterval: time.Second * 30, MinBackoff: time.Second, MaxBackoff: time.Minute})
+
+	t.Run("OK is not requeued", func(t *testing.T) {
+		assert.Equal(t, reconcile.Result{}, OK().ReconcileResult())
+	})
+	t.Run("Transient error is requeued with backoff", func(t *testing.T) {
+		result := Terminate(ProjectIPNotCreatedInAtlas, "failed")
+		assert.Equal(t, RetryTransient, result.RetryClass())
+		assert.Equal(t, reconcile.Result{Requeue: true}, result.ReconcileResult())
+		assert.Equal(t, reconcile.Result{Requeue: true}, TerminateSilently().ReconcileResult())
+	})
+	t.Run("Permanent error is not requeued", func(t *testing.T) {
+		result := Terminate(DatabaseUserInvalidSpec, "invalid")
+		assert.Equal(t, RetryPermanent, result.RetryClass())
+		assert.Equal(t, reconcile.Result{}, result.ReconcileResult())
+		assert.Equal(t, reconcile.Result{}, Terminate(Internal, "invalid").WithoutRetry().ReconcileResult())
+	})
+	t.Run("Permanent error can be retried", func(t *testing.T) {
+		result := Terminate(AtlasCredentialsNotProvided, "no Secret").WithRetryClass(RetryTransient)
+		assert.Equal(t, reconcile.Result{Requeue: true}, result.ReconcileResult())
+	})
+	t.Run("In progress is polled", func(t *testing.T) {
+		assert.Equal(t, reconcile.Result{RequeueAfter: time.Second * 30}, InProgress(ClusterCreating, "creating").ReconcileResult())
+	})
+	t.Run("Explicit retry", func(t *testing.T) {
+		result := InProgress(ClusterCreating, "creating").WithRetry(time.Minute * 2)
+		assert.Equal(t, reconcile.Result{RequeueAfter: time.Minute * 2}, result.ReconcileResult())
+	})
+}
+
+func TestRateLimiter(t *testing.T) {
+	defer SetRetryPolicy(DefaultRetryPolicy())
+	SetRetryPolicy(RetryPolicy{PollInterval: time.Second, MinBackoff: time.Second, MaxBackoff: time.Second * 5})
+
+	limiter := RateLimiter()
+	item := reconcile.Request{}
+	assert.Equal(t, time.Second, limiter.When(item))
+	assert.Equal(t, time.Second*2, limiter.When(item))
+	assert.Equal(t, time.Second*4, limiter.When(item))
+	assert.Equal(t, time.Second*5, limiter.When(item))
+
+	limiter.Forget(item)
+	assert.Equal(t, time.Second, limiter.When(item))
+}
@@ -0,0 +1,39 @@
+package workflow
+
+import (
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+)
+
+// RetryPolicy configures the requeue delays of the reconciliations (see RetryClass).
+type RetryPolicy struct {
+	// PollInterval is the delay before checking the state of the long-running Atlas operation again.
+	PollInterval time.Duration
+	// MinBackoff is the delay after the first transient error, it doubles after each next one.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum delay after the transient errors.
+	MaxBackoff time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		PollInterval: DefaultRetry,
+		MinBackoff:   DefaultRetry,
+		MaxBackoff:   5 * time.Minute,
+	}
+}
+
+var retryPolicy = DefaultRetryPolicy()
+
+// SetRetryPolicy configures the retry policy used by all the controllers. Must be called before they are started.
+func SetRetryPolicy(policy RetryPolicy) {
+	retryPolicy = policy
+}
+
+// RateLimiter returns the rate limiter the controllers requeue the failed reconciliations with. It applies the
+// exponential backoff configured by the retry policy to each resource separately.
+func RateLimiter() ratelimiter.RateLimiter {
+	return workqueue.NewItemExponentialFailureRateLimiter(retryPolicy.MinBackoff, retryPolicy.MaxBackoff)
+}
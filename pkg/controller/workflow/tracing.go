@@ -0,0 +1,44 @@
+package workflow
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/tracing"
+)
+
+// StartSpan starts the tracing span for the step of the reconciliation. The span stays the current one (the Context
+// carries it and the Log reports its ids) until the returned function ends it, so it's supposed to be deferred:
+//
+//	defer ctx.StartSpan("ensureIPAccessList")()
+func (c *Context) StartSpan(name string) func() {
+	parentContext, parentLog := c.Context, c.Log
+
+	spanContext, span := tracing.Start(c.Context, name)
+	c.Context = spanContext
+	c.Log = tracing.Logger(spanContext, parentLog)
+
+	return func() {
+		span.End()
+		c.Context, c.Log = parentContext, parentLog
+	}
+}
+
+// recordResult adds the condition set from the result to the current span. The span is marked as failed if the
+// result is a warning (unexpected error).
+func (c *Context) recordResult(conditionType status.ConditionType, result Result) {
+	span := trace.SpanFromContext(c.Context)
+	if !span.IsRecording() {
+		return
+	}
+	span.AddEvent("condition", trace.WithAttributes(
+		attribute.String("condition.type", string(conditionType)),
+		attribute.String("condition.reason", string(result.reason)),
+		attribute.String("condition.message", result.message),
+	))
+	if result.warning {
+		span.SetStatus(codes.Error, result.message)
+	}
+}
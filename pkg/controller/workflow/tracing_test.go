@@ -0,0 +1,45 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/tracing"
+)
+
+func TestStartSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+	log := zap.S()
+	ctx := NewContext(log, []status.Condition{})
+	rootContext, root := tracing.Start(ctx.Context, "Reconcile")
+	ctx.Context = rootContext
+
+	endStep := ctx.StartSpan("ensureIPAccessList")
+	assert.NotSame(t, log, ctx.Log)
+	ctx.SetConditionFromResult(status.IPAccessListReadyType, Terminate(ProjectIPNotCreatedInAtlas, "failed"))
+	endStep()
+	root.End()
+
+	assert.Equal(t, rootContext, ctx.Context)
+	assert.Same(t, log, ctx.Log)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	step := spans[0]
+	assert.Equal(t, "ensureIPAccessList", step.Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), step.Parent().SpanID())
+	assert.Equal(t, codes.Error, step.Status().Code)
+	require.Len(t, step.Events(), 1)
+	assert.Equal(t, "condition", step.Events()[0].Name)
+}
@@ -0,0 +1,245 @@
+package export
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/ownership"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/compat"
+)
+
+// The conversions below produce the specs which are equal to the Atlas state from the point of view of the reconcilers,
+// so the reconciliation of the exported resources doesn't change anything in Atlas.
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.]+`)
+
+// maxNameLength is the maximum length of the DNS-1123 subdomain used as the Kubernetes resource name
+const maxNameLength = 253
+
+// resourceName builds the valid Kubernetes resource name from the Atlas names joined by "-"
+func resourceName(parts ...string) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(strings.Join(parts, "-")), "-")
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	return strings.Trim(name, "-.")
+}
+
+// ipAccessListFromAtlas converts the Atlas IP Access List entries. Atlas returns the CIDR block for the single IP
+// address entries as well, it's dropped as the spec allows only one of them.
+func ipAccessListFromAtlas(atlasAccessLists []mongodbatlas.ProjectIPAccessList) []project.IPAccessList {
+	if len(atlasAccessLists) == 0 {
+		return nil
+	}
+
+	result := make([]project.IPAccessList, 0, len(atlasAccessLists))
+	for _, a := range atlasAccessLists {
+		entry := project.IPAccessList{
+			AwsSecurityGroup: a.AwsSecurityGroup,
+			CIDRBlock:        a.CIDRBlock,
+			Comment:          a.Comment,
+			DeleteAfterDate:  a.DeleteAfterDate,
+			IPAddress:        a.IPAddress,
+		}
+		if entry.IPAddress != "" || entry.AwsSecurityGroup != "" {
+			entry.CIDRBlock = ""
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// privateEndpointFromAtlas converts the Atlas private endpoint service and the first of its interface endpoints.
+// The IP address is required only for the Azure private endpoints.
+func privateEndpointFromAtlas(connection mongodbatlas.PrivateEndpointConnection, ip string) project.PrivateEndpoint {
+	pe := project.PrivateEndpoint{
+		Provider: provider.ProviderName(connection.ProviderName),
+		Region:   connection.Region,
+	}
+	switch pe.Provider {
+	case provider.ProviderAWS:
+		if len(connection.InterfaceEndpoints) != 0 {
+			pe.ID = connection.InterfaceEndpoints[0]
+		}
+	case provider.ProviderAzure:
+		if len(connection.PrivateEndpoints) != 0 {
+			pe.ID = connection.PrivateEndpoints[0]
+			pe.IP = ip
+		}
+	}
+	return pe
+}
+
+// clusterSpecFromAtlas converts the cluster returned by the regular Clusters API. The read-only fields (id, state,
+// connection strings etc) and the ownership labels are not part of the spec and get dropped by the conversion.
+func clusterSpecFromAtlas(cluster mongodbatlas.Cluster) (*mdbv1.ClusterSpec, error) {
+	diskSizeGB := cluster.DiskSizeGB
+	cluster.DiskSizeGB = nil
+	// The deprecated fields duplicate 'replicationSpecs' and aren't supported by the spec
+	cluster.ReplicationSpec = nil
+	cluster.ReplicationFactor = nil
+	cluster.Labels = ownership.WithoutLabels(cluster.Labels)
+
+	result := &mdbv1.ClusterSpec{}
+	if err := compat.JSONCopy(result, cluster); err != nil {
+		return nil, err
+	}
+	result.DiskSizeGB = diskSizeFromAtlas(diskSizeGB)
+	return result, nil
+}
+
+// advancedClusterSpecFromAtlas converts the cluster returned by the Advanced Clusters API
+func advancedClusterSpecFromAtlas(cluster mongodbatlas.AdvancedCluster) (*mdbv1.AdvancedClusterSpec, error) {
+	diskSizeGB := cluster.DiskSizeGB
+	cluster.DiskSizeGB = nil
+	cluster.ID = ""
+	cluster.GroupID = ""
+	cluster.ConnectionStrings = nil
+	cluster.StateName = ""
+	cluster.CreateDate = ""
+	cluster.MongoDBVersion = ""
+	cluster.Labels = ownership.WithoutLabels(cluster.Labels)
+
+	result := &mdbv1.AdvancedClusterSpec{}
+	if err := compat.JSONCopy(result, cluster); err != nil {
+		return nil, err
+	}
+	result.DiskSizeGB = diskSizeFromAtlas(diskSizeGB)
+	return result, nil
+}
+
+// diskSizeFromAtlas rounds the disk size as the spec doesn't allow fractions (see mongodb/go-client-mongodb-atlas#140)
+func diskSizeFromAtlas(diskSizeGB *float64) *int {
+	if diskSizeGB == nil {
+		return nil
+	}
+	size := int(math.Round(*diskSizeGB))
+	return &size
+}
+
+// serverlessSpecFromAtlas converts the serverless instance and its private endpoints. The name of the private
+// endpoint is stored in Atlas as its comment.
+func serverlessSpecFromAtlas(instance serverlessInstance, privateEndpoints []mongodbatlas.ServerlessPrivateEndpointConnection) *mdbv1.ServerlessSpec {
+	result := &mdbv1.ServerlessSpec{
+		Name:                         instance.Name,
+		TerminationProtectionEnabled: instance.TerminationProtectionEnabled,
+		Tags:                         instance.Tags,
+	}
+	if instance.ProviderSettings != nil {
+		result.ProviderSettings = &mdbv1.ProviderSettingsSpec{
+			BackingProviderName: instance.ProviderSettings.BackingProviderName,
+			ProviderName:        provider.ProviderName(instance.ProviderSettings.ProviderName),
+			RegionName:          instance.ProviderSettings.RegionName,
+		}
+	}
+	if instance.ServerlessBackupOptions != nil {
+		result.BackupOptions = &mdbv1.ServerlessBackupOptions{
+			ServerlessContinuousBackupEnabled: instance.ServerlessBackupOptions.ServerlessContinuousBackupEnabled,
+		}
+	}
+	for _, pe := range privateEndpoints {
+		result.PrivateEndpoints = append(result.PrivateEndpoints, mdbv1.ServerlessPrivateEndpoint{
+			Name:                     pe.Comment,
+			CloudProviderEndpointID:  pe.CloudProviderEndpointID,
+			PrivateEndpointIPAddress: pe.PrivateEndpointIPAddress,
+		})
+	}
+	return result
+}
+
+// processArgsFromAtlas converts the advanced configuration options of the cluster, it's the reverse of
+// ProcessArgs.ToAtlas
+func processArgsFromAtlas(args mongodbatlas.ProcessArgs) (*mdbv1.ProcessArgs, error) {
+	oplogMinRetentionHours := args.OplogMinRetentionHours
+	args.OplogMinRetentionHours = nil
+
+	result := &mdbv1.ProcessArgs{}
+	if err := compat.JSONCopy(result, args); err != nil {
+		return nil, err
+	}
+	if oplogMinRetentionHours != nil {
+		result.OplogMinRetentionHours = strconv.FormatFloat(*oplogMinRetentionHours, 'f', -1, 64)
+	}
+	return result, nil
+}
+
+// backupScheduleFromAtlas converts the Atlas backup schedule of the cluster into the AtlasBackupSchedule and
+// AtlasBackupPolicy specs. The Atlas cluster always has a single backup policy.
+func backupScheduleFromAtlas(schedule mongodbatlas.CloudProviderSnapshotBackupPolicy, policyRef mdbv1.ResourceRefNamespaced) (mdbv1.AtlasBackupScheduleSpec, mdbv1.AtlasBackupPolicySpec) {
+	scheduleSpec := mdbv1.AtlasBackupScheduleSpec{
+		PolicyRef: policyRef,
+		// the default of the CRD, the export isn't managed by the Operator
+		Export: mdbv1.AtlasBackupExportSpec{FrequencyType: "MONTHLY"},
+	}
+	if schedule.ReferenceHourOfDay != nil {
+		scheduleSpec.ReferenceHourOfDay = *schedule.ReferenceHourOfDay
+	}
+	if schedule.ReferenceMinuteOfHour != nil {
+		scheduleSpec.ReferenceMinuteOfHour = *schedule.ReferenceMinuteOfHour
+	}
+	if schedule.RestoreWindowDays != nil {
+		scheduleSpec.RestoreWindowDays = *schedule.RestoreWindowDays
+	}
+	if schedule.UpdateSnapshots != nil {
+		scheduleSpec.UpdateSnapshots = *schedule.UpdateSnapshots
+	}
+	if schedule.AutoExportEnabled != nil {
+		scheduleSpec.AutoExportEnabled = *schedule.AutoExportEnabled
+	}
+	if schedule.UseOrgAndGroupNamesInExportPrefix != nil {
+		scheduleSpec.UseOrgAndGroupNamesInExportPrefix = *schedule.UseOrgAndGroupNamesInExportPrefix
+	}
+	if schedule.Export != nil && schedule.Export.FrequencyType != "" {
+		scheduleSpec.Export = mdbv1.AtlasBackupExportSpec{
+			ExportBucketID: schedule.Export.ExportBucketID,
+			FrequencyType:  strings.ToUpper(schedule.Export.FrequencyType),
+		}
+	}
+
+	policySpec := mdbv1.AtlasBackupPolicySpec{Items: []mdbv1.AtlasBackupPolicyItem{}}
+	if len(schedule.Policies) > 0 {
+		for _, item := range schedule.Policies[0].PolicyItems {
+			policySpec.Items = append(policySpec.Items, mdbv1.AtlasBackupPolicyItem{
+				FrequencyType:     strings.ToLower(item.FrequencyType),
+				FrequencyInterval: item.FrequencyInterval,
+				RetentionUnit:     strings.ToLower(item.RetentionUnit),
+				RetentionValue:    item.RetentionValue,
+			})
+		}
+	}
+	return scheduleSpec, policySpec
+}
+
+// databaseUserSpecFromAtlas converts the database user. The password can't be read from Atlas so it's the caller's
+// responsibility to provide the password Secret.
+func databaseUserSpecFromAtlas(user mongodbatlas.DatabaseUser, projectRef mdbv1.ResourceRefNamespaced) (mdbv1.AtlasDatabaseUserSpec, error) {
+	user.Password = ""
+	user.GroupID = ""
+	user.Labels = ownership.WithoutLabels(user.Labels)
+
+	result := mdbv1.AtlasDatabaseUserSpec{}
+	if err := compat.JSONCopy(&result, user); err != nil {
+		return result, err
+	}
+	result.Project = projectRef
+	if result.Roles == nil {
+		result.Roles = []mdbv1.RoleSpec{}
+	}
+	return result, nil
+}
+
+// usesPassword returns true if the database user authenticates with the password (SCRAM) and not with x509, LDAP
+// or AWS IAM
+func usesPassword(user mongodbatlas.DatabaseUser) bool {
+	external := func(authType string) bool {
+		return authType != "" && authType != "NONE"
+	}
+	return !external(user.X509Type) && !external(user.LDAPAuthType) && !external(user.AWSIAMType)
+}
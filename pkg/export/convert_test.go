@@ -0,0 +1,270 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlascluster"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/toptr"
+)
+
+func TestResourceName(t *testing.T) {
+	assert.Equal(t, "my-project-cluster0", resourceName("My Project", "Cluster0"))
+	assert.Equal(t, "project-admin-cn-user-o-org", resourceName("project", "admin", "CN=user,O=org"))
+	assert.Equal(t, "project-user.name", resourceName("project_", "user.name@"))
+}
+
+func TestIPAccessListFromAtlas(t *testing.T) {
+	atlasAccessLists := []mongodbatlas.ProjectIPAccessList{
+		{IPAddress: "10.0.0.1", CIDRBlock: "10.0.0.1/32", Comment: "single IP"},
+		{CIDRBlock: "192.168.0.0/24"},
+		{AwsSecurityGroup: "sg-123", DeleteAfterDate: "2030-01-01T00:00:00Z"},
+	}
+	expected := []project.IPAccessList{
+		{IPAddress: "10.0.0.1", Comment: "single IP"},
+		{CIDRBlock: "192.168.0.0/24"},
+		{AwsSecurityGroup: "sg-123", DeleteAfterDate: "2030-01-01T00:00:00Z"},
+	}
+	assert.Equal(t, expected, ipAccessListFromAtlas(atlasAccessLists))
+	assert.Nil(t, ipAccessListFromAtlas(nil))
+}
+
+func TestClusterSpecFromAtlas(t *testing.T) {
+	atlasCluster := mongodbatlas.Cluster{
+		ID:                    "61e6f8c7ed1a2b3d4e5f6a7b",
+		Name:                  "cluster0",
+		ClusterType:           "REPLICASET",
+		DiskSizeGB:            toptr.Float64ptr(40.5),
+		MongoDBMajorVersion:   "5.0",
+		MongoDBVersion:        "5.0.9",
+		ProviderBackupEnabled: toptr.Boolptr(true),
+		StateName:             "IDLE",
+		ProviderSettings: &mongodbatlas.ProviderSettings{
+			ProviderName:     "AWS",
+			InstanceSizeName: "M10",
+			RegionName:       "US_EAST_1",
+		},
+		ReplicationSpecs: []mongodbatlas.ReplicationSpec{{
+			ID:        "61e6f8c7ed1a2b3d4e5f6a7c",
+			NumShards: toptr.Int64ptr(1),
+			ZoneName:  "Zone 1",
+			RegionsConfig: map[string]mongodbatlas.RegionsConfig{
+				"US_EAST_1": {ElectableNodes: toptr.Int64ptr(3), Priority: toptr.Int64ptr(7)},
+			},
+		}},
+		ConnectionStrings: &mongodbatlas.ConnectionStrings{Standard: "mongodb://cluster0"},
+	}
+
+	spec, err := clusterSpecFromAtlas(atlasCluster)
+	require.NoError(t, err)
+	assert.Equal(t, "cluster0", spec.Name)
+	assert.Equal(t, 41, *spec.DiskSizeGB)
+	assert.Equal(t, "M10", spec.ProviderSettings.InstanceSizeName)
+
+	// the rounded disk size is the only difference from Atlas
+	atlasCluster.DiskSizeGB = toptr.Float64ptr(41)
+	merged, err := atlascluster.MergedCluster(atlasCluster, mdbv1.AtlasClusterSpec{ClusterSpec: spec})
+	require.NoError(t, err)
+	assert.True(t, atlascluster.ClustersEqual(zap.S(), atlasCluster, merged))
+}
+
+func TestAdvancedClusterSpecFromAtlas(t *testing.T) {
+	atlasCluster := mongodbatlas.AdvancedCluster{
+		ID:                  "61e6f8c7ed1a2b3d4e5f6a7b",
+		GroupID:             "61e6f8c7ed1a2b3d4e5f6a70",
+		Name:                "multi-cloud",
+		ClusterType:         "REPLICASET",
+		DiskSizeGB:          toptr.Float64ptr(10),
+		BackupEnabled:       toptr.Boolptr(false),
+		MongoDBMajorVersion: "5.0",
+		MongoDBVersion:      "5.0.9",
+		StateName:           "IDLE",
+		CreateDate:          "2022-06-01T00:00:00Z",
+		ReplicationSpecs: []*mongodbatlas.AdvancedReplicationSpec{{
+			ID:        "61e6f8c7ed1a2b3d4e5f6a7c",
+			NumShards: 1,
+			ZoneName:  "Zone 1",
+			RegionConfigs: []*mongodbatlas.AdvancedRegionConfig{
+				{ProviderName: "AWS", RegionName: "US_EAST_1", Priority: toptr.Intptr(7), ElectableSpecs: &mongodbatlas.Specs{InstanceSize: "M10", NodeCount: toptr.Intptr(2)}},
+				{ProviderName: "GCP", RegionName: "CENTRAL_US", Priority: toptr.Intptr(6), ElectableSpecs: &mongodbatlas.Specs{InstanceSize: "M10", NodeCount: toptr.Intptr(1)}},
+			},
+		}},
+	}
+
+	spec, err := advancedClusterSpecFromAtlas(atlasCluster)
+	require.NoError(t, err)
+	assert.Empty(t, spec.ID)
+	assert.Empty(t, spec.StateName)
+	assert.Empty(t, spec.MongoDBVersion)
+	assert.Equal(t, 10, *spec.DiskSizeGB)
+	assert.Len(t, spec.ReplicationSpecs[0].RegionConfigs, 2)
+
+	merged, err := atlascluster.MergedAdvancedCluster(atlasCluster, mdbv1.AtlasClusterSpec{AdvancedClusterSpec: spec})
+	require.NoError(t, err)
+	assert.True(t, atlascluster.AdvancedClustersEqual(zap.S(), atlasCluster, merged))
+}
+
+func TestServerlessSpecFromAtlas(t *testing.T) {
+	instance := serverlessInstance{
+		Cluster: mongodbatlas.Cluster{
+			Name:                         "serverless",
+			TerminationProtectionEnabled: toptr.Boolptr(true),
+			ProviderSettings:             &mongodbatlas.ProviderSettings{ProviderName: "SERVERLESS", BackingProviderName: "AWS", RegionName: "US_EAST_1"},
+			ServerlessBackupOptions:      &mongodbatlas.ServerlessBackupOptions{ServerlessContinuousBackupEnabled: toptr.Boolptr(true)},
+		},
+		Tags: []mdbv1.TagSpec{{Key: "env", Value: "prod"}},
+	}
+	privateEndpoints := []mongodbatlas.ServerlessPrivateEndpointConnection{
+		{ID: "pe-id", Comment: "pe1", CloudProviderEndpointID: "vpce-123", Status: "AVAILABLE"},
+	}
+
+	expected := &mdbv1.ServerlessSpec{
+		Name:                         "serverless",
+		ProviderSettings:             &mdbv1.ProviderSettingsSpec{ProviderName: "SERVERLESS", BackingProviderName: "AWS", RegionName: "US_EAST_1"},
+		TerminationProtectionEnabled: toptr.Boolptr(true),
+		BackupOptions:                &mdbv1.ServerlessBackupOptions{ServerlessContinuousBackupEnabled: toptr.Boolptr(true)},
+		Tags:                         []mdbv1.TagSpec{{Key: "env", Value: "prod"}},
+		PrivateEndpoints:             []mdbv1.ServerlessPrivateEndpoint{{Name: "pe1", CloudProviderEndpointID: "vpce-123"}},
+	}
+	assert.Equal(t, expected, serverlessSpecFromAtlas(instance, privateEndpoints))
+}
+
+func TestProcessArgsFromAtlas(t *testing.T) {
+	atlasArgs := mongodbatlas.ProcessArgs{
+		DefaultReadConcern:        "available",
+		MinimumEnabledTLSProtocol: "TLS1_2",
+		JavascriptEnabled:         toptr.Boolptr(true),
+		NoTableScan:               toptr.Boolptr(false),
+		OplogSizeMB:               toptr.Int64ptr(2048),
+		OplogMinRetentionHours:    toptr.Float64ptr(2.5),
+	}
+
+	args, err := processArgsFromAtlas(atlasArgs)
+	require.NoError(t, err)
+	assert.Equal(t, "2.5", args.OplogMinRetentionHours)
+	assert.True(t, args.IsEqual(atlasArgs))
+}
+
+func TestBackupScheduleFromAtlas(t *testing.T) {
+	atlasSchedule := mongodbatlas.CloudProviderSnapshotBackupPolicy{
+		ClusterName:           "cluster0",
+		ReferenceHourOfDay:    toptr.Int64ptr(10),
+		ReferenceMinuteOfHour: toptr.Int64ptr(30),
+		RestoreWindowDays:     toptr.Int64ptr(2),
+		UpdateSnapshots:       toptr.Boolptr(false),
+		Policies: []mongodbatlas.Policy{{
+			ID: "policy-id",
+			PolicyItems: []mongodbatlas.PolicyItem{
+				{ID: "item-1", FrequencyType: "hourly", FrequencyInterval: 6, RetentionUnit: "days", RetentionValue: 2},
+				{ID: "item-2", FrequencyType: "daily", FrequencyInterval: 1, RetentionUnit: "days", RetentionValue: 7},
+			},
+		}},
+	}
+	policyRef := mdbv1.ResourceRefNamespaced{Name: "policy", Namespace: "ns"}
+
+	schedule, policy := backupScheduleFromAtlas(atlasSchedule, policyRef)
+	assert.Equal(t, mdbv1.AtlasBackupScheduleSpec{
+		PolicyRef:             policyRef,
+		ReferenceHourOfDay:    10,
+		ReferenceMinuteOfHour: 30,
+		RestoreWindowDays:     2,
+		Export:                mdbv1.AtlasBackupExportSpec{FrequencyType: "MONTHLY"},
+	}, schedule)
+	assert.Equal(t, []mdbv1.AtlasBackupPolicyItem{
+		{FrequencyType: "hourly", FrequencyInterval: 6, RetentionUnit: "days", RetentionValue: 2},
+		{FrequencyType: "daily", FrequencyInterval: 1, RetentionUnit: "days", RetentionValue: 7},
+	}, policy.Items)
+}
+
+func TestDatabaseUserSpecFromAtlas(t *testing.T) {
+	projectRef := mdbv1.ResourceRefNamespaced{Name: "project", Namespace: "ns"}
+	t.Run("SCRAM user", func(t *testing.T) {
+		user := mongodbatlas.DatabaseUser{
+			Username:     "app",
+			DatabaseName: "admin",
+			GroupID:      "61e6f8c7ed1a2b3d4e5f6a70",
+			X509Type:     "NONE",
+			LDAPAuthType: "NONE",
+			AWSIAMType:   "NONE",
+			Roles:        []mongodbatlas.Role{{RoleName: "readWrite", DatabaseName: "app"}},
+			Scopes:       []mongodbatlas.Scope{{Name: "cluster0", Type: "CLUSTER"}},
+			Labels:       []mongodbatlas.Label{{Key: "team", Value: "a"}},
+		}
+		spec, err := databaseUserSpecFromAtlas(user, projectRef)
+		require.NoError(t, err)
+		assert.Equal(t, mdbv1.AtlasDatabaseUserSpec{
+			Project:      projectRef,
+			Username:     "app",
+			DatabaseName: "admin",
+			X509Type:     "NONE",
+			Roles:        []mdbv1.RoleSpec{{RoleName: "readWrite", DatabaseName: "app"}},
+			Scopes:       []mdbv1.ScopeSpec{{Name: "cluster0", Type: mdbv1.ClusterScopeType}},
+			Labels:       []mdbv1.LabelSpec{{Key: "team", Value: "a"}},
+		}, spec)
+		assert.True(t, usesPassword(user))
+	})
+	t.Run("x509 user", func(t *testing.T) {
+		user := mongodbatlas.DatabaseUser{Username: "CN=user", DatabaseName: "$external", X509Type: "CUSTOMER"}
+		spec, err := databaseUserSpecFromAtlas(user, projectRef)
+		require.NoError(t, err)
+		assert.Equal(t, "CUSTOMER", spec.X509Type)
+		assert.Equal(t, []mdbv1.RoleSpec{}, spec.Roles)
+		assert.False(t, usesPassword(user))
+	})
+}
+
+func TestSecret(t *testing.T) {
+	exporter := NewExporter(mongodbatlas.Client{}, Options{Namespace: "ns"}, zap.S())
+	secret := exporter.secret("my-project-x509", map[string]string{"ca.crt": "cert"})
+
+	assert.Equal(t, "ns", secret.Namespace)
+	assert.Equal(t, connectionsecret.CredLabelVal, secret.Labels[connectionsecret.TypeLabelKey])
+	assert.Equal(t, map[string]string{"ca.crt": "cert"}, secret.StringData)
+}
+
+func TestWriteManifests(t *testing.T) {
+	objects := []client.Object{
+		&corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Name: "password", Namespace: "ns"},
+			StringData: map[string]string{"password": PasswordPlaceholder},
+		},
+		&mdbv1.AtlasProject{
+			TypeMeta:   typeMeta("AtlasProject"),
+			ObjectMeta: metav1.ObjectMeta{Name: "project", Namespace: "ns"},
+			Spec:       mdbv1.AtlasProjectSpec{Name: "Project"},
+		},
+	}
+
+	out := &bytes.Buffer{}
+	require.NoError(t, WriteManifests(out, objects))
+	expected := `---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: password
+  namespace: ns
+stringData:
+  password: replace-me
+---
+apiVersion: atlas.mongodb.com/v1
+kind: AtlasProject
+metadata:
+  name: project
+  namespace: ns
+spec:
+  name: Project
+`
+	assert.Equal(t, expected, out.String())
+}
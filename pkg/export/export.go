@@ -0,0 +1,367 @@
+// Package export reads the existing Atlas projects and converts them to the Custom Resources managed by the Operator.
+// The resources produced are expected to be reconciled without any changes made to Atlas.
+package export
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.mongodb.org/atlas/mongodbatlas"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/provider"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlas"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/connectionsecret"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+)
+
+const (
+	// PasswordPlaceholder is the password of the exported database users, Atlas never returns the real ones
+	PasswordPlaceholder = "replace-me"
+
+	serverlessInstancesPath = "api/atlas/v1.0/groups/%s/serverless"
+)
+
+// Options configure the resources produced by the Exporter
+type Options struct {
+	// Namespace is the namespace of all the resources exported
+	Namespace string
+
+	// ConnectionSecretName is the Secret with the Atlas API keys referenced by the AtlasProjects. The global Operator
+	// Secret is used if it's empty.
+	ConnectionSecretName string
+
+	// ReconciliationPolicy is set to the 'mongodb.com/atlas-reconciliation-policy' annotation of the Atlas resources
+	// if not empty
+	ReconciliationPolicy string
+}
+
+// Exporter reads the Atlas projects and converts them to the Custom Resources
+type Exporter struct {
+	client  mongodbatlas.Client
+	options Options
+	log     *zap.SugaredLogger
+}
+
+// serverlessInstance is the Atlas serverless instance extended with the tags the Atlas client doesn't support yet
+type serverlessInstance struct {
+	mongodbatlas.Cluster
+	Tags []mdbv1.TagSpec `json:"tags,omitempty"`
+}
+
+func NewExporter(client mongodbatlas.Client, options Options, log *zap.SugaredLogger) *Exporter {
+	return &Exporter{client: client, options: options, log: log}
+}
+
+// Organization exports all the projects of the Atlas organization
+func (e *Exporter) Organization(ctx context.Context, orgID string) ([]client.Object, error) {
+	var projectIDs []string
+	err := atlas.TraversePages(func(pageNum int) (atlas.Paginated, error) {
+		projects, response, err := e.client.Organizations.Projects(ctx, orgID, &mongodbatlas.ProjectsListOptions{ListOptions: *atlas.DefaultListOptions(pageNum)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the projects of the organization %s: %w", orgID, err)
+		}
+		return atlas.NewAtlasPaginated(response, projects.Results), nil
+	}, func(entity interface{}) bool {
+		projectIDs = append(projectIDs, entity.(*mongodbatlas.Project).ID)
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []client.Object
+	for _, projectID := range projectIDs {
+		objects, err := e.Project(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, objects...)
+	}
+	return result, nil
+}
+
+// Project exports the Atlas project with its clusters, backup schedules and database users
+func (e *Exporter) Project(ctx context.Context, projectID string) ([]client.Object, error) {
+	atlasProject, _, err := e.client.Projects.GetOneProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the project %s: %w", projectID, err)
+	}
+	e.log.Infow("Exporting the project", "projectID", projectID, "projectName", atlasProject.Name)
+
+	projectResource, x509Secret, err := e.project(ctx, *atlasProject)
+	if err != nil {
+		return nil, err
+	}
+	result := []client.Object{projectResource}
+	if x509Secret != nil {
+		result = append(result, x509Secret)
+	}
+
+	projectRef := mdbv1.ResourceRefNamespaced{Name: projectResource.Name, Namespace: e.options.Namespace}
+
+	clusters, err := e.clusters(ctx, projectID, projectResource.Name, projectRef)
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, clusters...)
+
+	users, err := e.databaseUsers(ctx, projectID, projectResource.Name, projectRef)
+	if err != nil {
+		return nil, err
+	}
+	return append(result, users...), nil
+}
+
+func (e *Exporter) project(ctx context.Context, atlasProject mongodbatlas.Project) (*mdbv1.AtlasProject, *corev1.Secret, error) {
+	projectResource := mdbv1.NewProject(e.options.Namespace, resourceName(atlasProject.Name), atlasProject.Name).
+		WithProjectID(atlasProject.ID).
+		WithConnectionSecret(e.options.ConnectionSecretName)
+	projectResource.TypeMeta = typeMeta("AtlasProject")
+	e.annotate(projectResource)
+
+	accessLists, _, err := e.client.ProjectIPAccessList.List(ctx, atlasProject.ID, atlas.DefaultListOptions(1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list the IP access list of the project %s: %w", atlasProject.ID, err)
+	}
+	projectResource.Spec.ProjectIPAccessList = ipAccessListFromAtlas(accessLists.Results)
+
+	for _, providerName := range []provider.ProviderName{provider.ProviderAWS, provider.ProviderAzure} {
+		connections, _, err := e.client.PrivateEndpoints.List(ctx, atlasProject.ID, string(providerName), &mongodbatlas.ListOptions{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list the %s private endpoints of the project %s: %w", providerName, atlasProject.ID, err)
+		}
+		for _, connection := range connections {
+			connection.ProviderName = string(providerName)
+			ip := ""
+			if providerName == provider.ProviderAzure && len(connection.PrivateEndpoints) != 0 {
+				endpoint, _, err := e.client.PrivateEndpoints.GetOnePrivateEndpoint(ctx, atlasProject.ID, string(providerName), connection.ID, connection.PrivateEndpoints[0])
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to get the private endpoint %s of the project %s: %w", connection.PrivateEndpoints[0], atlasProject.ID, err)
+				}
+				ip = endpoint.PrivateEndpointIPAddress
+			}
+			projectResource.Spec.PrivateEndpoints = append(projectResource.Spec.PrivateEndpoints, privateEndpointFromAtlas(connection, ip))
+		}
+	}
+
+	x509, _, err := e.client.X509AuthDBUsers.GetCurrentX509Conf(ctx, atlasProject.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the x509 configuration of the project %s: %w", atlasProject.ID, err)
+	}
+	if x509.Cas == "" {
+		return projectResource, nil, nil
+	}
+	x509Secret := e.secret(resourceName(projectResource.Name, "x509"), map[string]string{"ca.crt": x509.Cas})
+	projectResource.Spec.X509CertRef = &mdbv1.ResourceRef{Name: x509Secret.Name}
+	return projectResource, x509Secret, nil
+}
+
+// clusters exports the clusters of the project. The clusters available in the regular Clusters API are exported as
+// 'clusterSpec', the rest (multi-cloud ones) as 'advancedClusterSpec'.
+func (e *Exporter) clusters(ctx context.Context, projectID, projectName string, projectRef mdbv1.ResourceRefNamespaced) ([]client.Object, error) {
+	var result []client.Object
+	exported := map[string]bool{}
+
+	regularClusters, _, err := e.client.Clusters.List(ctx, projectID, atlas.DefaultListOptions(1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the clusters of the project %s: %w", projectID, err)
+	}
+	for _, c := range regularClusters {
+		spec, err := clusterSpecFromAtlas(c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the cluster %s: %w", c.Name, err)
+		}
+		cluster := e.cluster(projectName, c.Name, projectRef)
+		cluster.Spec.ClusterSpec = spec
+		objects, err := e.clusterOptions(ctx, projectID, cluster, c.ProviderBackupEnabled)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, objects...)
+		exported[c.Name] = true
+	}
+
+	advancedClusters, _, err := e.client.AdvancedClusters.List(ctx, projectID, atlas.DefaultListOptions(1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the advanced clusters of the project %s: %w", projectID, err)
+	}
+	for _, c := range advancedClusters.Results {
+		if exported[c.Name] {
+			continue
+		}
+		spec, err := advancedClusterSpecFromAtlas(*c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the cluster %s: %w", c.Name, err)
+		}
+		cluster := e.cluster(projectName, c.Name, projectRef)
+		cluster.Spec.AdvancedClusterSpec = spec
+		objects, err := e.clusterOptions(ctx, projectID, cluster, c.BackupEnabled)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, objects...)
+		exported[c.Name] = true
+	}
+
+	instances, err := e.serverlessInstances(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, instance := range instances {
+		if exported[instance.Name] {
+			continue
+		}
+		privateEndpoints, _, err := e.client.ServerlessPrivateEndpoints.List(ctx, projectID, instance.Name, &mongodbatlas.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the private endpoints of the serverless instance %s: %w", instance.Name, err)
+		}
+		cluster := e.cluster(projectName, instance.Name, projectRef)
+		cluster.Spec.ServerlessSpec = serverlessSpecFromAtlas(instance, privateEndpoints)
+		result = append(result, cluster)
+	}
+	return result, nil
+}
+
+func (e *Exporter) cluster(projectName, clusterName string, projectRef mdbv1.ResourceRefNamespaced) *mdbv1.AtlasCluster {
+	cluster := &mdbv1.AtlasCluster{
+		TypeMeta: typeMeta("AtlasCluster"),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceName(projectName, clusterName),
+			Namespace: e.options.Namespace,
+		},
+		Spec: mdbv1.AtlasClusterSpec{Project: projectRef},
+	}
+	e.annotate(cluster)
+	adopt(cluster)
+	return cluster
+}
+
+// clusterOptions adds the process arguments and the backup schedule to the regular or advanced cluster. The backup
+// schedule is exported only if the cloud backup is enabled, the Operator refuses to configure it otherwise.
+func (e *Exporter) clusterOptions(ctx context.Context, projectID string, cluster *mdbv1.AtlasCluster, backupEnabled *bool) ([]client.Object, error) {
+	clusterName := cluster.GetClusterName()
+	args, _, err := e.client.Clusters.GetProcessArgs(ctx, projectID, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the process arguments of the cluster %s: %w", clusterName, err)
+	}
+	if cluster.Spec.ProcessArgs, err = processArgsFromAtlas(*args); err != nil {
+		return nil, fmt.Errorf("failed to convert the process arguments of the cluster %s: %w", clusterName, err)
+	}
+
+	if backupEnabled == nil || !*backupEnabled {
+		return []client.Object{cluster}, nil
+	}
+
+	atlasSchedule, _, err := e.client.CloudProviderSnapshotBackupPolicies.Get(ctx, projectID, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the backup schedule of the cluster %s: %w", clusterName, err)
+	}
+
+	policy := &mdbv1.AtlasBackupPolicy{
+		TypeMeta:   typeMeta("AtlasBackupPolicy"),
+		ObjectMeta: metav1.ObjectMeta{Name: resourceName(cluster.Name, "backup-policy"), Namespace: e.options.Namespace},
+	}
+	schedule := &mdbv1.AtlasBackupSchedule{
+		TypeMeta:   typeMeta("AtlasBackupSchedule"),
+		ObjectMeta: metav1.ObjectMeta{Name: resourceName(cluster.Name, "backup-schedule"), Namespace: e.options.Namespace},
+	}
+	schedule.Spec, policy.Spec = backupScheduleFromAtlas(*atlasSchedule, mdbv1.ResourceRefNamespaced{Name: policy.Name, Namespace: e.options.Namespace})
+	cluster.Spec.BackupScheduleRef = mdbv1.ResourceRefNamespaced{Name: schedule.Name, Namespace: e.options.Namespace}
+
+	return []client.Object{policy, schedule, cluster}, nil
+}
+
+// serverlessInstances reads the serverless instances of the project. The request is made directly as the Atlas client
+// doesn't return the instance tags.
+func (e *Exporter) serverlessInstances(ctx context.Context, projectID string) ([]serverlessInstance, error) {
+	req, err := e.client.NewRequest(ctx, http.MethodGet, fmt.Sprintf(serverlessInstancesPath, projectID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := &struct {
+		Results []serverlessInstance `json:"results"`
+	}{}
+	if _, err := e.client.Do(ctx, req, instances); err != nil {
+		return nil, fmt.Errorf("failed to list the serverless instances of the project %s: %w", projectID, err)
+	}
+	return instances.Results, nil
+}
+
+// databaseUsers exports the database users of the project. The users authenticating with the password reference the
+// placeholder Secrets, the Operator doesn't send the password from such Secret to Atlas until the Secret is replaced
+// with the real password (the placeholder annotation is removed).
+func (e *Exporter) databaseUsers(ctx context.Context, projectID, projectName string, projectRef mdbv1.ResourceRefNamespaced) ([]client.Object, error) {
+	users, _, err := e.client.DatabaseUsers.List(ctx, projectID, atlas.DefaultListOptions(1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the database users of the project %s: %w", projectID, err)
+	}
+
+	var result []client.Object
+	for _, u := range users {
+		spec, err := databaseUserSpecFromAtlas(u, projectRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the database user %s: %w", u.Username, err)
+		}
+		user := &mdbv1.AtlasDatabaseUser{
+			TypeMeta: typeMeta("AtlasDatabaseUser"),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      resourceName(projectName, u.DatabaseName, u.Username),
+				Namespace: e.options.Namespace,
+			},
+			Spec: spec,
+		}
+		e.annotate(user)
+		adopt(user)
+
+		if usesPassword(u) {
+			secret := e.secret(resourceName(user.Name, "password"), map[string]string{"password": PasswordPlaceholder})
+			secret.Annotations = map[string]string{customresource.PasswordPlaceholderAnnotation: customresource.PasswordPlaceholderTrue}
+			user.Spec.PasswordSecret = &mdbv1.ResourceRef{Name: secret.Name}
+			result = append(result, secret)
+		}
+		result = append(result, user)
+	}
+	return result, nil
+}
+
+// secret returns the Secret referenced by the exported resources. The label is required for the Secret to be visible
+// to the Operator (see the cache configuration).
+func (e *Exporter) secret(name string, data map[string]string) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: e.options.Namespace,
+			Labels:    map[string]string{connectionsecret.TypeLabelKey: connectionsecret.CredLabelVal},
+		},
+		StringData: data,
+	}
+}
+
+func (e *Exporter) annotate(resource client.Object) {
+	if e.options.ReconciliationPolicy == "" {
+		return
+	}
+	resource.SetAnnotations(map[string]string{customresource.ReconciliationPolicyAnnotation: e.options.ReconciliationPolicy})
+}
+
+// adopt allows the Operator to take over the exported Atlas resource which isn't marked as managed by any Operator yet
+func adopt(resource client.Object) {
+	annotations := resource.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[customresource.AdoptAnnotation] = customresource.AdoptTrue
+	resource.SetAnnotations(annotations)
+}
+
+func typeMeta(kind string) metav1.TypeMeta {
+	return metav1.TypeMeta{APIVersion: mdbv1.GroupVersion.String(), Kind: kind}
+}
@@ -0,0 +1,42 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// WriteManifests writes the objects as the multi-document YAML ready to be applied by kubectl. The status and the
+// server-populated metadata fields are omitted.
+func WriteManifests(w io.Writer, objects []client.Object) error {
+	for _, object := range objects {
+		manifest, err := toManifest(object)
+		if err != nil {
+			return fmt.Errorf("failed to serialize %s %s: %w", object.GetObjectKind().GroupVersionKind().Kind, object.GetName(), err)
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", manifest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toManifest(object client.Object) ([]byte, error) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	delete(fields, "status")
+	if metadata, ok := fields["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "creationTimestamp")
+	}
+	return yaml.Marshal(fields)
+}
@@ -0,0 +1,73 @@
+package httputil
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics is the option observing the duration of each request in the histogram with the "method", "path" and "code"
+// labels. The path is reduced to the template (see PathTemplate) to keep the cardinality of the metric low.
+func Metrics(histogram *prometheus.HistogramVec) ClientOpt {
+	return func(c *http.Client) error {
+		c.Transport = &instrumentedRoundTripper{rt: c.Transport, histogram: histogram}
+		return nil
+	}
+}
+
+type instrumentedRoundTripper struct {
+	rt        http.RoundTripper
+	histogram *prometheus.HistogramVec
+}
+
+func (i *instrumentedRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	startTime := time.Now()
+	response, err := i.rt.RoundTrip(request)
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(response.StatusCode)
+	}
+	i.histogram.WithLabelValues(request.Method, PathTemplate(request.URL.EscapedPath()), code).Observe(time.Since(startTime).Seconds())
+	return response, err
+}
+
+var idSegment = regexp.MustCompile(`^[0-9a-fA-F]{24}$`)
+
+// parameterCollections are the Atlas API path segments followed by a user defined value (name, IP address etc)
+// rather than by the generated id. The value is the number of segments to replace.
+var parameterCollections = map[string]int{
+	"byName":        1,
+	"clusters":      1,
+	"serverless":    1,
+	"accessList":    1,
+	"whitelist":     1,
+	"databaseUsers": 2,
+	"roles":         1,
+	"endpoint":      1,
+	"users":         1,
+}
+
+// PathTemplate replaces the ids and the names in the Atlas API path with the placeholders, for example
+// '/api/atlas/v1.0/groups/5f1b.../clusters/my-cluster' becomes '/api/atlas/v1.0/groups/{id}/clusters/{name}'.
+func PathTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 0; i < len(segments); i++ {
+		if idSegment.MatchString(segments[i]) {
+			segments[i] = "{id}"
+			continue
+		}
+		if count, ok := parameterCollections[segments[i]]; ok {
+			for j := i + 1; j <= i+count && j < len(segments); j++ {
+				if segments[j] != "" {
+					segments[j] = "{name}"
+				}
+			}
+			i += count
+		}
+	}
+	return strings.Join(segments, "/")
+}
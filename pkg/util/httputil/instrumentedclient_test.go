@@ -0,0 +1,47 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathTemplate(t *testing.T) {
+	testCases := map[string]string{
+		"/api/atlas/v1.0/groups/5f1b1a7a2f4a5e3c1b2a3d4e":                                       "/api/atlas/v1.0/groups/{id}",
+		"/api/atlas/v1.0/groups/byName/my-project":                                              "/api/atlas/v1.0/groups/byName/{name}",
+		"/api/atlas/v1.5/groups/5f1b1a7a2f4a5e3c1b2a3d4e/clusters/my-cluster/":                  "/api/atlas/v1.5/groups/{id}/clusters/{name}/",
+		"/api/atlas/v1.0/groups/5f1b1a7a2f4a5e3c1b2a3d4e/clusters/my-cluster/processArgs":       "/api/atlas/v1.0/groups/{id}/clusters/{name}/processArgs",
+		"/api/atlas/v1.0/groups/5f1b1a7a2f4a5e3c1b2a3d4e/databaseUsers/admin/user":              "/api/atlas/v1.0/groups/{id}/databaseUsers/{name}/{name}",
+		"/api/atlas/v1.0/groups/5f1b1a7a2f4a5e3c1b2a3d4e/accessList/10.0.0.0%2F24":              "/api/atlas/v1.0/groups/{id}/accessList/{name}",
+		"/api/atlas/v1.0/groups/5f1b1a7a2f4a5e3c1b2a3d4e/privateEndpoint/AWS/endpointService":   "/api/atlas/v1.0/groups/{id}/privateEndpoint/AWS/endpointService",
+		"/api/atlas/v1.0/groups/5f1b1a7a2f4a5e3c1b2a3d4e/clusters/my-cluster/backup/schedule":   "/api/atlas/v1.0/groups/{id}/clusters/{name}/backup/schedule",
+		"/api/atlas/v1.0/groups/5f1b1a7a2f4a5e3c1b2a3d4e/privateEndpoint/endpoint/vpce-1234567": "/api/atlas/v1.0/groups/{id}/privateEndpoint/endpoint/{name}",
+	}
+	for path, expected := range testCases {
+		assert.Equal(t, expected, PathTemplate(path), path)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	histogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "test"}, []string{"method", "path", "code"})
+	client, err := DecorateClient(&http.Client{Transport: http.DefaultTransport}, Metrics(histogram))
+	require.NoError(t, err)
+
+	response, err := client.Get(server.URL + "/api/atlas/v1.0/groups/byName/my-project")
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	assert.Equal(t, 1, testutil.CollectAndCount(histogram))
+	assert.True(t, histogram.DeleteLabelValues(http.MethodGet, "/api/atlas/v1.0/groups/byName/{name}", "429"))
+}
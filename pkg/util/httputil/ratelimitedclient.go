@@ -0,0 +1,28 @@
+package httputil
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimit is the option making the client wait for the limiter before each request. The same limiter can be
+// shared by several clients to limit the total rate of their requests.
+func RateLimit(limiter *rate.Limiter) ClientOpt {
+	return func(c *http.Client) error {
+		c.Transport = &rateLimitedRoundTripper{rt: c.Transport, limiter: limiter}
+		return nil
+	}
+}
+
+type rateLimitedRoundTripper struct {
+	rt      http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func (r *rateLimitedRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if err := r.limiter.Wait(request.Context()); err != nil {
+		return nil, err
+	}
+	return r.rt.RoundTrip(request)
+}
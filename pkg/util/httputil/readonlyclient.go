@@ -0,0 +1,30 @@
+package httputil
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrReadOnly is returned by the read-only client for the requests which may change the state of the server.
+var ErrReadOnly = errors.New("the request is not allowed for the read-only client")
+
+// ReadOnly is the option making the client fail the requests other than GET, HEAD and OPTIONS without sending them.
+func ReadOnly() ClientOpt {
+	return func(c *http.Client) error {
+		c.Transport = &readOnlyRoundTripper{rt: c.Transport}
+		return nil
+	}
+}
+
+type readOnlyRoundTripper struct {
+	rt http.RoundTripper
+}
+
+func (r *readOnlyRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return r.rt.RoundTrip(request)
+	}
+	return nil, fmt.Errorf("%s %s: %w", request.Method, request.URL.Path, ErrReadOnly)
+}
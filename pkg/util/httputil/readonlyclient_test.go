@@ -0,0 +1,45 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnly(t *testing.T) {
+	server, bodies := serverResponding(nil)
+	defer server.Close()
+
+	var delays []time.Duration
+	client, err := DecorateClient(&http.Client{Transport: http.DefaultTransport}, ReadOnly(), Retry(3, 100*time.Millisecond, 5*time.Second))
+	require.NoError(t, err)
+	client.Transport.(*retryRoundTripper).sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	response, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		request, err := http.NewRequest(method, server.URL+"/resource", strings.NewReader("{}"))
+		require.NoError(t, err)
+
+		response, err := client.Do(request)
+		if err == nil {
+			response.Body.Close()
+		}
+		assert.True(t, errors.Is(err, ErrReadOnly), method)
+	}
+	// The requests are neither sent nor retried
+	assert.Len(t, *bodies, 1)
+	assert.Empty(t, delays)
+}
@@ -0,0 +1,149 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Retry is the option adding retries of the idempotent requests failed with the network errors or with the
+// "429 Too Many Requests" and 5xx responses. The delay between the attempts grows exponentially (with jitter) from
+// 'minBackoff' up to 'maxBackoff' unless the server specifies it in the 'Retry-After' header. The response is returned
+// as is if the server asks to wait longer than 'maxBackoff' - it's cheaper to requeue the reconciliation than to block
+// the worker.
+func Retry(maxRetries int, minBackoff, maxBackoff time.Duration) ClientOpt {
+	return func(c *http.Client) error {
+		c.Transport = &retryRoundTripper{
+			rt:         c.Transport,
+			maxRetries: maxRetries,
+			minBackoff: minBackoff,
+			maxBackoff: maxBackoff,
+			sleep:      sleepWithContext,
+		}
+		return nil
+	}
+}
+
+type retryRoundTripper struct {
+	rt         http.RoundTripper
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	sleep      func(ctx context.Context, d time.Duration) error
+}
+
+func (r *retryRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if !isIdempotent(request.Method) {
+		return r.rt.RoundTrip(request)
+	}
+
+	attemptRequest := request
+	for attempt := 0; ; attempt++ {
+		response, err := r.rt.RoundTrip(attemptRequest)
+		if attempt >= r.maxRetries || !shouldRetry(request.Context(), response, err) {
+			return response, err
+		}
+
+		delay := r.backoff(attempt)
+		if retryAfter, ok := parseRetryAfter(response); ok {
+			if retryAfter > r.maxBackoff {
+				return response, err
+			}
+			delay = retryAfter
+		}
+
+		// The body of the next attempt must be rewound, the request can't be repeated if it's not possible
+		nextRequest := request
+		if request.Body != nil && request.Body != http.NoBody {
+			if request.GetBody == nil {
+				return response, err
+			}
+			body, bodyErr := request.GetBody()
+			if bodyErr != nil {
+				return response, err
+			}
+			nextRequest = request.Clone(request.Context())
+			nextRequest.Body = body
+		}
+
+		if response != nil {
+			// Draining the body allows to reuse the connection
+			_, _ = io.Copy(ioutil.Discard, response.Body)
+			_ = response.Body.Close()
+		}
+		if sleepErr := r.sleep(request.Context(), delay); sleepErr != nil {
+			return nil, sleepErr
+		}
+		attemptRequest = nextRequest
+	}
+}
+
+// backoff returns the exponential delay for the attempt with the "equal jitter": the delay is randomized
+// in the [d/2, d) range so that the clients failed at the same time don't retry at the same time.
+func (r *retryRoundTripper) backoff(attempt int) time.Duration {
+	delay := r.minBackoff << uint(attempt)
+	if delay > r.maxBackoff || delay <= 0 {
+		delay = r.maxBackoff
+	}
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + time.Duration(rand.Int63n(int64(half))) //nolint:gosec // the jitter doesn't need a secure random
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func shouldRetry(ctx context.Context, response *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, ErrReadOnly)
+	}
+	return response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError
+}
+
+// parseRetryAfter reads the 'Retry-After' header specified either in seconds or as an HTTP date.
+func parseRetryAfter(response *http.Response) (time.Duration, bool) {
+	if response == nil {
+		return 0, false
+	}
+	value := response.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,161 @@
+package httputil
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRetryClient(t *testing.T, maxRetries int, delays *[]time.Duration) *http.Client {
+	client, err := DecorateClient(&http.Client{Transport: http.DefaultTransport}, Retry(maxRetries, 100*time.Millisecond, 5*time.Second))
+	require.NoError(t, err)
+	client.Transport.(*retryRoundTripper).sleep = func(_ context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+		return nil
+	}
+	return client
+}
+
+// serverResponding returns the server responding with the statuses specified and 200 after them
+func serverResponding(headers http.Header, statuses ...int) (*httptest.Server, *[]string) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) <= len(statuses) {
+			for k, v := range headers {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(statuses[len(bodies)-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	return server, &bodies
+}
+
+func TestRetry(t *testing.T) {
+	t.Run("Idempotent request is retried", func(t *testing.T) {
+		server, bodies := serverResponding(nil, http.StatusTooManyRequests, http.StatusServiceUnavailable)
+		defer server.Close()
+		var delays []time.Duration
+
+		response, err := newRetryClient(t, 3, &delays).Get(server.URL)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Len(t, *bodies, 3)
+		require.Len(t, delays, 2)
+		assert.True(t, delays[0] >= 50*time.Millisecond && delays[0] < 100*time.Millisecond)
+		assert.True(t, delays[1] >= 100*time.Millisecond && delays[1] < 200*time.Millisecond)
+	})
+
+	t.Run("Body is resent", func(t *testing.T) {
+		server, bodies := serverResponding(nil, http.StatusBadGateway)
+		defer server.Close()
+		var delays []time.Duration
+
+		request, err := http.NewRequestWithContext(context.Background(), http.MethodPut, server.URL, strings.NewReader(`{"foo":"bar"}`))
+		require.NoError(t, err)
+		response, err := newRetryClient(t, 3, &delays).Do(request)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, []string{`{"foo":"bar"}`, `{"foo":"bar"}`}, *bodies)
+	})
+
+	t.Run("Retries are limited", func(t *testing.T) {
+		server, bodies := serverResponding(nil, 500, 500, 500, 500)
+		defer server.Close()
+		var delays []time.Duration
+
+		response, err := newRetryClient(t, 2, &delays).Get(server.URL)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+		assert.Len(t, *bodies, 3)
+	})
+
+	t.Run("Non idempotent request is not retried", func(t *testing.T) {
+		server, bodies := serverResponding(nil, http.StatusServiceUnavailable)
+		defer server.Close()
+		var delays []time.Duration
+
+		response, err := newRetryClient(t, 3, &delays).Post(server.URL, "application/json", strings.NewReader("{}"))
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+		assert.Len(t, *bodies, 1)
+	})
+
+	t.Run("Client errors are not retried", func(t *testing.T) {
+		server, bodies := serverResponding(nil, http.StatusNotFound)
+		defer server.Close()
+		var delays []time.Duration
+
+		response, err := newRetryClient(t, 3, &delays).Get(server.URL)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+		assert.Len(t, *bodies, 1)
+	})
+
+	t.Run("Retry-After is respected", func(t *testing.T) {
+		server, _ := serverResponding(http.Header{"Retry-After": []string{"2"}}, http.StatusTooManyRequests)
+		defer server.Close()
+		var delays []time.Duration
+
+		response, err := newRetryClient(t, 3, &delays).Get(server.URL)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, []time.Duration{2 * time.Second}, delays)
+	})
+
+	t.Run("Too long Retry-After is returned", func(t *testing.T) {
+		server, bodies := serverResponding(http.Header{"Retry-After": []string{"60"}}, http.StatusTooManyRequests)
+		defer server.Close()
+		var delays []time.Duration
+
+		response, err := newRetryClient(t, 3, &delays).Get(server.URL)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+		assert.Len(t, *bodies, 1)
+		assert.Empty(t, delays)
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	response := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{value}}}
+	}
+
+	delay, ok := parseRetryAfter(response("5"))
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, delay)
+
+	delay, ok = parseRetryAfter(response(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), delay)
+
+	_, ok = parseRetryAfter(response("soon"))
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter(&http.Response{Header: http.Header{}})
+	assert.False(t, ok)
+}
@@ -0,0 +1,45 @@
+package httputil
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/httputil"
+
+// Tracing is the option starting the client span for each request. The span is the child of the one found in the
+// request context, so the requests are traced as the part of the operation which has sent them.
+func Tracing() ClientOpt {
+	return func(c *http.Client) error {
+		c.Transport = &tracedRoundTripper{rt: c.Transport}
+		return nil
+	}
+}
+
+type tracedRoundTripper struct {
+	rt http.RoundTripper
+}
+
+func (t *tracedRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx, span := otel.Tracer(tracerName).Start(
+		request.Context(),
+		"HTTP "+request.Method+" "+PathTemplate(request.URL.EscapedPath()),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.HTTPClientAttributesFromHTTPRequest(request)...),
+	)
+	defer span.End()
+
+	response, err := t.rt.RoundTrip(request.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return response, err
+	}
+	span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(response.StatusCode)...)
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(response.StatusCode, trace.SpanKindClient))
+	return response, nil
+}
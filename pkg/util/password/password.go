@@ -0,0 +1,27 @@
+package password
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// DefaultLength is the length of the passwords generated by the Operator
+const DefaultLength = 32
+
+// alphabet doesn't contain the special characters so that the password can be used in the connection strings without
+// escaping
+const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// Generate returns the random password of the specified length
+func Generate(length int) (string, error) {
+	result := make([]byte, length)
+	max := big.NewInt(int64(len(alphabet)))
+	for i := range result {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		result[i] = alphabet[n.Int64()]
+	}
+	return string(result), nil
+}
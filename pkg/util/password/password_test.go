@@ -0,0 +1,19 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	first, err := Generate(DefaultLength)
+	require.NoError(t, err)
+	second, err := Generate(DefaultLength)
+	require.NoError(t, err)
+
+	assert.Len(t, first, DefaultLength)
+	assert.Regexp(t, "^[a-zA-Z0-9]+$", first)
+	assert.NotEqual(t, first, second)
+}
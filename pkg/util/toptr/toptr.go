@@ -7,3 +7,11 @@ func Int64ptr(i int64) *int64 {
 func Boolptr(b bool) *bool {
 	return &b
 }
+
+func Intptr(i int) *int {
+	return &i
+}
+
+func Float64ptr(f float64) *float64 {
+	return &f
+}
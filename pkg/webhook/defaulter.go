@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/defaults"
+)
+
+// +kubebuilder:webhook:path=/mutate-atlas-mongodb-com-v1-atlascluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasclusters,verbs=create;update,versions=v1,name=matlascluster.atlas.mongodb.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-atlas-mongodb-com-v1-atlasproject,mutating=true,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasprojects,verbs=create;update,versions=v1,name=matlasproject.atlas.mongodb.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-atlas-mongodb-com-v1-atlasdatabaseuser,mutating=true,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasdatabaseusers,verbs=create;update,versions=v1,name=matlasdatabaseuser.atlas.mongodb.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-atlas-mongodb-com-v1-atlasbackuppolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasbackuppolicies,verbs=create;update,versions=v1,name=matlasbackuppolicy.atlas.mongodb.com,admissionReviewVersions=v1
+
+var (
+	_ admission.CustomDefaulter = &clusterDefaulter{}
+	_ admission.CustomDefaulter = &projectDefaulter{}
+	_ admission.CustomDefaulter = &databaseUserDefaulter{}
+	_ admission.CustomDefaulter = &backupPolicyDefaulter{}
+)
+
+type clusterDefaulter struct{}
+
+func (d *clusterDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	cluster, ok := obj.(*mdbv1.AtlasCluster)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasCluster{}, obj)
+	}
+	defaults.Cluster(cluster)
+	return nil
+}
+
+type projectDefaulter struct{}
+
+func (d *projectDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	project, ok := obj.(*mdbv1.AtlasProject)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasProject{}, obj)
+	}
+	defaults.Project(project)
+	return nil
+}
+
+type databaseUserDefaulter struct{}
+
+func (d *databaseUserDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	user, ok := obj.(*mdbv1.AtlasDatabaseUser)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasDatabaseUser{}, obj)
+	}
+	defaults.DatabaseUser(user)
+	return nil
+}
+
+type backupPolicyDefaulter struct{}
+
+func (d *backupPolicyDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	policy, ok := obj.(*mdbv1.AtlasBackupPolicy)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasBackupPolicy{}, obj)
+	}
+	defaults.BackupPolicy(policy)
+	return nil
+}
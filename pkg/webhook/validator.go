@@ -0,0 +1,199 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/validate"
+)
+
+// +kubebuilder:webhook:path=/validate-atlas-mongodb-com-v1-atlascluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasclusters,verbs=create;update,versions=v1,name=vatlascluster.atlas.mongodb.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-atlas-mongodb-com-v1-atlasproject,mutating=false,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasprojects,verbs=create;update,versions=v1,name=vatlasproject.atlas.mongodb.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-atlas-mongodb-com-v1-atlasdatabaseuser,mutating=false,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasdatabaseusers,verbs=create;update,versions=v1,name=vatlasdatabaseuser.atlas.mongodb.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-atlas-mongodb-com-v1-atlasbackuppolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasbackuppolicies,verbs=create;update,versions=v1,name=vatlasbackuppolicy.atlas.mongodb.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-atlas-mongodb-com-v1-atlasbackupschedule,mutating=false,failurePolicy=fail,sideEffects=None,groups=atlas.mongodb.com,resources=atlasbackupschedules,verbs=create;update,versions=v1,name=vatlasbackupschedule.atlas.mongodb.com,admissionReviewVersions=v1
+
+var (
+	_ admission.CustomValidator = &clusterValidator{}
+	_ admission.CustomValidator = &projectValidator{}
+	_ admission.CustomValidator = &databaseUserValidator{}
+	_ admission.CustomValidator = &backupPolicyValidator{}
+	_ admission.CustomValidator = &backupScheduleValidator{}
+)
+
+// updateShouldBeValidated returns false if the update doesn't change the spec (e.g. the annotations or finalizers are
+// changed) or the resource is being deleted. This makes sure the resources created before the validation was
+// introduced can still be managed and removed.
+func updateShouldBeValidated(newObj metav1.Object, oldSpec, newSpec interface{}) bool {
+	if !newObj.GetDeletionTimestamp().IsZero() {
+		return false
+	}
+	return !equality.Semantic.DeepEqual(oldSpec, newSpec)
+}
+
+func unexpectedTypeError(expected, got runtime.Object) error {
+	return fmt.Errorf("expected %T but got %T", expected, got)
+}
+
+type clusterValidator struct{}
+
+func (v *clusterValidator) ValidateCreate(_ context.Context, obj runtime.Object) error {
+	cluster, ok := obj.(*mdbv1.AtlasCluster)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasCluster{}, obj)
+	}
+	return validate.ClusterSpec(cluster.Spec)
+}
+
+func (v *clusterValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) error {
+	oldCluster, ok := oldObj.(*mdbv1.AtlasCluster)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasCluster{}, oldObj)
+	}
+	newCluster, ok := newObj.(*mdbv1.AtlasCluster)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasCluster{}, newObj)
+	}
+	if !updateShouldBeValidated(newCluster, oldCluster.Spec, newCluster.Spec) {
+		return nil
+	}
+
+	if err := validate.ClusterSpec(newCluster.Spec); err != nil {
+		return err
+	}
+	// The update rules rely on the valid spec so they are checked only for the valid specs
+	if err := validate.ClusterSpec(oldCluster.Spec); err != nil {
+		return nil
+	}
+	return validate.ClusterUpdate(oldCluster, newCluster)
+}
+
+func (v *clusterValidator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+type projectValidator struct{}
+
+func (v *projectValidator) ValidateCreate(_ context.Context, obj runtime.Object) error {
+	project, ok := obj.(*mdbv1.AtlasProject)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasProject{}, obj)
+	}
+	return validate.Project(project)
+}
+
+func (v *projectValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) error {
+	oldProject, ok := oldObj.(*mdbv1.AtlasProject)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasProject{}, oldObj)
+	}
+	newProject, ok := newObj.(*mdbv1.AtlasProject)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasProject{}, newObj)
+	}
+	if !updateShouldBeValidated(newProject, oldProject.Spec, newProject.Spec) {
+		return nil
+	}
+
+	if err := validate.Project(newProject); err != nil {
+		return err
+	}
+	return validate.ProjectUpdate(oldProject, newProject)
+}
+
+func (v *projectValidator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+type databaseUserValidator struct{}
+
+func (v *databaseUserValidator) ValidateCreate(_ context.Context, obj runtime.Object) error {
+	user, ok := obj.(*mdbv1.AtlasDatabaseUser)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasDatabaseUser{}, obj)
+	}
+	return validate.DatabaseUser(user)
+}
+
+func (v *databaseUserValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) error {
+	oldUser, ok := oldObj.(*mdbv1.AtlasDatabaseUser)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasDatabaseUser{}, oldObj)
+	}
+	newUser, ok := newObj.(*mdbv1.AtlasDatabaseUser)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasDatabaseUser{}, newObj)
+	}
+	if !updateShouldBeValidated(newUser, oldUser.Spec, newUser.Spec) {
+		return nil
+	}
+	return validate.DatabaseUser(newUser)
+}
+
+func (v *databaseUserValidator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+type backupPolicyValidator struct{}
+
+func (v *backupPolicyValidator) ValidateCreate(_ context.Context, obj runtime.Object) error {
+	policy, ok := obj.(*mdbv1.AtlasBackupPolicy)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasBackupPolicy{}, obj)
+	}
+	return validate.BackupPolicy(policy)
+}
+
+func (v *backupPolicyValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) error {
+	oldPolicy, ok := oldObj.(*mdbv1.AtlasBackupPolicy)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasBackupPolicy{}, oldObj)
+	}
+	newPolicy, ok := newObj.(*mdbv1.AtlasBackupPolicy)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasBackupPolicy{}, newObj)
+	}
+	if !updateShouldBeValidated(newPolicy, oldPolicy.Spec, newPolicy.Spec) {
+		return nil
+	}
+	return validate.BackupPolicy(newPolicy)
+}
+
+func (v *backupPolicyValidator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+type backupScheduleValidator struct{}
+
+func (v *backupScheduleValidator) ValidateCreate(_ context.Context, obj runtime.Object) error {
+	schedule, ok := obj.(*mdbv1.AtlasBackupSchedule)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasBackupSchedule{}, obj)
+	}
+	return validate.BackupSchedule(schedule)
+}
+
+func (v *backupScheduleValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) error {
+	oldSchedule, ok := oldObj.(*mdbv1.AtlasBackupSchedule)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasBackupSchedule{}, oldObj)
+	}
+	newSchedule, ok := newObj.(*mdbv1.AtlasBackupSchedule)
+	if !ok {
+		return unexpectedTypeError(&mdbv1.AtlasBackupSchedule{}, newObj)
+	}
+	if !updateShouldBeValidated(newSchedule, oldSchedule.Spec, newSchedule.Spec) {
+		return nil
+	}
+	return validate.BackupSchedule(newSchedule)
+}
+
+func (v *backupScheduleValidator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
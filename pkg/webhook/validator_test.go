@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+)
+
+func TestClusterValidator(t *testing.T) {
+	v := &clusterValidator{}
+
+	t.Run("Invalid cluster is rejected on creation", func(t *testing.T) {
+		cluster := mdbv1.DefaultAWSCluster("default", "my-project").WithInstanceSize("")
+		assert.Error(t, v.ValidateCreate(context.Background(), cluster))
+	})
+
+	t.Run("Immutable field change is rejected", func(t *testing.T) {
+		oldCluster := mdbv1.DefaultAWSCluster("default", "my-project")
+		newCluster := mdbv1.DefaultAWSCluster("default", "my-project").WithAtlasName("other-name")
+		assert.Error(t, v.ValidateUpdate(context.Background(), oldCluster, newCluster))
+	})
+
+	t.Run("Metadata only change is allowed for invalid cluster", func(t *testing.T) {
+		oldCluster := mdbv1.DefaultAWSCluster("default", "my-project").WithInstanceSize("")
+		newCluster := oldCluster.DeepCopy()
+		newCluster.Annotations = map[string]string{"foo": "bar"}
+		assert.NoError(t, v.ValidateUpdate(context.Background(), oldCluster, newCluster))
+	})
+
+	t.Run("Cluster being deleted is not validated", func(t *testing.T) {
+		oldCluster := mdbv1.DefaultAWSCluster("default", "my-project")
+		newCluster := mdbv1.DefaultAWSCluster("default", "my-project").WithInstanceSize("")
+		now := metav1.Now()
+		newCluster.DeletionTimestamp = &now
+		assert.NoError(t, v.ValidateUpdate(context.Background(), oldCluster, newCluster))
+	})
+
+	t.Run("Unexpected type", func(t *testing.T) {
+		assert.Error(t, v.ValidateCreate(context.Background(), &mdbv1.AtlasProject{}))
+	})
+}
+
+func TestDatabaseUserValidator(t *testing.T) {
+	v := &databaseUserValidator{}
+
+	oldUser := mdbv1.DefaultDBUser("default", "theuser", "my-project")
+	newUser := oldUser.DeepCopy().WithRole("readWriteAnyDatabase", "test", "")
+	assert.Error(t, v.ValidateUpdate(context.Background(), oldUser, newUser))
+	assert.NoError(t, v.ValidateCreate(context.Background(), oldUser))
+}
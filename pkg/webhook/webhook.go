@@ -0,0 +1,37 @@
+// Package webhook contains the admission webhooks for the Atlas Custom Resources. The webhooks are served by the
+// Operator manager and require the TLS certificate (see 'config/webhook' and 'config/certmanager').
+package webhook
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	mdbv1 "github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1"
+)
+
+// SetupWithManager registers the validating and defaulting admission webhooks for all the Atlas Custom Resources in the manager
+func SetupWithManager(mgr ctrl.Manager) error {
+	webhooks := []struct {
+		apiType   runtime.Object
+		validator admission.CustomValidator
+		defaulter admission.CustomDefaulter
+	}{
+		{apiType: &mdbv1.AtlasCluster{}, validator: &clusterValidator{}, defaulter: &clusterDefaulter{}},
+		{apiType: &mdbv1.AtlasProject{}, validator: &projectValidator{}, defaulter: &projectDefaulter{}},
+		{apiType: &mdbv1.AtlasDatabaseUser{}, validator: &databaseUserValidator{}, defaulter: &databaseUserDefaulter{}},
+		{apiType: &mdbv1.AtlasBackupPolicy{}, validator: &backupPolicyValidator{}, defaulter: &backupPolicyDefaulter{}},
+		{apiType: &mdbv1.AtlasBackupSchedule{}, validator: &backupScheduleValidator{}},
+	}
+
+	for _, w := range webhooks {
+		builder := ctrl.NewWebhookManagedBy(mgr).For(w.apiType).WithValidator(w.validator)
+		if w.defaulter != nil {
+			builder = builder.WithDefaulter(w.defaulter)
+		}
+		if err := builder.Complete(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
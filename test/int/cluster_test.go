@@ -22,6 +22,7 @@ import (
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/project"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/api/v1/status"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlascluster"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/ownership"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/workflow"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/kube"
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/util/testutil"
@@ -179,6 +180,7 @@ var _ = Describe("AtlasCluster", Label("int", "AtlasCluster"), func() {
 
 			mergedCluster, err := atlascluster.MergedCluster(*atlasCluster, createdCluster.Spec)
 			Expect(err).ToNot(HaveOccurred())
+			mergedCluster.Labels = ownership.WithLabels(mergedCluster.Labels, createdCluster)
 
 			Expect(atlascluster.ClustersEqual(zap.S(), *atlasCluster, mergedCluster)).To(BeTrue())
 
@@ -195,6 +197,7 @@ var _ = Describe("AtlasCluster", Label("int", "AtlasCluster"), func() {
 
 			mergedCluster, err := atlascluster.MergedAdvancedCluster(*atlasCluster, createdCluster.Spec)
 			Expect(err).ToNot(HaveOccurred())
+			mergedCluster.Labels = ownership.WithLabels(mergedCluster.Labels, createdCluster)
 
 			Expect(atlascluster.AdvancedClustersEqual(zap.S(), *atlasCluster, mergedCluster)).To(BeTrue())
 
@@ -118,10 +118,12 @@ var _ = BeforeSuite(func() {
 			}),
 		}
 
+		atlasClients := atlas.NewClientCache(atlasDomain, logger.Named("atlas").Sugar())
+
 		err = (&atlasproject.AtlasProjectReconciler{
 			Client:           k8sManager.GetClient(),
 			Log:              logger.Named("controllers").Named("AtlasProject").Sugar(),
-			AtlasDomain:      atlasDomain,
+			AtlasClients:     atlasClients,
 			ResourceWatcher:  watch.NewResourceWatcher(),
 			GlobalPredicates: globalPredicates,
 			EventRecorder:    k8sManager.GetEventRecorderFor("AtlasProject"),
@@ -131,7 +133,7 @@ var _ = BeforeSuite(func() {
 		err = (&atlascluster.AtlasClusterReconciler{
 			Client:           k8sManager.GetClient(),
 			Log:              logger.Named("controllers").Named("AtlasCluster").Sugar(),
-			AtlasDomain:      atlasDomain,
+			AtlasClients:     atlasClients,
 			GlobalPredicates: globalPredicates,
 			EventRecorder:    k8sManager.GetEventRecorderFor("AtlasCluster"),
 		}).SetupWithManager(k8sManager)
@@ -140,7 +142,7 @@ var _ = BeforeSuite(func() {
 		err = (&atlasdatabaseuser.AtlasDatabaseUserReconciler{
 			Client:           k8sManager.GetClient(),
 			Log:              logger.Named("controllers").Named("AtlasCluster").Sugar(),
-			AtlasDomain:      atlasDomain,
+			AtlasClients:     atlasClients,
 			GlobalPredicates: globalPredicates,
 			EventRecorder:    k8sManager.GetEventRecorderFor("AtlasCluster"),
 			ResourceWatcher:  watch.NewResourceWatcher(),
@@ -22,6 +22,7 @@ import (
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/atlasdatabaseuser"
 
 	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/customresource"
+	"github.com/mongodb/mongodb-atlas-kubernetes/pkg/controller/ownership"
 
 	"go.mongodb.org/mongo-driver/mongo"
 
@@ -805,6 +806,7 @@ func checkUserInAtlas(projectID string, user mdbv1.AtlasDatabaseUser) {
 		Expect(err).ToNot(HaveOccurred())
 		operatorDBUser, err := user.ToAtlas(k8sClient)
 		Expect(err).ToNot(HaveOccurred())
+		operatorDBUser.Labels = ownership.WithLabels(operatorDBUser.Labels, &user)
 
 		Expect(*atlasDBUser).To(Equal(normalize(*operatorDBUser, projectID)))
 	})
@@ -213,10 +213,12 @@ func prepareControllers() {
 		}),
 	}
 
+	atlasClients := atlas.NewClientCache(atlasDomain, logger.Named("atlas").Sugar())
+
 	err = (&atlasproject.AtlasProjectReconciler{
 		Client:           k8sManager.GetClient(),
 		Log:              logger.Named("controllers").Named("AtlasProject").Sugar(),
-		AtlasDomain:      atlasDomain,
+		AtlasClients:     atlasClients,
 		ResourceWatcher:  watch.NewResourceWatcher(),
 		GlobalAPISecret:  kube.ObjectKey(namespace.Name, "atlas-operator-api-key"),
 		GlobalPredicates: globalPredicates,
@@ -227,7 +229,7 @@ func prepareControllers() {
 	err = (&atlascluster.AtlasClusterReconciler{
 		Client:           k8sManager.GetClient(),
 		Log:              logger.Named("controllers").Named("AtlasCluster").Sugar(),
-		AtlasDomain:      atlasDomain,
+		AtlasClients:     atlasClients,
 		ResourceWatcher:  watch.NewResourceWatcher(),
 		GlobalAPISecret:  kube.ObjectKey(namespace.Name, "atlas-operator-api-key"),
 		GlobalPredicates: globalPredicates,
@@ -238,7 +240,7 @@ func prepareControllers() {
 	err = (&atlasdatabaseuser.AtlasDatabaseUserReconciler{
 		Client:           k8sManager.GetClient(),
 		Log:              logger.Named("controllers").Named("AtlasDatabaseUser").Sugar(),
-		AtlasDomain:      atlasDomain,
+		AtlasClients:     atlasClients,
 		EventRecorder:    k8sManager.GetEventRecorderFor("AtlasDatabaseUser"),
 		ResourceWatcher:  watch.NewResourceWatcher(),
 		GlobalAPISecret:  kube.ObjectKey(namespace.Name, "atlas-operator-api-key"),